@@ -2,6 +2,8 @@ package main
 
 import (
 	_ "embed"
+	"errors"
+	"flag"
 	"image/color"
 	"log"
 	"math"
@@ -9,6 +11,7 @@ import (
 	"runtime"
 
 	"example.com/menu/internals/charts"
+	"example.com/menu/internals/charts/core"
 	"example.com/menu/internals/textwrapper"
 	"github.com/hajimehoshi/ebiten/v2"
 )
@@ -51,6 +54,10 @@ var filePathTxt string
 const Assets_Relative_Path = "../../"
 
 func main() {
+	data := flag.String("data", "", "CSV or JSON file to chart instead of the generated data, e.g. assets/data/sales.csv")
+	x := flag.String("x", "", "column of the x values in the data file")
+	decimalComma := flag.Bool("decimal-comma", false, "read 1,5 as 1.5 in the data file")
+	flag.Parse()
 
 	_, filePathTxt, _, _ = runtime.Caller(0)
 
@@ -107,6 +114,17 @@ func main() {
 		TextWrapper: textWrapper,
 	}
 
+	if *data != "" {
+		m, err := core.LoadFile(*data, core.LoadOptions{X: *x, DecimalComma: *decimalComma})
+		var malformed core.RowErrors
+		if errors.As(err, &malformed) {
+			log.Printf("%s: %v", *data, err)
+		} else if err != nil {
+			log.Fatal(err)
+		}
+		barGraph.Model, plotlineGraph.Model = m, m
+	}
+
 	game := &Game{barGraph: barGraph, plotlineGraph: plotlineGraph}
 	ebiten.SetWindowSize(ScreenSize, ScreenSize)
 	ebiten.SetWindowTitle("Bar and Plotline Graphs")
@@ -0,0 +1,133 @@
+package main
+
+import (
+	"image"
+	"image/color"
+	"log"
+	"math"
+	"time"
+
+	"example.com/menu/internals/charts"
+	"example.com/menu/internals/charts/axis"
+	"example.com/menu/internals/charts/core"
+	"example.com/menu/internals/textwrapper"
+	"example.com/menu/internals/utils"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+)
+
+const (
+	screenWidth  = 1000
+	screenHeight = 800
+)
+
+type Game struct {
+	charts   []*charts.Chart03
+	timeline *core.Model
+}
+
+// spans are the time ranges of the timeline chart, keys 1 to 5
+var spans = []time.Duration{
+	90 * time.Second,
+	6 * time.Hour,
+	10 * 24 * time.Hour,
+	400 * 24 * time.Hour,
+	30 * 365 * 24 * time.Hour,
+}
+
+func timeline(span time.Duration) *core.Series {
+	start := time.Date(2024, 3, 15, 13, 7, 0, 0, time.Local)
+	s := &core.Series{Name: "load", LineWidth: 2}
+	for i := 0; i <= 200; i++ {
+		t := start.Add(span * time.Duration(i) / 200)
+		s.Append(float64(t.Unix()), 50+30*math.Sin(float64(i)/15)+10*math.Sin(float64(i)/3))
+	}
+	return s
+}
+
+func (g *Game) Update() error {
+	for i, key := range []ebiten.Key{ebiten.Key1, ebiten.Key2, ebiten.Key3, ebiten.Key4, ebiten.Key5} {
+		if inpututil.IsKeyJustPressed(key) {
+			s := timeline(spans[i])
+			s.Color = g.timeline.Series[0].Color
+			g.timeline.Series[0] = s
+		}
+	}
+	return nil
+}
+
+func (g *Game) Draw(screen *ebiten.Image) {
+	screen.Fill(color.RGBA{30, 32, 38, 255})
+	// Two small charts per row, the timeline takes the whole last row
+	w, h := screenWidth/2, (screenHeight-20)/3
+	for i, c := range g.charts {
+		x, y := (i%2)*w, (i/2)*h
+		rect := image.Rect(x, y, x+w, y+h)
+		if i == len(g.charts)-1 {
+			rect = image.Rect(0, y, screenWidth, y+h)
+		}
+		c.DrawPlotline(screen.SubImage(rect).(*ebiten.Image))
+	}
+	ebitenutil.DebugPrintAt(screen, "1-5 time span of the timeline: 90s, 6h, 10 days, 400 days, 30 years", 10, screenHeight-20)
+}
+
+func (g *Game) Layout(outsideWidth, outsideHeight int) (int, int) {
+	return screenWidth, screenHeight
+}
+
+func main() {
+	utils.InitGetFilepath()
+	tw, err := textwrapper.NewTextWrapper(utils.GetFilePath("assets/fonts/roboto_regularTTF.ttf"), 12, false)
+	if err != nil {
+		log.Fatal(err)
+	}
+	tw.Color = color.White
+
+	// Fractional data that used to be truncated to integers
+	var xs, ys []float64
+	for x := 0.0; x <= 2*math.Pi; x += 0.05 {
+		xs = append(xs, x)
+		ys = append(ys, 0.37*math.Sin(x))
+	}
+	fractional := core.NewModel(core.NewSeries("0.37 sin", xs, ys))
+	fractional.Legend.Position = core.LegendBottomLeft
+
+	// Large values with SI suffixes, the y axis as percentages
+	var downloads, share []float64
+	for i := 0; i < 24; i++ {
+		downloads = append(downloads, 1e5*math.Pow(1.25, float64(i)))
+		share = append(share, 0.05+0.9*float64(i)/23)
+	}
+	si := core.NewModel(core.SeriesFromValues("downloads", downloads))
+	si.Y = core.AxisRange{IncludeZero: true, Scale: &axis.Linear{Format: axis.SI(1)}}
+	percent := core.NewModel(&core.Series{Name: "market share", Points: core.SeriesFromValues("", share).Points, Marker: core.MarkerCircle})
+	percent.Y = core.AxisRange{Fixed: true, Min: 0, Max: 1, Scale: &axis.Linear{Format: axis.Percent(0)}}
+
+	// The same downloads on a log axis
+	logarithmic := core.NewModel(core.SeriesFromValues("downloads", downloads))
+	logarithmic.Y.Scale = &axis.Log{}
+
+	timelineModel := core.NewModel(timeline(spans[2]))
+	timelineModel.X.Scale = &axis.Time{}
+
+	axisColor := color.RGBA{200, 200, 200, 255}
+	chart := func(m *core.Model, xLabel, yLabel string) *charts.Chart03 {
+		return &charts.Chart03{Model: m, XLabel: xLabel, YLabel: yLabel, NumXTicks: 8, NumYTicks: 6, OffsetX: 60, OffsetY: 40, AxisColor: axisColor, TextWrapper: tw}
+	}
+	game := &Game{
+		charts: []*charts.Chart03{
+			chart(fractional, "rad", "fractional"),
+			chart(si, "month", "SI suffixes"),
+			chart(logarithmic, "month", "log"),
+			chart(percent, "month", "percent"),
+			chart(timelineModel, "", "time"),
+		},
+		timeline: timelineModel,
+	}
+	ebiten.SetWindowSize(screenWidth, screenHeight)
+	ebiten.SetWindowTitle("Chart Axes")
+	if err := ebiten.RunGame(game); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,185 @@
+package main
+
+import (
+	"image/color"
+	"log"
+	"math"
+	"math/rand"
+
+	"example.com/menu/cmd/responsive05/responsive"
+	"example.com/menu/internals/charts"
+	"example.com/menu/internals/charts/axis"
+	"example.com/menu/internals/charts/core"
+	"example.com/menu/internals/layout"
+	"example.com/menu/internals/textwrapper"
+	"example.com/menu/internals/utils"
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+const (
+	// stripHeight is the bottom part of the window with the sparklines
+	stripHeight = 260
+	// updateTicks is how often the figures change, 60 ticks are a second
+	updateTicks = 30
+)
+
+// gauge is a sparkline of the strip with its name
+type gauge struct {
+	name      string
+	sparkline *charts.Sparkline
+	value     float64
+}
+
+type Game struct {
+	tiles   []*charts.KPITile
+	gauges  []*gauge
+	manager *responsive.LayoutManager
+	tw      *textwrapper.TextWrapper
+	width   int
+	height  int
+	tick    int
+}
+
+func (g *Game) Update() error {
+	g.tick++
+	if g.tick%updateTicks != 0 {
+		return nil
+	}
+	for _, t := range g.tiles {
+		t.SetValue(math.Max(0, t.Value*(1+rand.NormFloat64()*0.04)))
+	}
+	for _, gg := range g.gauges {
+		gg.value = math.Max(0, math.Min(100, gg.value+rand.NormFloat64()*6))
+		gg.sparkline.Append(gg.value)
+	}
+	return nil
+}
+
+// arrange gives the tiles the cells of a grid and the sparklines the positions of
+// the layout manager. The manager picks the mode from the width, the grid follows it.
+func (g *Game) arrange(width, height int) {
+	elements := make([]string, len(g.gauges))
+	for i, gg := range g.gauges {
+		elements[i] = gg.name
+	}
+
+	columns := 1
+	switch g.manager.DetermineLayout(width) {
+	case responsive.LayoutHorizontal:
+		columns = 4
+	case responsive.LayoutGrid:
+		columns = 2
+	}
+	top := max(height-stripHeight, 100)
+	rows := (len(g.tiles) + columns - 1) / columns
+	grid := layout.NewGrid(rows, columns, width, top, 10, 10, 0, false)
+	for i, t := range g.tiles {
+		cell := grid.Cells[i/columns][i%columns]
+		t.SetBounds(float32(cell.X), float32(cell.Y), float32(cell.Width), float32(cell.Height))
+	}
+
+	positions := g.manager.CalculatePositions(width, stripHeight, elements)
+	for _, gg := range g.gauges {
+		pos := positions[gg.name]
+		gg.sparkline.SetBounds(float32(pos.X), float32(top+pos.Y), float32(pos.Width), float32(pos.Height))
+	}
+}
+
+func (g *Game) Draw(screen *ebiten.Image) {
+	screen.Fill(color.RGBA{30, 32, 38, 255})
+	for _, t := range g.tiles {
+		t.Draw(screen)
+	}
+	for _, gg := range g.gauges {
+		x, y, _, _ := gg.sparkline.Bounds()
+		g.tw.DrawText(screen, gg.name, float64(x), float64(y)-16)
+		gg.sparkline.Draw(screen)
+	}
+}
+
+func (g *Game) Layout(outsideWidth, outsideHeight int) (int, int) {
+	if outsideWidth != g.width || outsideHeight != g.height {
+		g.width, g.height = outsideWidth, outsideHeight
+		g.arrange(outsideWidth, outsideHeight)
+	}
+	return outsideWidth, outsideHeight
+}
+
+// walk is a random history of n values around start
+func walk(start float64, n int) []float64 {
+	values := make([]float64, n)
+	v := start
+	for i := range values {
+		v = math.Max(0, v*(1+rand.NormFloat64()*0.04))
+		values[i] = v
+	}
+	return values
+}
+
+func main() {
+	utils.InitGetFilepath()
+	tw, err := textwrapper.NewTextWrapper(utils.GetFilePath("assets/fonts/roboto_regularTTF.ttf"), 13, false)
+	if err != nil {
+		log.Fatal(err)
+	}
+	tw.Color = color.RGBA{190, 190, 190, 255}
+	numberText, err := textwrapper.NewTextWrapper(utils.GetFilePath("assets/fonts/roboto_regularTTF.ttf"), 28, false)
+	if err != nil {
+		log.Fatal(err)
+	}
+	numberText.Color = color.White
+
+	tileColor, border := color.RGBA{42, 45, 53, 255}, color.RGBA{70, 74, 84, 255}
+	tile := func(label string, start float64) *charts.KPITile {
+		t := charts.NewKPITile(0, 0, 0, 0, label, walk(start, 40), tw, numberText)
+		t.Background, t.BorderColor = tileColor, border
+		t.Sparkline.MaxValues = 40
+		return t
+	}
+	players := tile("Players online", 12000)
+	players.Sparkline.Fill = true
+	players.Sparkline.Markers = charts.MarkMin | charts.MarkMax | charts.MarkLast
+	latency := tile("Latency", 48)
+	latency.Format = func(v float64) string { return axis.Fixed(0)(v) + " ms" }
+	latency.LowerIsBetter, latency.DeltaPercent = true, true
+	revenue := tile("Revenue today", 8400)
+	revenue.Format = func(v float64) string { return "$" + axis.SI(1)(v) }
+	revenue.Sparkline.Bars = true
+	crashes := tile("Crashes per hour", 3)
+	crashes.LowerIsBetter = true
+	crashes.Sparkline.Color = color.RGBA{251, 188, 5, 255}
+
+	var gauges []*gauge
+	for i, name := range []string{"CPU", "GPU", "Network", "Memory"} {
+		s := charts.NewSparkline(0, 0, 0, 0, nil)
+		s.MaxValues = 60
+		s.Color = core.DefaultPalette[i%len(core.DefaultPalette)]
+		s.Range = core.FixedRange(0, 100)
+		s.Markers = charts.MarkMax | charts.MarkLast
+		gg := &gauge{name: name, sparkline: s, value: 30 + float64(i)*10}
+		for j := 0; j < 60; j++ {
+			gg.value = math.Max(0, math.Min(100, gg.value+rand.NormFloat64()*6))
+			s.Append(gg.value)
+		}
+		gauges = append(gauges, gg)
+	}
+
+	breakpoints := []responsive.Breakpoint{
+		{Width: 1000, LayoutMode: responsive.LayoutHorizontal},
+		{Width: 600, LayoutMode: responsive.LayoutGrid},
+		{Width: 0, LayoutMode: responsive.LayoutVertical},
+	}
+	game := &Game{
+		tiles:   []*charts.KPITile{players, latency, revenue, crashes},
+		gauges:  gauges,
+		manager: responsive.NewLayoutManager(breakpoints),
+		tw:      tw,
+	}
+
+	ebiten.SetWindowSize(1100, 700)
+	ebiten.SetWindowResizingMode(ebiten.WindowResizingModeEnabled)
+	ebiten.SetWindowTitle("Dashboard")
+	if err := ebiten.RunGame(game); err != nil {
+		log.Fatal(err)
+	}
+}
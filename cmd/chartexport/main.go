@@ -0,0 +1,129 @@
+// chartexport draws a chart of CSV or JSON data into PNG and SVG files, without a window or a GPU.
+//
+//	go run .\cmd\chartexport\ -in sales.csv -kind bar -out sales.png -out sales.svg
+//
+// CSV and JSON data is read by core.LoadFile, -x and -y pick its columns.
+// Malformed rows are reported and left out.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"image/color"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"example.com/menu/internals/charts/core"
+)
+
+// outputs collects the repeated -out flags
+type outputs []string
+
+func (o *outputs) String() string {
+	return strings.Join(*o, ",")
+}
+
+func (o *outputs) Set(path string) error {
+	*o = append(*o, path)
+	return nil
+}
+
+func main() {
+	var outs outputs
+	in := flag.String("in", "", "CSV or JSON data file")
+	x := flag.String("x", "", "column of the x values, by name or number from 0, empty numbers the rows")
+	y := flag.String("y", "", "comma separated columns of the series, empty takes every numeric column")
+	comma := flag.String("comma", "", "CSV field separator, detected when empty")
+	decimalComma := flag.Bool("decimal-comma", false, "read 1,5 as 1.5")
+	kind := flag.String("kind", "line", "line, bar, stacked, area, scatter or pie")
+	width := flag.Int("width", 800, "width in pixels at 96 DPI")
+	height := flag.Int("height", 500, "height in pixels at 96 DPI")
+	dpi := flag.Float64("dpi", 96, "resolution of PNG files, the layout stays the same")
+	xLabel := flag.String("xlabel", "", "label of the x axis")
+	yLabel := flag.String("ylabel", "", "label of the y axis")
+	font := flag.String("font", "", "TrueType font for the labels, Go Regular when empty")
+	dark := flag.Bool("dark", false, "light text on a dark background, like in game")
+	flag.Var(&outs, "out", "output file, .png or .svg, may be repeated")
+	flag.Parse()
+	if *in == "" || len(outs) == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	load := core.LoadOptions{X: *x, DecimalComma: *decimalComma}
+	if *y != "" {
+		load.Y = strings.Split(*y, ",")
+	}
+	if *comma != "" {
+		load.Comma = []rune(*comma)[0]
+	}
+	m, err := core.LoadFile(*in, load)
+	var malformed core.RowErrors
+	if errors.As(err, &malformed) {
+		for _, row := range malformed {
+			log.Printf("%s: skipped %v", *in, row)
+		}
+	} else if err != nil {
+		log.Fatal(err)
+	}
+	renderer, err := rendererFor(*kind)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	m.Y.Padding = 0.05
+	opts := core.ExportOptions{Width: *width, Height: *height, DPI: *dpi, FontFile: *font, Background: color.White, TextColor: color.Black}
+	chart := &core.Chart{Model: m, XLabel: *xLabel, YLabel: *yLabel, NumXTicks: 8, NumYTicks: 6, OffsetX: 60, OffsetY: 45, AxisColor: color.RGBA{80, 80, 80, 255}}
+	if *dark {
+		opts.Background, opts.TextColor = color.RGBA{30, 32, 38, 255}, color.White
+		chart.AxisColor = color.RGBA{200, 200, 200, 255}
+	}
+
+	for _, path := range outs {
+		if err := export(chart, renderer, path, opts); err != nil {
+			log.Fatal(err)
+		}
+		fmt.Println("wrote", path)
+	}
+}
+
+func rendererFor(kind string) (core.Renderer, error) {
+	switch kind {
+	case "line":
+		return &core.LineRenderer{}, nil
+	case "bar":
+		return &core.BarRenderer{}, nil
+	case "stacked":
+		return &core.BarRenderer{Stacked: true}, nil
+	case "area":
+		return &core.AreaRenderer{}, nil
+	case "scatter":
+		return &core.ScatterRenderer{}, nil
+	case "pie":
+		return &core.PieRenderer{}, nil
+	}
+	return nil, fmt.Errorf("unknown chart kind %q", kind)
+}
+
+func export(chart *core.Chart, r core.Renderer, path string, opts core.ExportOptions) error {
+	write := chart.ExportPNG
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".png":
+	case ".svg":
+		write = chart.ExportSVG
+	default:
+		return fmt.Errorf("%s: unknown output format, use .png or .svg", path)
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	if err := write(f, r, opts); err != nil {
+		return err
+	}
+	return f.Close()
+}
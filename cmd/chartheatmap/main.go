@@ -0,0 +1,149 @@
+package main
+
+import (
+	"fmt"
+	"image/color"
+	"log"
+	"math"
+	"math/rand"
+
+	"example.com/menu/internals/charts"
+	"example.com/menu/internals/charts/axis"
+	"example.com/menu/internals/textwrapper"
+	"example.com/menu/internals/utils"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+)
+
+const (
+	screenWidth  = 1200
+	screenHeight = 760
+	// matrixSize is the side of the profiling matrix
+	matrixSize = 500
+)
+
+var background = color.RGBA{30, 32, 38, 255}
+
+type Game struct {
+	profile  *charts.Heatmap
+	activity *charts.Heatmap
+	terrain  *charts.Heatmap
+	phase    float64
+}
+
+func (g *Game) heatmaps() []*charts.Heatmap {
+	return []*charts.Heatmap{g.profile, g.activity, g.terrain}
+}
+
+func (g *Game) Update() error {
+	switch {
+	case inpututil.IsKeyJustPressed(ebiten.Key1):
+		g.profile.Scale = &charts.Sequential{}
+		g.profile.Invalidate()
+	case inpututil.IsKeyJustPressed(ebiten.Key2):
+		g.profile.Scale = &charts.Sequential{Colors: []color.RGBA{{8, 29, 88, 255}, {65, 182, 196, 255}, {255, 255, 217, 255}}}
+		g.profile.Invalidate()
+	case inpututil.IsKeyJustPressed(ebiten.Key3):
+		g.profile.Scale = &charts.Diverging{Center: 16.7}
+		g.profile.Invalidate()
+	case inpututil.IsKeyJustPressed(ebiten.KeyR):
+		// A new matrix every press, the cells are colored and uploaded once
+		g.phase += 0.7
+		g.profile.SetValues(frameTimes(g.phase))
+	}
+	for _, h := range g.heatmaps() {
+		h.Update(0, 0, false)
+	}
+	return nil
+}
+
+func (g *Game) Draw(screen *ebiten.Image) {
+	screen.Fill(background)
+	for _, h := range g.heatmaps() {
+		h.Draw(screen)
+	}
+	status := "1 viridis  2 custom ramp  3 diverging around 16.7 ms  R new profile"
+	for _, h := range g.heatmaps() {
+		if row, col, ok := h.Hovered(); ok {
+			status = fmt.Sprintf("row %d, column %d  %s", row, col, status)
+		}
+	}
+	ebitenutil.DebugPrintAt(screen, fmt.Sprintf("%s  TPS %.0f", status, ebiten.ActualTPS()), 10, screenHeight-20)
+}
+
+func (g *Game) Layout(outsideWidth, outsideHeight int) (int, int) {
+	return screenWidth, screenHeight
+}
+
+// frameTimes is a made up frame time in milliseconds for every camera position of a level
+func frameTimes(phase float64) [][]float64 {
+	values := make([][]float64, matrixSize)
+	for r := range values {
+		values[r] = make([]float64, matrixSize)
+		for c := range values[r] {
+			x, y := float64(c)/matrixSize, float64(r)/matrixSize
+			hotspot := math.Exp(-((x-0.3)*(x-0.3)+(y-0.6)*(y-0.6))*40) * 14
+			values[r][c] = 12 + 4*math.Sin(x*9+phase)*math.Cos(y*7) + hotspot + rand.Float64()*0.8
+		}
+	}
+	return values
+}
+
+func main() {
+	utils.InitGetFilepath()
+	tw, err := textwrapper.NewTextWrapper(utils.GetFilePath("assets/fonts/roboto_regularTTF.ttf"), 12, false)
+	if err != nil {
+		log.Fatal(err)
+	}
+	tw.Color = color.White
+
+	profile := charts.NewHeatmap(10, 10, 640, 600, frameTimes(0), tw)
+	profile.ColorBarLabel = "ms"
+	profile.Format = axis.Fixed(1)
+
+	days := []string{"Mon", "Tue", "Wed", "Thu", "Fri", "Sat", "Sun"}
+	hours := make([]string, 24)
+	players := make([][]float64, len(days))
+	for r := range players {
+		players[r] = make([]float64, len(hours))
+		for c := range hours {
+			hours[c] = fmt.Sprintf("%02d", c)
+			evening := math.Exp(-math.Pow(float64(c)-20, 2) / 18)
+			weekend := 1.0
+			if r >= 5 {
+				weekend = 1.6
+			}
+			players[r][c] = math.Round((200 + 1800*evening*weekend) * (0.9 + rand.Float64()*0.2))
+		}
+	}
+	// The servers were down on Wednesday night
+	players[2][3], players[2][4] = math.NaN(), math.NaN()
+	activity := charts.NewHeatmap(660, 10, 530, 300, players, tw)
+	activity.RowLabels, activity.ColLabels = days, hours
+	activity.ColorBarLabel = "players"
+	activity.Format = axis.Fixed(0)
+	activity.CellMargin, activity.Background = 2, background
+	activity.NaNColor = color.RGBA{70, 70, 70, 255}
+
+	terrain := make([][]float64, 24)
+	for r := range terrain {
+		terrain[r] = make([]float64, 32)
+		for c := range terrain[r] {
+			height := math.Sin(float64(c)/5) + math.Cos(float64(r)/4) + rand.Float64()*0.4
+			terrain[r][c] = math.Max(0, math.Min(3, math.Floor(height+1.6)))
+		}
+	}
+	tiles := charts.NewHeatmap(660, 320, 530, 400, terrain, tw)
+	tiles.Scale = &charts.Categorical{
+		Colors: []color.RGBA{{52, 120, 200, 255}, {230, 210, 140, 255}, {80, 160, 70, 255}, {130, 130, 130, 255}},
+		Labels: []string{"water", "sand", "grass", "rock"},
+	}
+	tiles.CellMargin, tiles.Background = 1, background
+
+	ebiten.SetWindowSize(screenWidth, screenHeight)
+	ebiten.SetWindowTitle("Heatmaps")
+	if err := ebiten.RunGame(&Game{profile: profile, activity: activity, terrain: tiles}); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"log"
+	"math"
+	"math/rand"
+
+	"example.com/menu/internals/charts"
+	"example.com/menu/internals/charts/core"
+	"example.com/menu/internals/navigator"
+	"example.com/menu/internals/page"
+	"example.com/menu/internals/textwrapper"
+	"example.com/menu/internals/utils"
+	"example.com/menu/internals/widgets"
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+const (
+	screenWidth  = 1000
+	screenHeight = 700
+)
+
+type Game struct {
+	navigator *navigator.Navigator
+}
+
+func (g *Game) Update() error {
+	_, err := g.navigator.Update(0, 0)
+	return err
+}
+
+func (g *Game) Draw(screen *ebiten.Image) {
+	g.navigator.Draw(screen, image.Rect(0, 0, screenWidth, screenHeight))
+}
+
+func (g *Game) Layout(outsideWidth, outsideHeight int) (int, int) {
+	return screenWidth, screenHeight
+}
+
+func describe(selected []charts.SelectedPoint) string {
+	switch len(selected) {
+	case 0:
+		return "Nothing selected"
+	case 1:
+		p := selected[0]
+		return fmt.Sprintf("Selected %s at x %.2f, y %.2f", p.Series.Name, p.Point.X, p.Point.Y)
+	}
+	return fmt.Sprintf("Selected %d points", len(selected))
+}
+
+func newChartPage(nav *navigator.Navigator, tw, buttonText *textwrapper.TextWrapper, depth int) *page.BasePage {
+	p := page.NewBasePage(color.RGBA{30, 32, 38, 255}, fmt.Sprintf("Charts %d", depth), tw, 0, 0, screenWidth, screenHeight)
+	p.Message = "Hover, wheel zoom, drag pan, right drag box zoom, Shift drag select, double click reset, click legend"
+
+	var xs, signal, noisy, trend []float64
+	for i := 0; i <= 120; i++ {
+		x := float64(i) / 10
+		xs = append(xs, x)
+		signal = append(signal, math.Sin(x)*10)
+		noisy = append(noisy, math.Sin(x)*10+rand.NormFloat64()*2)
+		trend = append(trend, x-6)
+	}
+	measured := core.NewSeries("measured", xs, noisy)
+	measured.Line = core.LineNone
+	measured.Marker = core.MarkerCircle
+	measured.MarkerSize = 4
+	model := core.NewModel(core.NewSeries("signal", xs, signal), measured, core.NewSeries("trend", xs, trend))
+	model.Y.Padding = 0.1
+
+	axis := color.RGBA{200, 200, 200, 255}
+	line := charts.NewInteractiveChart(40, 40, 600, 400, &charts.Chart03{
+		Model: model, XLabel: "t", YLabel: "value", NumXTicks: 8, NumYTicks: 8,
+		OffsetX: 50, OffsetY: 40, AxisColor: axis, TextWrapper: tw,
+	}, &charts.LineRenderer{})
+	line.OnSelectionChanged = func(selected []charts.SelectedPoint) { p.Message = describe(selected) }
+	line.OnViewChanged = func(xMin, xMax, yMin, yMax float64) {
+		p.Message = fmt.Sprintf("View x %.2f..%.2f  y %.2f..%.2f", xMin, xMax, yMin, yMax)
+	}
+	p.AddUIelement(line)
+
+	sales := core.NewModel(
+		core.SeriesFromValues("2023", []float64{4.2, 7.5, 3.8, 6.1, 9.4}),
+		core.SeriesFromValues("2024", []float64{5.0, -2.8, 4.9, 7.7, 8.1}),
+	)
+	sales.Y.Padding = 0.1
+	bars := charts.NewInteractiveChart(650, 40, 330, 400, &charts.Chart03{
+		Model: sales, XLabel: "Q", YLabel: "sales", NumXTicks: 5, NumYTicks: 6,
+		OffsetX: 45, OffsetY: 40, AxisColor: axis, TextWrapper: tw,
+	}, &charts.BarRenderer{})
+	bars.OnSelectionChanged = func(selected []charts.SelectedPoint) { p.Message = describe(selected) }
+	p.AddUIelement(bars)
+
+	// The pages slide in and out, the charts must keep working at every offset
+	buttonColor := color.RGBA{70, 70, 70, 255}
+	p.AddUIelement(widgets.NewButtonStd(40, 470, 220, 36, "Push another page", buttonText, color.White, buttonColor, 16, func() {
+		nav.Push(newChartPage(nav, tw, buttonText, depth+1))
+	}))
+	p.AddUIelement(widgets.NewButtonStd(280, 470, 120, 36, "Pop", buttonText, color.White, buttonColor, 16, nav.Pop))
+	return p
+}
+
+func main() {
+	utils.InitGetFilepath()
+	tw, err := textwrapper.NewTextWrapper(utils.GetFilePath("assets/fonts/roboto_regularTTF.ttf"), 12, false)
+	if err != nil {
+		log.Fatal(err)
+	}
+	tw.Color = color.White
+	// Buttons set their own font size, the charts keep theirs on a separate wrapper
+	buttonText, err := textwrapper.NewTextWrapper(utils.GetFilePath("assets/fonts/roboto_regularTTF.ttf"), 16, false)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	nav := navigator.NewNavigator()
+	nav.Push(newChartPage(nav, tw, buttonText, 1))
+
+	ebiten.SetWindowSize(screenWidth, screenHeight)
+	ebiten.SetWindowTitle("Interactive Charts")
+	if err := ebiten.RunGame(&Game{navigator: nav}); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,151 @@
+package main
+
+import (
+	"image"
+	"image/color"
+	"log"
+	"math"
+	"math/rand"
+
+	"example.com/menu/internals/charts"
+	"example.com/menu/internals/charts/core"
+	"example.com/menu/internals/textwrapper"
+	"example.com/menu/internals/utils"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+)
+
+const (
+	screenWidth  = 1200
+	screenHeight = 760
+)
+
+// panel is one chart of the grid with the renderer it is drawn with
+type panel struct {
+	chart    *charts.Chart03
+	renderer charts.Renderer
+}
+
+type Game struct {
+	panels []panel
+	area   *charts.AreaRenderer
+	bars   *charts.BarRenderer
+	pie    *charts.PieRenderer
+}
+
+func (g *Game) Update() error {
+	switch {
+	case inpututil.IsKeyJustPressed(ebiten.KeyS):
+		g.area.Stacked = !g.area.Stacked
+	case inpututil.IsKeyJustPressed(ebiten.KeyB):
+		g.bars.Stacked = !g.bars.Stacked
+	case inpututil.IsKeyJustPressed(ebiten.KeyD):
+		if g.pie.Hole > 0 {
+			g.pie.Hole = 0
+		} else {
+			g.pie.Hole = 0.55
+		}
+	}
+	return nil
+}
+
+func (g *Game) Draw(screen *ebiten.Image) {
+	screen.Fill(color.RGBA{30, 32, 38, 255})
+	w, h := screenWidth/3, (screenHeight-20)/2
+	for i, p := range g.panels {
+		x, y := (i%3)*w, (i/3)*h
+		p.chart.Draw(screen.SubImage(image.Rect(x, y, x+w, y+h)).(*ebiten.Image), p.renderer, nil)
+	}
+	ebitenutil.DebugPrintAt(screen, "S stacked area  B grouped / stacked bars  D pie / donut", 10, screenHeight-20)
+}
+
+func (g *Game) Layout(outsideWidth, outsideHeight int) (int, int) {
+	return screenWidth, screenHeight
+}
+
+func cluster(name string, cx, cy, spread float64, n int, marker core.MarkerShape) *core.Series {
+	s := &core.Series{Name: name, Marker: marker}
+	for i := 0; i < n; i++ {
+		s.Append(cx+rand.NormFloat64()*spread, cy+rand.NormFloat64()*spread)
+	}
+	return s
+}
+
+func main() {
+	utils.InitGetFilepath()
+	tw, err := textwrapper.NewTextWrapper(utils.GetFilePath("assets/fonts/roboto_regularTTF.ttf"), 12, false)
+	if err != nil {
+		log.Fatal(err)
+	}
+	tw.Color = color.White
+
+	scatter := core.NewModel(
+		cluster("players", 2, 3, 0.8, 120, core.MarkerCircle),
+		cluster("bots", 5, 1.5, 0.6, 80, core.MarkerTriangle),
+		cluster("admins", 4, 5, 0.3, 15, core.MarkerSquare),
+	)
+	scatter.X.Padding, scatter.Y.Padding = 0.05, 0.05
+
+	var hours []float64
+	for h := 0.0; h <= 24; h++ {
+		hours = append(hours, h)
+	}
+	traffic := func(name string, peak, height float64) *core.Series {
+		ys := make([]float64, len(hours))
+		for i, h := range hours {
+			ys[i] = height * math.Exp(-math.Pow(h-peak, 2)/18)
+		}
+		return core.NewSeries(name, hours, ys)
+	}
+	area := core.NewModel(traffic("Europe", 19, 40), traffic("America", 2, 30), traffic("Asia", 12, 25))
+	area.Legend.Position = core.LegendTopLeft
+
+	// Profit per quarter, some of it negative
+	bars := core.NewModel(
+		core.SeriesFromValues("shop", []float64{12, -4, 8, 15}),
+		core.SeriesFromValues("ads", []float64{5, 6, -3, 9}),
+		core.SeriesFromValues("events", []float64{-2, 3, 4, -6}),
+	)
+	bars.Y.Padding = 0.1
+	bars.Legend.Position = core.LegendBottomRight
+
+	platforms := core.NewModel(
+		core.SeriesFromValues("Windows", []float64{61}),
+		core.SeriesFromValues("macOS", []float64{18}),
+		core.SeriesFromValues("Linux", []float64{12}),
+		core.SeriesFromValues("Steam Deck", []float64{7}),
+		core.SeriesFromValues("Other", []float64{2}),
+	)
+	platforms.Legend.Position = core.LegendBottomLeft
+
+	sessions := make([]float64, 2000)
+	for i := range sessions {
+		sessions[i] = 35 + 12*rand.NormFloat64()
+	}
+	histogram := core.NewModel(core.NewHistogram("session minutes", sessions, 0))
+
+	axisColor := color.RGBA{200, 200, 200, 255}
+	chart := func(m *core.Model, xLabel, yLabel string) *charts.Chart03 {
+		return &charts.Chart03{Model: m, XLabel: xLabel, YLabel: yLabel, NumXTicks: 6, NumYTicks: 6, OffsetX: 50, OffsetY: 40, AxisColor: axisColor, TextWrapper: tw}
+	}
+
+	g := &Game{
+		area: &charts.AreaRenderer{},
+		bars: &charts.BarRenderer{},
+		pie:  &charts.PieRenderer{Explode: map[string]float32{"Linux": 12}, TextWrapper: tw},
+	}
+	g.panels = []panel{
+		{chart(scatter, "x", "scatter"), &charts.ScatterRenderer{}},
+		{chart(area, "hour", "area"), g.area},
+		{chart(bars, "quarter", "bars"), g.bars},
+		{chart(platforms, "", "pie"), g.pie},
+		{chart(histogram, "minutes", "histogram"), &charts.BarRenderer{Gutter: 1}},
+		{chart(core.NewModel(core.NewHistogram("20 bins", sessions, 20)), "minutes", "fixed bins"), &charts.BarRenderer{Gutter: 0.9}},
+	}
+	ebiten.SetWindowSize(screenWidth, screenHeight)
+	ebiten.SetWindowTitle("Chart Types")
+	if err := ebiten.RunGame(g); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,162 @@
+package main
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"log"
+	"math"
+	"math/rand"
+
+	"example.com/menu/internals/charts"
+	"example.com/menu/internals/charts/core"
+	"example.com/menu/internals/textwrapper"
+	"example.com/menu/internals/utils"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+)
+
+const (
+	screenWidth  = 1200
+	screenHeight = 760
+)
+
+type Game struct {
+	charts   []*charts.Chart03
+	lines    *charts.LineRenderer
+	signal   *core.Series
+	step     *core.Series
+	stepMode int
+}
+
+var stepModes = []core.Interpolation{core.StepAfter, core.StepBefore, core.StepMiddle}
+var stepNames = []string{"after", "before", "middle"}
+
+func (g *Game) Update() error {
+	switch {
+	case inpututil.IsKeyJustPressed(ebiten.KeyA):
+		g.lines.Crisp = !g.lines.Crisp
+	case inpututil.IsKeyJustPressed(ebiten.KeyL):
+		if g.lines.MaxPoints < 0 {
+			g.lines.MaxPoints = 0
+		} else {
+			g.lines.MaxPoints = -1
+		}
+	case inpututil.IsKeyJustPressed(ebiten.KeyS):
+		g.stepMode = (g.stepMode + 1) % len(stepModes)
+		g.step.Interpolation = stepModes[g.stepMode]
+		g.step.Name = "step " + stepNames[g.stepMode]
+	}
+	return nil
+}
+
+func (g *Game) Draw(screen *ebiten.Image) {
+	screen.Fill(color.RGBA{30, 32, 38, 255})
+	w, h := screenWidth/2, (screenHeight-20)/2
+	for i, c := range g.charts {
+		x, y := (i%2)*w, (i/2)*h
+		c.Draw(screen.SubImage(image.Rect(x, y, x+w, y+h)).(*ebiten.Image), g.lines, nil)
+	}
+	decimation := "LTTB"
+	if g.lines.MaxPoints < 0 {
+		decimation = "all points"
+	}
+	ebitenutil.DebugPrintAt(screen, fmt.Sprintf("A anti-aliasing: %v  L decimation: %s (%d points)  S step mode  %.0f FPS",
+		!g.lines.Crisp, decimation, len(g.signal.Points), ebiten.ActualFPS()), 10, screenHeight-20)
+}
+
+func (g *Game) Layout(outsideWidth, outsideHeight int) (int, int) {
+	return screenWidth, screenHeight
+}
+
+// zigzag is a sharp line so the joins show
+func zigzag(name string, offset float64) *core.Series {
+	s := &core.Series{Name: name}
+	for i := 0; i <= 8; i++ {
+		s.Append(float64(i), offset+float64(i%2)*2)
+	}
+	return s
+}
+
+func main() {
+	utils.InitGetFilepath()
+	tw, err := textwrapper.NewTextWrapper(utils.GetFilePath("assets/fonts/roboto_regularTTF.ttf"), 12, false)
+	if err != nil {
+		log.Fatal(err)
+	}
+	tw.Color = color.White
+
+	round, miter, bevel := zigzag("round 8px", 8), zigzag("miter 8px", 4), zigzag("bevel 8px", 0)
+	round.LineWidth, miter.LineWidth, bevel.LineWidth = 8, 8, 8
+	miter.Join, bevel.Join = core.JoinMiter, core.JoinBevel
+	dashed := zigzag("dashed", -4)
+	dashed.Line, dashed.LineWidth = core.LineDashed, 2
+	dotted := zigzag("dotted", -8)
+	dotted.Line, dotted.LineWidth = core.LineDotted, 3
+	custom := zigzag("dash-dot", -12)
+	custom.Dash, custom.LineWidth = []float32{14, 4, 2, 4}, 2
+	strokes := core.NewModel(round, miter, bevel, dashed, dotted, custom)
+	strokes.X.Padding, strokes.Y.Padding = 0.05, 0.1
+	strokes.Legend.Position = core.LegendBottomRight
+
+	// Daily players, the step line keeps each value until the next day
+	days := []float64{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}
+	players := []float64{120, 180, 150, 260, 240, 310, 200, 220, 330, 300}
+	filled := core.NewSeries("filled", days, players)
+	filled.Fill = true
+	step := core.NewSeries("step after", days, players)
+	step.Interpolation, step.LineWidth = core.StepAfter, 2
+	for i := range step.Points {
+		step.Points[i].Y -= 90
+	}
+	fills := core.NewModel(filled, step)
+	fills.Y.IncludeZero = true
+	fills.Legend.Position = core.LegendTopLeft
+
+	// Monotone curves pass through every point without overshooting
+	xs := []float64{0, 1, 2, 3, 3.5, 6, 7, 9, 10}
+	ys := []float64{0, 4, 4, 8, 2, 2, 9, 9, 5}
+	linear := core.NewSeries("linear", xs, ys)
+	linear.Line, linear.Marker = core.LineDashed, core.MarkerCircle
+	monotone := core.NewSeries("monotone", xs, ys)
+	monotone.Interpolation, monotone.LineWidth, monotone.Fill = core.Monotone, 2.5, true
+	curves := core.NewModel(linear, monotone)
+	curves.Y.Padding = 0.1
+	curves.Legend.Position = core.LegendBottomRight
+
+	signal := &core.Series{Name: "100k samples", LineWidth: 1.5}
+	value := 0.0
+	for i := 0; i < 100_000; i++ {
+		value += rand.NormFloat64()
+		spike := 0.0
+		if i%17_000 == 0 {
+			spike = 80
+		}
+		signal.Append(float64(i), value+20*math.Sin(float64(i)/4000)+spike)
+	}
+	large := core.NewModel(signal)
+	large.Legend.Position = core.LegendTopLeft
+
+	axisColor := color.RGBA{200, 200, 200, 255}
+	chart := func(m *core.Model, xLabel, yLabel string) *charts.Chart03 {
+		return &charts.Chart03{Model: m, XLabel: xLabel, YLabel: yLabel, NumXTicks: 6, NumYTicks: 6, OffsetX: 50, OffsetY: 40, AxisColor: axisColor, TextWrapper: tw}
+	}
+
+	g := &Game{
+		lines:  &charts.LineRenderer{},
+		signal: signal,
+		step:   step,
+		charts: []*charts.Chart03{
+			chart(strokes, "", "joins and dashes"),
+			chart(fills, "day", "players"),
+			chart(curves, "x", "interpolation"),
+			chart(large, "sample", "random walk"),
+		},
+	}
+	ebiten.SetWindowSize(screenWidth, screenHeight)
+	ebiten.SetWindowTitle("Chart Paths")
+	if err := ebiten.RunGame(g); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"image"
+	"image/color"
+	"log"
+	"math"
+
+	"example.com/menu/internals/charts"
+	"example.com/menu/internals/charts/core"
+	"example.com/menu/internals/textwrapper"
+	"example.com/menu/internals/utils"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+)
+
+const (
+	screenWidth  = 1000
+	screenHeight = 500
+)
+
+type Game struct {
+	lines *charts.Chart03
+	bars  *charts.Chart03
+}
+
+func (g *Game) Update() error {
+	m := g.lines.Model
+	switch {
+	case inpututil.IsKeyJustPressed(ebiten.KeyL):
+		m.Legend.Toggle()
+		g.bars.Model.Legend.Toggle()
+	case inpututil.IsKeyJustPressed(ebiten.KeyP):
+		m.Legend.Position = (m.Legend.Position + 1) % 4
+		g.bars.Model.Legend.Position = m.Legend.Position
+	case inpututil.IsKeyJustPressed(ebiten.KeyF):
+		// Toggle between a fixed window and a fit of the data
+		if m.Y.Fixed {
+			m.Y = core.AxisRange{Padding: 0.1}
+		} else {
+			m.Y = core.FixedRange(-0.5, 0.5)
+		}
+	}
+	for i, key := range []ebiten.Key{ebiten.Key1, ebiten.Key2, ebiten.Key3} {
+		if inpututil.IsKeyJustPressed(key) {
+			m.Series[i].Hidden = !m.Series[i].Hidden
+		}
+	}
+	return nil
+}
+
+func (g *Game) Draw(screen *ebiten.Image) {
+	screen.Fill(color.RGBA{30, 32, 38, 255})
+	half := screenWidth * 3 / 5
+	g.lines.DrawPlotline(screen.SubImage(image.Rect(0, 0, half, screenHeight)).(*ebiten.Image))
+	g.bars.DrawBars(screen.SubImage(image.Rect(half, 0, screenWidth, screenHeight)).(*ebiten.Image))
+	ebitenutil.DebugPrintAt(screen, "L legend  P legend corner  F fixed / auto y range  1-3 hide series", 10, screenHeight-20)
+}
+
+func (g *Game) Layout(outsideWidth, outsideHeight int) (int, int) {
+	return screenWidth, screenHeight
+}
+
+func main() {
+	utils.InitGetFilepath()
+	tw, err := textwrapper.NewTextWrapper(utils.GetFilePath("assets/fonts/roboto_regularTTF.ttf"), 12, false)
+	if err != nil {
+		log.Fatal(err)
+	}
+	tw.Color = color.White
+
+	var xs, sin, cos []float64
+	for x := 0.0; x <= 2*math.Pi; x += math.Pi / 16 {
+		xs = append(xs, x)
+		sin = append(sin, math.Sin(x))
+		cos = append(cos, 0.8*math.Cos(x))
+	}
+	samples := &core.Series{Name: "samples", Line: core.LineNone, Marker: core.MarkerTriangle, MarkerSize: 8}
+	for x := 0.25; x < 2*math.Pi; x += 0.75 {
+		samples.Append(x, math.Sin(x)+0.15*math.Sin(7*x))
+	}
+
+	wave := core.NewSeries("sin", xs, sin)
+	wave.LineWidth = 2
+	damped := core.NewSeries("0.8 cos", xs, cos)
+	damped.Line = core.LineDashed
+	damped.Marker = core.MarkerCircle
+	damped.MarkerSize = 5
+
+	lines := core.NewModel(wave, damped, samples)
+	lines.Y.Padding = 0.1
+
+	sales := core.NewModel(
+		core.SeriesFromValues("2023", []float64{4.2, 7.5, 3.8, 6.1, 9.4}),
+		core.SeriesFromValues("2024", []float64{5.0, 6.8, 4.9, 7.7, 8.1}),
+	)
+	sales.Legend.Position = core.LegendTopLeft
+	sales.Y.Padding = 0.1
+
+	axis := color.RGBA{200, 200, 200, 255}
+	game := &Game{
+		lines: &charts.Chart03{Model: lines, XLabel: "x", YLabel: "y", NumXTicks: 8, NumYTicks: 8, OffsetX: 50, OffsetY: 50, AxisColor: axis, TextWrapper: tw},
+		bars:  &charts.Chart03{Model: sales, XLabel: "Q", YLabel: "Sales", NumXTicks: 6, NumYTicks: 8, GutterWidth: 0.8, OffsetX: 50, OffsetY: 50, AxisColor: axis, TextWrapper: tw},
+	}
+	ebiten.SetWindowSize(screenWidth, screenHeight)
+	ebiten.SetWindowTitle("Multi-series Charts")
+	if err := ebiten.RunGame(game); err != nil {
+		log.Fatal(err)
+	}
+}
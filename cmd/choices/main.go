@@ -0,0 +1,129 @@
+package main
+
+import (
+	"fmt"
+	"image/color"
+	"log"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"example.com/menu/internals/textwrapper"
+	"example.com/menu/internals/widgets"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+)
+
+const (
+	screenWidth  = 640
+	screenHeight = 480
+	fontSize     = 18.0
+)
+
+type element interface {
+	Update(offsetX, offsetY float32, isAnimating bool)
+	Draw(screen *ebiten.Image)
+}
+
+type Game struct {
+	elements   []element
+	lastChange string
+}
+
+func (g *Game) Update() error {
+	for _, e := range g.elements {
+		e.Update(0, 0, false)
+	}
+	return nil
+}
+
+func (g *Game) Draw(screen *ebiten.Image) {
+	screen.Fill(color.RGBA{230, 230, 230, 255})
+	ebitenutil.DebugPrintAt(screen, "Click to focus, then arrows, Home/End, Space. Gray items are disabled.", 20, screenHeight-40)
+	ebitenutil.DebugPrintAt(screen, g.lastChange, 20, screenHeight-20)
+	for _, e := range g.elements {
+		e.Draw(screen)
+	}
+}
+
+func (g *Game) Layout(outsideWidth, outsideHeight int) (int, int) {
+	return screenWidth, screenHeight
+}
+
+func getFilePath(fileName string) string {
+	dir := filepath.Dir(filePathTxt)
+	return filepath.Join(dir, Assets_Relative_Path, fileName)
+}
+
+var filePathTxt string
+
+const Assets_Relative_Path = "../../"
+
+func main() {
+	_, filePathTxt, _, _ = runtime.Caller(0)
+	tw, err := textwrapper.NewTextWrapper(getFilePath("assets/fonts/roboto_regularTTF.ttf"), fontSize, false)
+	if err != nil {
+		log.Fatalf("Failed to create text wrapper: %v", err)
+	}
+
+	game := &Game{}
+
+	// A group of checkboxes sharing one Selection, with a tri-state "all" box on top
+	toppings := []string{"Cheese", "Tomato", "Olives", "Pineapple"}
+	toppingSelection := widgets.NewSelection(len(toppings), widgets.SelectMultiple)
+	toppingSelection.SetEnabled(3, false)
+	toppingSelection.OnChange = func(index int, selected bool) {
+		var names []string
+		for _, i := range toppingSelection.SelectedIndices() {
+			names = append(names, toppings[i])
+		}
+		game.lastChange = "Toppings: " + strings.Join(names, ", ")
+	}
+
+	all := widgets.NewCheckbox(40, 40, "All toppings", tw, fontSize)
+	all.BindAll(toppingSelection)
+	game.elements = append(game.elements, all)
+	for i, topping := range toppings {
+		cb := widgets.NewCheckbox(70, 80+float32(i)*36, topping, tw, fontSize)
+		cb.Bind(toppingSelection, i)
+		game.elements = append(game.elements, cb)
+	}
+
+	triState := widgets.NewCheckbox(40, 250, "Tri-state on its own", tw, fontSize)
+	triState.TriState = true
+	triState.OnChange = func(state widgets.CheckState) {
+		game.lastChange = fmt.Sprintf("Tri-state: %v", []string{"unchecked", "checked", "indeterminate"}[state])
+	}
+	game.elements = append(game.elements, triState)
+
+	difficulty := widgets.NewRadioGroup(340, 40, []string{"Easy", "Normal", "Hard", "Nightmare"}, tw, fontSize)
+	difficulty.SetOptionEnabled(3, false)
+	difficulty.SetSelectedIndex(1)
+	difficulty.Selection.OnChange = func(index int, selected bool) {
+		if selected {
+			game.lastChange = "Difficulty: " + difficulty.Options[index]
+		}
+	}
+	game.elements = append(game.elements, difficulty)
+
+	view := widgets.NewSegmentedControl(340, 250, []string{"Map", "List", "Grid"}, tw, fontSize)
+	view.Selection.OnChange = func(index int, selected bool) {
+		if selected {
+			game.lastChange = "View: " + view.Options[index]
+		}
+	}
+	game.elements = append(game.elements, view)
+
+	days := widgets.NewSegmentedControl(40, 330, []string{"Mo", "Tu", "We", "Th", "Fr", "Sa", "Su"}, tw, fontSize)
+	days.Selection = widgets.NewSelection(len(days.Options), widgets.SelectMultiple)
+	days.Selection.OnChange = func(index int, selected bool) {
+		game.lastChange = fmt.Sprintf("%d days selected", days.Selection.Count())
+	}
+	game.elements = append(game.elements, days)
+
+	ebiten.SetWindowSize(screenWidth, screenHeight)
+	ebiten.SetWindowTitle("Checkbox, RadioGroup and SegmentedControl Example")
+	if err := ebiten.RunGame(game); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,142 @@
+package main
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"log"
+	"strconv"
+	"sync"
+
+	"example.com/menu/internals/dialog"
+	"example.com/menu/internals/navigator"
+	"example.com/menu/internals/page"
+	"example.com/menu/internals/textwrapper"
+	"example.com/menu/internals/utils"
+	"example.com/menu/internals/widgets"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+)
+
+const (
+	pageWidth  = 640
+	pageHeight = 480
+	fontSize   = 18.0
+)
+
+type Game struct {
+	navigator *navigator.Navigator
+	width     int
+	height    int
+
+	mu     sync.Mutex
+	status string
+}
+
+// setStatus may be called from the goroutine waiting on a dialog
+func (g *Game) setStatus(format string, args ...any) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.status = fmt.Sprintf(format, args...)
+}
+
+func (g *Game) Update() error {
+	_, err := g.navigator.Update(0, 0)
+	return err
+}
+
+func (g *Game) Draw(screen *ebiten.Image) {
+	g.navigator.Draw(screen, image.Rect(0, 0, pageWidth, pageHeight))
+	g.mu.Lock()
+	ebitenutil.DebugPrintAt(screen, g.status, 10, g.height-20)
+	g.mu.Unlock()
+}
+
+// Layout follows the window size, the window dialogs size themselves by its breakpoint
+func (g *Game) Layout(outsideWidth, outsideHeight int) (int, int) {
+	g.width, g.height = outsideWidth, outsideHeight
+	return outsideWidth, outsideHeight
+}
+
+func main() {
+	utils.InitGetFilepath()
+	fontPath := utils.GetFilePath("assets/fonts/roboto_regularTTF.ttf")
+
+	tw, err := textwrapper.NewTextWrapper(fontPath, fontSize, false)
+	if err != nil {
+		log.Fatalf("Failed to create TextWrapper: %v", err)
+	}
+
+	nav := navigator.NewNavigator()
+	game := &Game{navigator: nav, status: "Enter = primary button, Escape = cancel, Tab moves the focus"}
+
+	p := page.NewBasePage(color.RGBA{200, 210, 225, 255}, "Dialogs", tw, 0, 0, pageWidth, pageHeight)
+	buttonColor := color.RGBA{70, 70, 70, 255}
+	y := float32(20)
+	addButton := func(label string, onClick func()) {
+		p.AddUIelement(widgets.NewButtonStd(20, y, 260, 36, label, tw, color.White, buttonColor, fontSize, onClick))
+		y += 46
+	}
+
+	addButton("Alert", func() {
+		p.Alert("Saved", "Your settings have been saved.", func() { game.setStatus("alert closed") })
+	})
+
+	addButton("Confirm", func() {
+		p.Confirm("Quit to menu? Unsaved progress will be lost.", func(ok bool) {
+			game.setStatus("confirm: %v", ok)
+		})
+	})
+
+	addButton("Prompt (number)", func() {
+		d := dialog.NewPrompt("How many players?", "1 - 8", tw)
+		d.Input.Filter = widgets.NumericFilter(false, false)
+		d.Input.Validate = func(value string) error {
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 1 || n > 8 {
+				return fmt.Errorf("between 1 and 8")
+			}
+			return nil
+		}
+		d.OnResult = func(r dialog.Result) {
+			game.setStatus("prompt: %q accepted=%v", r.Value, r.Accepted)
+		}
+		p.ShowDialog(d)
+	})
+
+	addButton("Custom body, result by channel", func() {
+		difficulty := widgets.NewRadioGroup(0, 0, []string{"Easy", "Normal", "Hard"}, tw, fontSize)
+		difficulty.SetSelectedIndex(1)
+		d := dialog.New("New game", "Pick a difficulty.", tw, "Cancel", "Later", "Start")
+		d.Body = difficulty
+		p.ShowDialog(d)
+
+		// Done receives the result once, a goroutine can wait for it
+		go func() {
+			r := <-d.Done()
+			game.setStatus("custom: button %d, difficulty %s", r.Button, difficulty.SelectedOption())
+		}()
+	})
+
+	addButton("Window dialog (resize the window)", func() {
+		dialog.Alert(nav.Overlays, tw, "Responsive", "Window dialogs follow the window breakpoint: stacked buttons when it is small, a centered box when it is large.", nil)
+	})
+
+	addButton("Dialog, then push a page", func() {
+		p.Confirm("This dialog is cancelled by the push.", func(ok bool) {
+			game.setStatus("closed by the page switch: ok=%v", ok)
+		})
+		next := page.NewBasePage(color.RGBA{225, 215, 200, 255}, "Next page", tw, 0, 0, pageWidth, pageHeight)
+		next.AddUIelement(widgets.NewButtonStd(20, 20, 200, 36, "Back", tw, color.White, buttonColor, fontSize, nav.Pop))
+		nav.Push(next)
+	})
+
+	nav.Push(p)
+
+	ebiten.SetWindowSize(pageWidth, pageHeight+30)
+	ebiten.SetWindowResizingMode(ebiten.WindowResizingModeEnabled)
+	ebiten.SetWindowTitle("Dialog Example")
+	if err := ebiten.RunGame(game); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"fmt"
+	"image/color"
+	"log"
+	"path/filepath"
+	"runtime"
+
+	"example.com/menu/internals/overlay"
+	"example.com/menu/internals/textwrapper"
+	"example.com/menu/internals/widgets"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+)
+
+const (
+	screenWidth  = 640
+	screenHeight = 480
+	fontSize     = 18.0
+)
+
+type Game struct {
+	overlays   *overlay.Manager
+	dropdowns  []*widgets.Dropdown
+	names      []string
+	lastChange string
+}
+
+func (g *Game) Update() error {
+	// The open list goes first, a click it used does not reach the dropdowns beneath
+	blocked := g.overlays.Update(0, 0, false)
+	for _, d := range g.dropdowns {
+		d.Update(0, 0, blocked)
+	}
+	return nil
+}
+
+func (g *Game) Draw(screen *ebiten.Image) {
+	screen.Fill(color.RGBA{230, 230, 230, 255})
+	for i, d := range g.dropdowns {
+		ebitenutil.DebugPrintAt(screen, g.names[i], int(d.X), int(d.Y)-18)
+		d.Draw(screen)
+	}
+	ebitenutil.DebugPrintAt(screen, g.lastChange, 20, screenHeight-20)
+	g.overlays.Draw(screen)
+}
+
+func (g *Game) Layout(outsideWidth, outsideHeight int) (int, int) {
+	return screenWidth, screenHeight
+}
+
+func (g *Game) addDropdown(name string, d *widgets.Dropdown) {
+	d.OnChange = func(index int, value string) {
+		g.lastChange = fmt.Sprintf("%s: %q (option %d)", name, value, index)
+	}
+	g.names = append(g.names, name)
+	g.dropdowns = append(g.dropdowns, d)
+}
+
+func getFilePath(fileName string) string {
+	dir := filepath.Dir(filePathTxt)
+	return filepath.Join(dir, Assets_Relative_Path, fileName)
+}
+
+var filePathTxt string
+
+const Assets_Relative_Path = "../../"
+
+func main() {
+	_, filePathTxt, _, _ = runtime.Caller(0)
+	tw, err := textwrapper.NewTextWrapper(getFilePath("assets/fonts/roboto_regularTTF.ttf"), fontSize, false)
+	if err != nil {
+		log.Fatalf("Failed to create text wrapper: %v", err)
+	}
+
+	game := &Game{overlays: overlay.NewManager()}
+
+	difficulty := widgets.NewDropdown(40, 60, 220, 34, []string{"Easy", "Normal", "Hard", "Nightmare"}, game.overlays, tw, fontSize)
+	difficulty.SetSelectedIndex(1)
+	game.addDropdown("Difficulty", difficulty)
+
+	languages := []string{
+		"Deutsch", "English", "Español", "Français", "Italiano", "Nederlands", "Norsk",
+		"Polski", "Português", "Română", "Suomi", "Svenska", "Türkçe", "Čeština", "Ελληνικά",
+	}
+	language := widgets.NewDropdown(340, 60, 220, 34, languages, game.overlays, tw, fontSize)
+	language.Placeholder = "Pick a language"
+	game.addDropdown("Language (type to jump, list scrolls)", language)
+
+	// Near the bottom edge the list opens upward
+	server := widgets.NewDropdown(40, 400, 300, 34, []string{"eu-west", "eu-central", "us-east", "us-west", "asia-east"}, game.overlays, tw, fontSize)
+	server.Editable = true
+	server.Placeholder = "Server or host:port"
+	game.addDropdown("Server (editable, typing filters)", server)
+
+	ebiten.SetWindowSize(screenWidth, screenHeight)
+	ebiten.SetWindowTitle("Dropdown Example")
+	if err := ebiten.RunGame(game); err != nil {
+		panic(err)
+	}
+}
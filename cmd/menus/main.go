@@ -0,0 +1,182 @@
+package main
+
+import (
+	"fmt"
+	"image/color"
+	"log"
+
+	"example.com/menu/internals/overlay"
+	"example.com/menu/internals/textwrapper"
+	"example.com/menu/internals/utils"
+	"example.com/menu/internals/widgets"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+const (
+	screenWidth  = 800
+	screenHeight = 600
+	fontSize     = 16.0
+)
+
+// Game is a tiny level editor: a menu bar on top and a canvas with a context menu
+type Game struct {
+	overlays *overlay.Manager
+	bar      *widgets.MenuBar
+	context  *widgets.ContextMenu
+	showGrid *widgets.MenuItem
+	status   string
+	objects  []object
+}
+
+type object struct {
+	x, y float32
+	kind string
+}
+
+func (g *Game) Update() error {
+	blocked := g.overlays.Update(0, 0, false)
+	g.bar.Update(0, 0, blocked)
+	g.context.Update(0, 0, blocked)
+	return nil
+}
+
+func (g *Game) Draw(screen *ebiten.Image) {
+	screen.Fill(color.RGBA{60, 63, 70, 255})
+	if g.showGrid.Checked {
+		for x := float32(0); x < screenWidth; x += 32 {
+			vector.StrokeLine(screen, x, 0, x, screenHeight, 1, color.RGBA{75, 78, 86, 255}, false)
+		}
+		for y := float32(0); y < screenHeight; y += 32 {
+			vector.StrokeLine(screen, 0, y, screenWidth, y, 1, color.RGBA{75, 78, 86, 255}, false)
+		}
+	}
+	for _, o := range g.objects {
+		vector.DrawFilledRect(screen, o.x-12, o.y-12, 24, 24, color.RGBA{230, 170, 60, 255}, false)
+		ebitenutil.DebugPrintAt(screen, o.kind, int(o.x)-12, int(o.y)+14)
+	}
+	ebitenutil.DebugPrintAt(screen, "Right click the canvas. "+g.status, 10, screenHeight-20)
+	g.bar.Draw(screen)
+	g.overlays.Draw(screen)
+}
+
+func (g *Game) Layout(outsideWidth, outsideHeight int) (int, int) {
+	return screenWidth, screenHeight
+}
+
+func (g *Game) say(format string, args ...any) func(*widgets.MenuItem) {
+	return func(*widgets.MenuItem) { g.status = fmt.Sprintf(format, args...) }
+}
+
+func main() {
+	utils.InitGetFilepath()
+	tw, err := textwrapper.NewTextWrapper(utils.GetFilePath("assets/fonts/roboto_regularTTF.ttf"), fontSize, false)
+	if err != nil {
+		log.Fatalf("Failed to create TextWrapper: %v", err)
+	}
+
+	g := &Game{overlays: overlay.NewManager()}
+
+	redo := widgets.NewMenuItem("Redo", g.say("Redo")).WithShortcut("Mod+Shift+Z")
+	redo.Disabled = true
+	undo := widgets.NewMenuItem("Undo", func(*widgets.MenuItem) {
+		g.status = "Undo"
+		redo.Disabled = false
+	}).WithShortcut("Mod+Z")
+
+	g.showGrid = widgets.NewCheckMenuItem("Show grid", true, func(item *widgets.MenuItem) {
+		g.status = fmt.Sprintf("Grid %v", item.Checked)
+	}).WithShortcut("Mod+G")
+
+	g.bar = widgets.NewMenuBar(0, 0, screenWidth, g.overlays, tw, fontSize)
+	g.bar.Add("File", widgets.NewMenu(
+		widgets.NewMenuItem("New level", g.say("New level")).WithShortcut("Mod+N"),
+		widgets.NewMenuItem("Open...", g.say("Open")).WithShortcut("Mod+O"),
+		widgets.NewSubmenu("Open recent",
+			widgets.NewMenuItem("forest.lvl", g.say("Open forest.lvl")),
+			widgets.NewMenuItem("castle.lvl", g.say("Open castle.lvl")),
+			widgets.NewSubmenu("Archive",
+				widgets.NewMenuItem("tutorial_v1.lvl", g.say("Open tutorial_v1.lvl")),
+				widgets.NewMenuItem("tutorial_v2.lvl", g.say("Open tutorial_v2.lvl")),
+			),
+		),
+		widgets.NewMenuSeparator(),
+		widgets.NewMenuItem("Save", g.say("Saved")).WithShortcut("Mod+S"),
+		widgets.NewMenuItem("Save as...", g.say("Save as")).WithShortcut("Mod+Shift+S"),
+		widgets.NewMenuSeparator(),
+		&widgets.MenuItem{Label: "Export (no level loaded)", Disabled: true},
+	))
+	g.bar.Add("Edit", widgets.NewMenu(
+		undo,
+		redo,
+		widgets.NewMenuSeparator(),
+		widgets.NewMenuItem("Cut", g.say("Cut")).WithShortcut("Mod+X"),
+		widgets.NewMenuItem("Copy", g.say("Copy")).WithShortcut("Mod+C"),
+		widgets.NewMenuItem("Paste", g.say("Paste")).WithShortcut("Mod+V"),
+	))
+	g.bar.Add("View", widgets.NewMenu(
+		g.showGrid,
+		widgets.NewCheckMenuItem("Snap to grid", false, func(item *widgets.MenuItem) {
+			g.status = fmt.Sprintf("Snap %v", item.Checked)
+		}),
+		widgets.NewMenuSeparator(),
+		widgets.NewSubmenu("Zoom",
+			widgets.NewMenuItem("Zoom in", g.say("Zoom in")).WithShortcut("Mod+="),
+			widgets.NewMenuItem("Zoom out", g.say("Zoom out")).WithShortcut("Mod+-"),
+			widgets.NewMenuItem("Actual size", g.say("Zoom 100%%")).WithShortcut("Mod+0"),
+		),
+	))
+	g.bar.Add("Help", widgets.NewMenu(widgets.NewMenuItem("About", g.say("Level editor demo"))))
+
+	var clickX, clickY float32
+	add := func(kind string) func(*widgets.MenuItem) {
+		return func(*widgets.MenuItem) {
+			g.objects = append(g.objects, object{clickX, clickY, kind})
+			g.status = "Added " + kind
+		}
+	}
+	clear := widgets.NewMenuItem("Clear level", func(*widgets.MenuItem) {
+		g.objects = nil
+		g.status = "Cleared"
+	})
+	canvasMenu := widgets.NewMenu(
+		widgets.NewSubmenu("Add",
+			widgets.NewMenuItem("Sprite", add("sprite")),
+			widgets.NewMenuItem("Light", add("light")),
+			widgets.NewSubmenu("Trigger",
+				widgets.NewMenuItem("On enter", add("enter")),
+				widgets.NewMenuItem("On leave", add("leave")),
+			),
+		),
+		widgets.NewMenuSeparator(),
+		clear,
+	)
+
+	// The canvas is everything under the bar
+	g.context = widgets.NewContextMenu(canvasMenu, g.overlays, tw, fontSize)
+	g.context.X, g.context.Y = 0, g.bar.Height
+	g.context.Width, g.context.Height = screenWidth, screenHeight-g.bar.Height
+
+	ebiten.SetWindowSize(screenWidth, screenHeight)
+	ebiten.SetWindowTitle("Menu Example")
+	if err := ebiten.RunGame(&editor{Game: g, clickX: &clickX, clickY: &clickY, clear: clear}); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// editor remembers where the context menu opened and disables Clear on an empty level
+type editor struct {
+	*Game
+	clickX, clickY *float32
+	clear          *widgets.MenuItem
+}
+
+func (e *editor) Update() error {
+	if ebiten.IsMouseButtonPressed(ebiten.MouseButtonRight) && !e.context.IsOpen() {
+		x, y := ebiten.CursorPosition()
+		*e.clickX, *e.clickY = float32(x), float32(y)
+	}
+	e.clear.Disabled = len(e.objects) == 0
+	return e.Game.Update()
+}
@@ -0,0 +1,151 @@
+package main
+
+import (
+	"image"
+	"image/color"
+	"log"
+
+	"example.com/menu/internals/navigator"
+	"example.com/menu/internals/overlay"
+	"example.com/menu/internals/page"
+	"example.com/menu/internals/textwrapper"
+	"example.com/menu/internals/utils"
+	"example.com/menu/internals/widgets"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+const (
+	screenWidth     = 800
+	screenHeight    = 600
+	leftColumnWidth = 160
+	fontSize        = 16.0
+)
+
+type Game struct {
+	navigator *navigator.Navigator
+}
+
+func (g *Game) Update() error {
+	_, err := g.navigator.Update(leftColumnWidth, 0)
+	return err
+}
+
+func (g *Game) Draw(screen *ebiten.Image) {
+	vector.DrawFilledRect(screen, 0, 0, leftColumnWidth, screenHeight, color.RGBA{50, 50, 50, 255}, false)
+	ebitenutil.DebugPrintAt(screen, "Escape closes the top\noverlay, a push or pop\ncloses all but toasts", 10, 10)
+	g.navigator.Draw(screen, image.Rect(leftColumnWidth, 0, screenWidth, screenHeight))
+}
+
+func (g *Game) Layout(outsideWidth, outsideHeight int) (int, int) {
+	return screenWidth, screenHeight
+}
+
+// panel is a minimal overlay content: a box with a message and an optional button
+type panel struct {
+	X, Y, Width, Height float32
+	Message             string
+	Color               color.Color
+	Button              *widgets.ButtonStd
+	TextWrapper         *textwrapper.TextWrapper
+}
+
+func newPanel(x, y, width, height float32, message string, clr color.Color, tw *textwrapper.TextWrapper) *panel {
+	return &panel{X: x, Y: y, Width: width, Height: height, Message: message, Color: clr, TextWrapper: tw}
+}
+
+// withButton adds a button at the bottom right of the panel
+func (p *panel) withButton(label string, onClick func()) *panel {
+	p.Button = widgets.NewButtonStd(p.X+p.Width-110, p.Y+p.Height-44, 100, 34, label, p.TextWrapper,
+		color.White, color.RGBA{0, 120, 215, 255}, fontSize, onClick)
+	return p
+}
+
+func (p *panel) Update(offsetX, offsetY float32, isAnimating bool) {
+	if p.Button != nil {
+		p.Button.Update(offsetX, offsetY, isAnimating)
+	}
+}
+
+func (p *panel) Draw(screen *ebiten.Image) {
+	vector.DrawFilledRect(screen, p.X, p.Y, p.Width, p.Height, p.Color, false)
+	vector.StrokeRect(screen, p.X, p.Y, p.Width, p.Height, 1, color.RGBA{90, 90, 90, 255}, false)
+	p.TextWrapper.Color = color.Black
+	p.TextWrapper.SetFontSize(fontSize)
+	p.TextWrapper.DrawText(screen, p.Message, float64(p.X+12), float64(p.Y+12))
+	if p.Button != nil {
+		p.Button.Draw(screen)
+	}
+}
+
+func (p *panel) Contains(x, y float32) bool {
+	return x >= p.X && x < p.X+p.Width && y >= p.Y && y < p.Y+p.Height
+}
+
+func newDemoPage(title string, bg color.Color, tw *textwrapper.TextWrapper, nav *navigator.Navigator) *page.BasePage {
+	width, height := float32(screenWidth-leftColumnWidth), float32(screenHeight)
+	p := page.NewBasePage(bg, title, tw, 0, 0, width, height)
+	overlays := p.Overlays()
+
+	name := widgets.NewTextInput(20, 60, 260, 34, tw, fontSize, "Focus me, then open the modal")
+	p.AddUIelement(name)
+
+	buttonColor := color.RGBA{70, 70, 70, 255}
+	addButton := func(y float32, label string, onClick func()) {
+		p.AddUIelement(widgets.NewButtonStd(20, y, 200, 36, label, tw, color.White, buttonColor, fontSize, onClick))
+	}
+
+	// Page overlays are in page coordinates and close when the page is left
+	var modal *overlay.Overlay
+	modalPanel := newPanel(width/2-180, height/2-80, 360, 160, "A modal: the page beneath is blocked.\nThe focused input gets the focus back.", color.White, tw).
+		withButton("Close", func() { modal.Close() })
+	modal = overlay.New(overlay.Modal, modalPanel)
+	addButton(120, "Open modal", func() { overlays.Open(modal) })
+
+	popup := overlay.New(overlay.Popup, newPanel(230, 170, 240, 100, "A popup: click outside\nto close it.", color.RGBA{255, 250, 210, 255}, tw))
+	addButton(170, "Open popup", func() { overlays.Open(popup) })
+
+	tooltip := overlay.New(overlay.Tooltip, newPanel(230, 220, 240, 44, "A tooltip never takes input", color.RGBA{240, 240, 240, 255}, tw))
+	addButton(220, "Toggle tooltip", func() {
+		if tooltip.IsOpen() {
+			tooltip.Close()
+			return
+		}
+		overlays.Open(tooltip)
+	})
+
+	// Window overlays are in screen coordinates, a persistent toast survives navigation
+	var toast *overlay.Overlay
+	toastPanel := newPanel(screenWidth-320, screenHeight-80, 300, 60, "Toast, stays across pages", color.RGBA{210, 240, 210, 255}, tw).
+		withButton("OK", func() { toast.Close() })
+	toast = overlay.New(overlay.Toast, toastPanel)
+	toast.Persistent = true
+	addButton(270, "Show toast", func() { nav.Overlays.Open(toast) })
+
+	addButton(340, "Push next page", func() {
+		nav.Push(newDemoPage(title+" >", bg, tw, nav))
+	})
+	addButton(390, "Pop", func() { nav.Pop() })
+
+	return p
+}
+
+func main() {
+	utils.InitGetFilepath()
+	fontPath := utils.GetFilePath("assets/fonts/roboto_regularTTF.ttf")
+
+	tw, err := textwrapper.NewTextWrapper(fontPath, fontSize, false)
+	if err != nil {
+		log.Fatalf("Failed to create TextWrapper: %v", err)
+	}
+
+	nav := navigator.NewNavigator()
+	nav.Push(newDemoPage("Overlays", color.RGBA{200, 210, 225, 255}, tw, nav))
+
+	ebiten.SetWindowSize(screenWidth, screenHeight)
+	ebiten.SetWindowTitle("Overlay Example")
+	if err := ebiten.RunGame(&Game{navigator: nav}); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -1,48 +1,117 @@
 package main
 
 import (
+	"fmt"
 	"image/color"
+	"log"
+	"path/filepath"
+	"runtime"
 
+	"example.com/menu/internals/textwrapper"
 	"example.com/menu/internals/widgets"
 	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
 )
 
 const (
 	screenWidth  = 640
 	screenHeight = 480
+	fontSize     = 14.0
 )
 
 type Game struct {
-	slider *widgets.Slider
+	sliders    []*widgets.Slider
+	names      []string
+	lastAction string
 }
 
 func (g *Game) Update() error {
-	g.slider.Update()
+	for _, slider := range g.sliders {
+		slider.Update(0, 0, false)
+	}
 	return nil
 }
 
 func (g *Game) Draw(screen *ebiten.Image) {
 	screen.Fill(color.RGBA{0, 0, 0, 255})
-	g.slider.Draw(screen)
+	for i, slider := range g.sliders {
+		label := fmt.Sprintf("%s: %g", g.names[i], slider.Value())
+		if slider.IsRange {
+			low, high := slider.RangeValues()
+			label = fmt.Sprintf("%s: %g - %g", g.names[i], low, high)
+		}
+		ebitenutil.DebugPrintAt(screen, label, int(slider.X), int(slider.Y)-20)
+		slider.Draw(screen)
+	}
+	ebitenutil.DebugPrintAt(screen, "Click a slider, then arrows/PageUp/PageDown/Home/End or the wheel. Tab switches range thumbs.", 10, screenHeight-40)
+	ebitenutil.DebugPrintAt(screen, g.lastAction, 10, screenHeight-20)
 }
 
 func (g *Game) Layout(outsideWidth, outsideHeight int) (int, int) {
 	return screenWidth, screenHeight
 }
 
-func main() {
-	ebiten.SetWindowSize(screenWidth, screenHeight)
-	ebiten.SetWindowTitle("Slider Example")
+func (g *Game) addSlider(name string, slider *widgets.Slider) {
+	slider.OnChangeEnd = func(value float64) {
+		g.lastAction = fmt.Sprintf("%s set to %g", name, value)
+	}
+	g.names = append(g.names, name)
+	g.sliders = append(g.sliders, slider)
+}
+
+func getFilePath(fileName string) string {
+	dir := filepath.Dir(filePathTxt)
+	return filepath.Join(dir, Assets_Relative_Path, fileName)
+}
+
+var filePathTxt string
 
-	slider := &widgets.Slider{
-		X:         100,
-		Y:         200,
-		Width:     400,
-		Height:    20,
-		HandlePos: 200,
+const Assets_Relative_Path = "../../"
+
+func main() {
+	_, filePathTxt, _, _ = runtime.Caller(0)
+	tw, err := textwrapper.NewTextWrapper(getFilePath("assets/fonts/roboto_regularTTF.ttf"), fontSize, false)
+	if err != nil {
+		log.Fatalf("Failed to create text wrapper: %v", err)
 	}
 
-	game := &Game{slider: slider}
+	game := &Game{}
+
+	volume := widgets.NewSlider(60, 60, 400, 20, 0, 100)
+	volume.Step = 1
+	volume.TickStep = 25
+	volume.ShowLabels = true
+	volume.TextWrapper = tw
+	volume.LabelFormat = func(v float64) string { return fmt.Sprintf("%g%%", v) }
+	volume.SetValue(50)
+	game.addSlider("Volume", volume)
+
+	sensitivity := widgets.NewSlider(60, 160, 400, 20, 0.1, 5)
+	sensitivity.Step = 0.1
+	sensitivity.PageStep = 1
+	sensitivity.SetValue(1)
+	game.addSlider("Sensitivity", sensitivity)
+
+	spawn := widgets.NewSlider(60, 240, 400, 20, 0, 60)
+	spawn.Step = 5
+	spawn.IsRange = true
+	spawn.TickStep = 10
+	spawn.ShowLabels = true
+	spawn.TextWrapper = tw
+	spawn.SetRange(10, 40)
+	game.addSlider("Spawn delay (s)", spawn)
+
+	fov := widgets.NewSlider(540, 60, 20, 300, 60, 120)
+	fov.Step = 5
+	fov.Orientation = widgets.SliderVertical
+	fov.TickStep = 10
+	fov.ShowLabels = true
+	fov.TextWrapper = tw
+	fov.SetValue(90)
+	game.addSlider("FOV", fov)
+
+	ebiten.SetWindowSize(screenWidth, screenHeight)
+	ebiten.SetWindowTitle("Slider Example")
 	if err := ebiten.RunGame(game); err != nil {
 		panic(err)
 	}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"fmt"
+	"image/color"
+	"log"
+	"math"
+	"math/rand"
+	"time"
+
+	"example.com/menu/internals/charts"
+	"example.com/menu/internals/textwrapper"
+	"example.com/menu/internals/utils"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+)
+
+const (
+	screenWidth  = 1000
+	screenHeight = 640
+)
+
+type Game struct {
+	start      time.Time
+	lastFrame  time.Time
+	frameTimes *charts.Stream
+	timing     *charts.StreamChart
+	simulation *charts.StreamChart
+}
+
+func (g *Game) Update() error {
+	now := time.Now()
+	g.frameTimes.Append(now.Sub(g.start).Seconds(), float64(now.Sub(g.lastFrame).Microseconds())/1000)
+	g.lastFrame = now
+
+	switch {
+	case inpututil.IsKeyJustPressed(ebiten.KeySpace):
+		g.timing.TogglePause()
+		g.simulation.TogglePause()
+	case inpututil.IsKeyJustPressed(ebiten.KeyA):
+		g.simulation.AutoScale = !g.simulation.AutoScale
+	case inpututil.IsKeyJustPressed(ebiten.KeyEqual):
+		g.simulation.Window = math.Max(g.simulation.Window/2, 1)
+	case inpututil.IsKeyJustPressed(ebiten.KeyMinus):
+		g.simulation.Window = math.Min(g.simulation.Window*2, 60)
+	}
+	g.timing.Update(0, 0, false)
+	g.simulation.Update(0, 0, false)
+	return nil
+}
+
+func (g *Game) Draw(screen *ebiten.Image) {
+	screen.Fill(color.RGBA{24, 26, 31, 255})
+	g.timing.Draw(screen)
+	g.simulation.Draw(screen)
+	yMin, yMax := g.simulation.YRange()
+	ebitenutil.DebugPrintAt(screen, fmt.Sprintf(
+		"Space pause  A auto scale (%v)  +/- window (%.0fs)  y %.2f..%.2f  TPS %.0f",
+		g.simulation.AutoScale, g.simulation.Window, yMin, yMax, ebiten.ActualTPS()), 10, screenHeight-20)
+}
+
+func (g *Game) Layout(outsideWidth, outsideHeight int) (int, int) {
+	return screenWidth, screenHeight
+}
+
+// produce calls sample rate times a second from its own goroutine
+func produce(rate int, sample func(t float64)) {
+	start := time.Now()
+	go func() {
+		ticker := time.NewTicker(time.Second / time.Duration(rate))
+		defer ticker.Stop()
+		for now := range ticker.C {
+			sample(now.Sub(start).Seconds())
+		}
+	}()
+}
+
+func main() {
+	utils.InitGetFilepath()
+	tw, err := textwrapper.NewTextWrapper(utils.GetFilePath("assets/fonts/roboto_regularTTF.ttf"), 12, false)
+	if err != nil {
+		log.Fatal(err)
+	}
+	tw.Color = color.White
+
+	panel := color.RGBA{34, 37, 44, 255}
+	timing := charts.NewStreamChart(10, 10, screenWidth-20, 280, 10, tw)
+	timing.Background = panel
+	frameTimes := timing.AddStream("frame time (ms)", 60*60)
+	frameTimes.LineWidth = 1.5
+	latency := timing.AddStream("latency (ms)", 100*60)
+
+	// Thousands of values a second, decimated to a few per pixel column when drawn
+	simulation := charts.NewStreamChart(10, 300, screenWidth-20, 310, 10, tw)
+	simulation.Background = panel
+	simulation.YMin, simulation.YMax = -3, 3
+	value := simulation.AddStream("simulation, 4000 values/s", 4000*60)
+	smooth := simulation.AddStream("moving average", 4000*60)
+	smooth.LineWidth = 2
+
+	produce(100, func(t float64) {
+		spike := 0.0
+		if rand.Intn(200) == 0 {
+			spike = 80
+		}
+		latency.Append(t, 20+5*rand.Float64()+spike)
+	})
+	// Ticker resolution is coarse, emit a burst of values per tick
+	average := 0.0
+	produce(200, func(t float64) {
+		for i := 0; i < 20; i++ {
+			ti := t + float64(i)/4000
+			amplitude := 1 + 0.8*math.Sin(ti/7)
+			v := amplitude*math.Sin(2*math.Pi*ti*0.5) + 0.3*rand.NormFloat64()
+			average += (v - average) * 0.01
+			value.Append(ti, v)
+			smooth.Append(ti, average)
+		}
+	})
+
+	now := time.Now()
+	g := &Game{start: now, lastFrame: now, frameTimes: frameTimes, timing: timing, simulation: simulation}
+	ebiten.SetWindowSize(screenWidth, screenHeight)
+	ebiten.SetWindowTitle("Streaming Charts")
+	if err := ebiten.RunGame(g); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"fmt"
+	"image/color"
+	"log"
+	"path/filepath"
+	"runtime"
+
+	"example.com/menu/internals/textwrapper"
+	"example.com/menu/internals/widgets"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+)
+
+const (
+	screenWidth  = 640
+	screenHeight = 480
+	inputX       = 200
+	inputW       = 300
+	inputH       = 36
+	fontSize     = 20.0
+)
+
+type Game struct {
+	labels     *textwrapper.TextWrapper
+	inputs     []*widgets.TextInput
+	names      []string
+	lastAction string
+}
+
+func (g *Game) Update() error {
+	for _, input := range g.inputs {
+		input.Update(0, 0, false)
+	}
+	return nil
+}
+
+func (g *Game) Draw(screen *ebiten.Image) {
+	screen.Fill(color.RGBA{230, 230, 230, 255})
+	g.labels.SetFontSize(fontSize)
+	for i, input := range g.inputs {
+		g.labels.DrawText(screen, g.names[i], 40, float64(input.Y)+8)
+		input.Draw(screen)
+	}
+	ebitenutil.DebugPrintAt(screen, "Enter submits, Escape cancels. "+g.lastAction, 40, screenHeight-40)
+}
+
+func (g *Game) Layout(outsideWidth, outsideHeight int) (int, int) {
+	return screenWidth, screenHeight
+}
+
+func (g *Game) addInput(name string, input *widgets.TextInput) {
+	input.OnSubmit = func(value string) {
+		g.lastAction = fmt.Sprintf("%s submitted %q", name, value)
+	}
+	input.OnCancel = func(value string) {
+		g.lastAction = fmt.Sprintf("%s cancelled", name)
+	}
+	g.names = append(g.names, name)
+	g.inputs = append(g.inputs, input)
+}
+
+func getFilePath(fileName string) string {
+	dir := filepath.Dir(filePathTxt)
+	return filepath.Join(dir, Assets_Relative_Path, fileName)
+}
+
+var filePathTxt string
+
+const Assets_Relative_Path = "../../"
+
+func main() {
+	_, filePathTxt, _, _ = runtime.Caller(0)
+	fontPath := getFilePath("assets/fonts/roboto_regularTTF.ttf")
+
+	newWrapper := func() *textwrapper.TextWrapper {
+		tw, err := textwrapper.NewTextWrapper(fontPath, fontSize, false)
+		if err != nil {
+			log.Fatalf("Failed to create text wrapper: %v", err)
+		}
+		tw.Color = color.Black
+		return tw
+	}
+
+	game := &Game{labels: newWrapper()}
+	tw := newWrapper()
+
+	name := widgets.NewTextInput(inputX, 40, inputW, inputH, tw, fontSize, "Player name")
+	name.MaxLength = 16
+	name.Validate = widgets.RequiredValidator("a name is required")
+	game.addInput("Player name", name)
+
+	port := widgets.NewTextInput(inputX, 120, inputW, inputH, tw, fontSize, "1024 - 65535")
+	port.Filter = widgets.NumericFilter(false, false)
+	port.Validate = widgets.IntRangeValidator(1024, 65535)
+	port.SetText("8080")
+	game.addInput("Server port", port)
+
+	seed := widgets.NewTextInput(inputX, 200, inputW, inputH, tw, fontSize, "hex seed")
+	hexFilter, err := widgets.RegexFilter("[0-9a-fA-F]*")
+	if err != nil {
+		log.Fatal(err)
+	}
+	seed.Filter = hexFilter
+	game.addInput("World seed", seed)
+
+	password := widgets.NewTextInput(inputX, 280, inputW, inputH, tw, fontSize, "Password")
+	password.Password = true
+	game.addInput("Password", password)
+
+	ebiten.SetWindowSize(screenWidth, screenHeight)
+	ebiten.SetWindowTitle("TextInput Example")
+	if err := ebiten.RunGame(game); err != nil {
+		panic(err)
+	}
+}
@@ -8,9 +8,11 @@ import (
 	"runtime"
 
 	//"example.com/menu/internals/textwrapper02"
+	"example.com/menu/internals/syntax"
 	"example.com/menu/internals/textwrapper"
 	"example.com/menu/internals/widgets"
 	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
 )
 
 const (
@@ -23,7 +25,24 @@ const (
 )
 
 type Game struct {
-	textarea *widgets.TextArea
+	textarea       *widgets.TextArea
+	lineNumbers    bool
+	showWhitespace bool
+	lexerIndex     int
+	keymapIndex    int
+	keymaps        []namedKeymap
+}
+
+type namedKeymap struct {
+	name   string
+	keymap *widgets.Keymap
+}
+
+var lexers = []syntax.Tokenizer{
+	nil,
+	syntax.NewGoLexer(),
+	syntax.NewJSONLexer(),
+	syntax.NewINILexer(),
 }
 
 func (g *Game) Draw(screen *ebiten.Image) {
@@ -31,6 +50,31 @@ func (g *Game) Draw(screen *ebiten.Image) {
 }
 
 func (g *Game) Update() error {
+	// F2 toggles soft line wrapping
+	if inpututil.IsKeyJustPressed(ebiten.KeyF2) {
+		g.textarea.SetSoftWrap(!g.textarea.IsSoftWrap())
+	}
+	// F3 toggles the line number gutter
+	if inpututil.IsKeyJustPressed(ebiten.KeyF3) {
+		g.lineNumbers = !g.lineNumbers
+		g.textarea.SetLineNumbers(g.lineNumbers)
+	}
+	// F4 toggles visible whitespace
+	if inpututil.IsKeyJustPressed(ebiten.KeyF4) {
+		g.showWhitespace = !g.showWhitespace
+		g.textarea.SetShowWhitespace(g.showWhitespace)
+	}
+	// F5 cycles the syntax highlighting: none, Go, JSON, INI
+	if inpututil.IsKeyJustPressed(ebiten.KeyF5) {
+		g.lexerIndex = (g.lexerIndex + 1) % len(lexers)
+		g.textarea.SetTokenizer(lexers[g.lexerIndex])
+	}
+	// F6 cycles the key bindings: default, emacs, vim, keymap.json
+	if inpututil.IsKeyJustPressed(ebiten.KeyF6) {
+		g.keymapIndex = (g.keymapIndex + 1) % len(g.keymaps)
+		g.textarea.SetKeymap(g.keymaps[g.keymapIndex].keymap)
+		ebiten.SetWindowTitle("Text Input with Selection Example - " + g.keymaps[g.keymapIndex].name + " keys")
+	}
 	return g.textarea.Update()
 }
 
@@ -73,6 +117,17 @@ func main() {
 	ebiten.SetWindowTitle("Text Input with Selection Example")
 	game := &Game{
 		textarea: widgets.NewTextAreaSelection(textWrapper, textAreaX, textAreaY, textAreaW, textAreaH, string(textStart)),
+		keymaps: []namedKeymap{
+			{"default", widgets.DefaultKeymap()},
+			{"emacs", widgets.EmacsKeymap()},
+			{"vim", widgets.VimKeymap()},
+		},
+	}
+	customKeymap, err := widgets.LoadKeymap(getFilePath("cmd/textareaSelection/keymap.json"))
+	if err != nil {
+		log.Printf("Custom keymap not loaded: %v", err)
+	} else {
+		game.keymaps = append(game.keymaps, namedKeymap{"keymap.json", customKeymap})
 	}
 	if err := ebiten.RunGame(game); err != nil {
 		panic(err)
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"log"
+	"math/rand"
+	"time"
+
+	"example.com/menu/internals/navigator"
+	"example.com/menu/internals/page"
+	"example.com/menu/internals/textwrapper"
+	"example.com/menu/internals/toast"
+	"example.com/menu/internals/utils"
+	"example.com/menu/internals/widgets"
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+const (
+	screenWidth  = 800
+	screenHeight = 600
+	fontSize     = 16.0
+)
+
+type Game struct {
+	navigator *navigator.Navigator
+}
+
+func (g *Game) Update() error {
+	_, err := g.navigator.Update(0, 0)
+	return err
+}
+
+func (g *Game) Draw(screen *ebiten.Image) {
+	g.navigator.Draw(screen, image.Rect(0, 0, screenWidth, screenHeight))
+}
+
+func (g *Game) Layout(outsideWidth, outsideHeight int) (int, int) {
+	return screenWidth, screenHeight
+}
+
+// backgroundSave stands for a save running off the game goroutine, it reports through the queue
+func backgroundSave(toasts *toast.Queue) {
+	progress := toasts.Show(&toast.Notice{Message: "Saving...", Duration: -1})
+	go func() {
+		time.Sleep(time.Duration(500+rand.Intn(1500)) * time.Millisecond)
+		progress.Dismiss()
+		if rand.Intn(3) == 0 {
+			toasts.Show(&toast.Notice{
+				Message:  "Save failed: disk full",
+				Severity: toast.Error,
+				Duration: -1,
+				Action:   "Retry",
+				OnAction: func() { backgroundSave(toasts) },
+			})
+			return
+		}
+		toasts.Success(fmt.Sprintf("Game saved at %s", time.Now().Format("15:04:05")))
+	}()
+}
+
+func newDemoPage(title string, bg color.Color, tw *textwrapper.TextWrapper, nav *navigator.Navigator, toasts *toast.Queue) *page.BasePage {
+	p := page.NewBasePage(bg, title, tw, 0, 0, screenWidth, screenHeight)
+
+	buttonColor := color.RGBA{70, 70, 70, 255}
+	y := float32(40)
+	addButton := func(label string, onClick func()) {
+		p.AddUIelement(widgets.NewButtonStd(20, y, 260, 36, label, tw, color.White, buttonColor, fontSize, onClick))
+		y += 46
+	}
+
+	addButton("Info", func() { toasts.Info("Settings saved") })
+	addButton("Warning with action", func() {
+		toasts.Show(&toast.Notice{
+			Message:  "Controller disconnected",
+			Severity: toast.Warning,
+			Action:   "Reconnect",
+			OnAction: func() { toasts.Success("Controller connected") },
+		})
+	})
+	addButton("Long error, stays until clicked", func() {
+		toasts.Show(&toast.Notice{
+			Message:  "Could not reach the server. Check your connection, the game keeps running offline.",
+			Severity: toast.Error,
+			Duration: -1,
+		})
+	})
+	addButton("Background save (goroutine)", func() { backgroundSave(toasts) })
+	addButton("Ten at once (4 shown, the rest wait)", func() {
+		for i := 1; i <= 10; i++ {
+			toasts.Info(fmt.Sprintf("Notice %d", i))
+		}
+	})
+	addButton("Clear", toasts.Clear)
+
+	y += 20
+	addButton("Push next page", func() {
+		nav.Push(newDemoPage(title+" >", bg, tw, nav, toasts))
+	})
+	addButton("Pop", nav.Pop)
+	return p
+}
+
+func main() {
+	utils.InitGetFilepath()
+	tw, err := textwrapper.NewTextWrapper(utils.GetFilePath("assets/fonts/roboto_regularTTF.ttf"), fontSize, false)
+	if err != nil {
+		log.Fatalf("Failed to create TextWrapper: %v", err)
+	}
+
+	nav := navigator.NewNavigator()
+	// The queue lives in the window overlays, it stays across Push and Pop
+	toasts := toast.NewQueue(nav.Overlays, tw)
+	nav.Push(newDemoPage("Toasts", color.RGBA{200, 210, 225, 255}, tw, nav, toasts))
+
+	ebiten.SetWindowSize(screenWidth, screenHeight)
+	ebiten.SetWindowTitle("Toast Example")
+	if err := ebiten.RunGame(&Game{navigator: nav}); err != nil {
+		log.Fatal(err)
+	}
+}
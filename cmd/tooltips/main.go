@@ -0,0 +1,133 @@
+package main
+
+import (
+	"image/color"
+	"log"
+	"time"
+
+	"example.com/menu/internals/overlay"
+	"example.com/menu/internals/textwrapper"
+	"example.com/menu/internals/tooltip"
+	"example.com/menu/internals/utils"
+	"example.com/menu/internals/widgets"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+const (
+	screenWidth  = 640
+	screenHeight = 480
+	fontSize     = 16.0
+)
+
+type Game struct {
+	overlays *overlay.Manager
+	tooltips *tooltip.Manager
+	input    widgets.InputManager
+	buttons  []*widgets.ButtonStd
+	name     *widgets.TextInput
+	sound    *widgets.Checkbox
+}
+
+func (g *Game) Update() error {
+	g.overlays.Update(0, 0, false)
+	g.tooltips.Update(0, 0, false)
+	g.input.Update()
+	for _, b := range g.buttons {
+		b.Update(0, 0, false)
+	}
+	g.sound.Update(0, 0, false)
+
+	// Tab moves the focus between the field and the checkbox, their tooltips follow it
+	if inpututil.IsKeyJustPressed(ebiten.KeyTab) {
+		if g.name.IsFocused() {
+			g.name.Blur()
+			g.sound.Focus()
+		} else {
+			g.sound.Blur()
+			g.name.Focus()
+		}
+		return nil
+	}
+	g.name.Update(0, 0, false)
+	return nil
+}
+
+func (g *Game) Draw(screen *ebiten.Image) {
+	screen.Fill(color.RGBA{230, 230, 230, 255})
+	ebitenutil.DebugPrintAt(screen, "Hover a widget, or press Tab to focus one", 20, 10)
+	for _, c := range g.input.Clickables {
+		c.(*widgets.Button01).Draw(screen)
+	}
+	for _, b := range g.buttons {
+		b.Draw(screen)
+	}
+	g.name.Draw(screen)
+	g.sound.Draw(screen)
+	g.overlays.Draw(screen)
+}
+
+func (g *Game) Layout(outsideWidth, outsideHeight int) (int, int) {
+	return screenWidth, screenHeight
+}
+
+// swatch is a custom tooltip content
+type swatch struct {
+	x, y  float32
+	color color.Color
+}
+
+func (s *swatch) Size() (float32, float32) { return 120, 60 }
+
+func (s *swatch) SetPosition(x, y float32) { s.x, s.y = x, y }
+
+func (s *swatch) Draw(screen *ebiten.Image) {
+	vector.DrawFilledRect(screen, s.x, s.y, 120, 60, color.White, false)
+	vector.DrawFilledRect(screen, s.x+6, s.y+6, 108, 48, s.color, false)
+}
+
+func main() {
+	utils.InitGetFilepath()
+	tw, err := textwrapper.NewTextWrapper(utils.GetFilePath("assets/fonts/roboto_regularTTF.ttf"), fontSize, false)
+	if err != nil {
+		log.Fatalf("Failed to create TextWrapper: %v", err)
+	}
+
+	g := &Game{overlays: overlay.NewManager()}
+	g.tooltips = tooltip.NewManager(g.overlays, tw)
+
+	// Button01 tracks the hover through the InputManager, Attach uses its IsHovered
+	save := widgets.NewButton(20, 40, 140, 40, "Save (Button01)",
+		color.RGBA{0, 120, 215, 255}, color.RGBA{0, 90, 170, 255}, color.RGBA{0, 60, 120, 255}, func() {})
+	g.input.Register(save)
+	g.tooltips.Attach(save, "Saves the game in the current slot. A click hides this tooltip until the cursor leaves.")
+
+	buttonColor := color.RGBA{70, 70, 70, 255}
+	addButton := func(x, y float32, label, tip string) *tooltip.Target {
+		b := widgets.NewButtonStd(x, y, 140, 36, label, tw, color.White, buttonColor, fontSize, func() {})
+		g.buttons = append(g.buttons, b)
+		return g.tooltips.Attach(b, tip)
+	}
+	addButton(screenWidth-150, 40, "Top right", "Near the right edge the tooltip moves left to stay in the window.")
+	addButton(20, screenHeight-46, "Bottom left", "Near the bottom edge the tooltip opens above the cursor.")
+	slow := addButton(screenWidth-150, screenHeight-46, "Slow tooltip", "This one waits two seconds.")
+	slow.Delay = 2 * time.Second
+
+	colorButton := widgets.NewButtonStd(200, 40, 140, 36, "Team color", tw, color.White, color.RGBA{200, 60, 60, 255}, fontSize, func() {})
+	g.buttons = append(g.buttons, colorButton)
+	g.tooltips.Add(&tooltip.Target{Bounds: colorButton.Bounds, Content: &swatch{color: color.RGBA{200, 60, 60, 255}}})
+
+	g.name = widgets.NewTextInput(20, 160, 260, 34, tw, fontSize, "Player name")
+	g.tooltips.Attach(g.name, "Shown to the other players. Letters, digits and spaces, up to 16 characters.")
+
+	g.sound = widgets.NewCheckbox(20, 220, "Sound effects", tw, fontSize)
+	g.tooltips.Attach(g.sound, "Plays the sounds of the game and of the menus.")
+
+	ebiten.SetWindowSize(screenWidth, screenHeight)
+	ebiten.SetWindowTitle("Tooltip Example")
+	if err := ebiten.RunGame(g); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -6,47 +6,56 @@ import (
 
 	"example.com/menu/cmd02/more06/navigator"
 	"example.com/menu/cmd02/more06/pagemodel"
-	"example.com/menu/cmd02/more06/responsive"
+	"example.com/menu/cmd02/more06/settings"
 	"example.com/menu/cmd02/more06/textwrapper"
-	"example.com/menu/cmd02/more06/types"
 	"example.com/menu/cmd02/more06/widgets"
+	"example.com/menu/internals/form"
+	internalwidgets "example.com/menu/internals/widgets"
 )
 
 type AudioPage struct {
-	*pagemodel.SinglePageBase
+	*pagemodel.FormPageBase
 }
 
-func NewAudioPage(nv *navigator.Navigator, textWrapper *textwrapper.TextWrapper, screenWidth, screenHeight int) *AudioPage {
-	breakpoints := []responsive.Breakpoint{
-		{Width: 1000, LayoutMode: responsive.LayoutVertical},
-		{Width: 600, LayoutMode: responsive.LayoutHorizontal},
+func NewAudioPage(nv *navigator.Navigator, textWrapper *textwrapper.TextWrapper, audio *settings.Audio, screenWidth, screenHeight int) *AudioPage {
+	f, err := settings.NewAudioForm(audio)
+	if err != nil {
+		log.Fatalf("Failed to create audio form: %v", err)
 	}
-
-	fields := []types.Element{
-		widgets.NewButton("Volume Up", func() {
-			log.Println("Volume Up clicked")
-		}, textWrapper),
-		widgets.NewButton("Volume Down", func() {
-			log.Println("Volume Down clicked")
-		}, textWrapper),
-		widgets.NewButton("Back", func() {
-			log.Println("Back clicked")
-			nv.SwitchTo("settings")
-		}, textWrapper),
+	f.OnSubmit = func() error {
+		log.Printf("Audio settings applied: %+v\n", *audio)
+		return nil
 	}
 
-	ui := widgets.NewUI("Audio Settings", breakpoints, fields, textWrapper, responsive.AlignCenter)
-
-	ui.LayoutUpdate(screenWidth, screenHeight)
-
-	page := &pagemodel.SinglePageBase{
-		Ui:            ui,
-		PrevWidth:     screenWidth,
-		PrevHeight:    screenHeight,
-		Navigator:     nv,
-		BackgroundClr: color.RGBA{0x4E, 0x4E, 0x4E, 0xFF},
+	controls := []pagemodel.FormControl{
+		{
+			Name:    "master",
+			Element: widgets.NewOptionButton("Master", widgets.IntOptions(0, 100, 10, "%d%%"), textWrapper),
+			Tooltip: "Overall volume. Music and effects are played at their own volume times this one.",
+		},
+		{
+			Name:    "music",
+			Element: widgets.NewOptionButton("Music", widgets.IntOptions(0, 100, 10, "%d%%"), textWrapper),
+			Tooltip: "Volume of the menu and level music.",
+		},
+		{
+			Name:    "effects",
+			Element: newCheckboxChoice("Sound effects", textWrapper),
+			Tooltip: "Plays the sounds of the game and of the menus. The music is not affected.",
+		},
 	}
+
+	page := pagemodel.NewFormPageBase(nv, textWrapper, "Audio Settings", f, controls, "settings", screenWidth, screenHeight)
 	return &AudioPage{
-		SinglePageBase: page,
+		FormPageBase: page,
 	}
 }
+
+const choiceFontSize = 22
+
+// newCheckboxChoice returns a checkbox for a bool form field
+func newCheckboxChoice(label string, textWrapper *textwrapper.TextWrapper) *widgets.Choice {
+	checkbox := internalwidgets.NewCheckbox(0, 0, label, textWrapper, choiceFontSize)
+	checkbox.FontColor = color.White
+	return widgets.NewChoice(checkbox, form.CheckboxControl(checkbox), textWrapper)
+}
@@ -6,49 +6,82 @@ import (
 
 	"example.com/menu/cmd02/more06/navigator"
 	"example.com/menu/cmd02/more06/pagemodel"
-	"example.com/menu/cmd02/more06/responsive"
+	"example.com/menu/cmd02/more06/settings"
 	"example.com/menu/cmd02/more06/textwrapper"
-	"example.com/menu/cmd02/more06/types"
 	"example.com/menu/cmd02/more06/widgets"
+	"example.com/menu/internals/form"
+	"example.com/menu/internals/overlay"
+	internalwidgets "example.com/menu/internals/widgets"
 )
 
 type GraphicsPage struct {
-	*pagemodel.SinglePageBase
+	*pagemodel.FormPageBase
 }
 
-func NewGraphicsPage(nv *navigator.Navigator, textWrapper *textwrapper.TextWrapper, screenWidth, screenHeight int) *GraphicsPage {
-	breakpoints := []responsive.Breakpoint{
-		{Width: 1000, LayoutMode: responsive.LayoutVertical},
-		{Width: 600, LayoutMode: responsive.LayoutHorizontal},
+func NewGraphicsPage(nv *navigator.Navigator, textWrapper *textwrapper.TextWrapper, graphics *settings.Graphics, screenWidth, screenHeight int) *GraphicsPage {
+	f, err := settings.NewGraphicsForm(graphics)
+	if err != nil {
+		log.Fatalf("Failed to create graphics form: %v", err)
+	}
+	f.OnSubmit = func() error {
+		log.Printf("Graphics settings applied: %+v\n", *graphics)
+		return nil
 	}
 
-	fields := []types.Element{
-		widgets.NewButton("Resolution", func() {
-			log.Println("Resolution clicked")
-
-		}, textWrapper),
-		widgets.NewButton("Fullscreen", func() {
-			log.Println("Fullscreen clicked")
+	fpsOptions := []widgets.Option{{Label: "Unlimited", Value: 0}}
+	fpsOptions = append(fpsOptions, widgets.IntOptions(30, 30, 1, "%d")...)
+	fpsOptions = append(fpsOptions, widgets.IntOptions(60, 240, 60, "%d")...)
 
-		}, textWrapper),
-		widgets.NewButton("Back", func() {
-			log.Println("Back clicked")
-			nv.SwitchTo("settings")
-		}, textWrapper),
+	controls := []pagemodel.FormControl{
+		{
+			Name:    "resolution",
+			Element: newResolutionChoice(nv.Overlays, textWrapper),
+			Tooltip: "Size of the window, or of the screen in fullscreen. Lower resolutions run faster.",
+		},
+		{
+			Name:    "quality",
+			Element: newQualityChoice(textWrapper),
+			Tooltip: "Detail of the effects and shadows. Low suits older graphics cards.",
+		},
+		{
+			Name:    "fullscreen",
+			Element: newCheckboxChoice("Fullscreen", textWrapper),
+			Tooltip: "Uses the whole screen instead of a window.",
+		},
+		{
+			Name:    "vsync",
+			Element: newCheckboxChoice("VSync", textWrapper),
+			Tooltip: "Waits for the screen refresh before showing a frame. Removes tearing, may add a little input lag.",
+		},
+		{
+			Name:    "maxFps",
+			Element: widgets.NewOptionButton("Max FPS", fpsOptions, textWrapper),
+			Tooltip: "Upper limit of frames per second. Unlimited uses as much of the graphics card as it can.",
+		},
 	}
 
-	ui := widgets.NewUI("Graphics Settings", breakpoints, fields, textWrapper, responsive.AlignCenter)
+	page := pagemodel.NewFormPageBase(nv, textWrapper, "Graphics Settings", f, controls, "settings", screenWidth, screenHeight)
+	page.BackgroundClr = color.RGBA{0x3E, 0x3E, 0x4E, 0xFF}
+	return &GraphicsPage{
+		FormPageBase: page,
+	}
+}
 
-	ui.LayoutUpdate(screenWidth, screenHeight)
+// newResolutionChoice returns a dropdown with the resolutions, the list scrolls.
+// It opens in the window overlays, above the page and its tooltips.
+func newResolutionChoice(overlays *overlay.Manager, textWrapper *textwrapper.TextWrapper) *widgets.Choice {
+	dropdown := internalwidgets.NewDropdown(0, 0, 0, 0, settings.Resolutions, overlays, textWrapper, choiceFontSize)
+	dropdown.Placeholder = "Resolution"
+	dropdown.MaxVisible = 6
+	return widgets.NewChoice(dropdown, form.DropdownControl(dropdown), textWrapper)
+}
 
-	page := &pagemodel.SinglePageBase{
-		Ui:            ui,
-		PrevWidth:     screenWidth,
-		PrevHeight:    screenHeight,
-		Navigator:     nv,
-		BackgroundClr: color.RGBA{0x5E, 0x5E, 0x5E, 0xFF},
-	}
-	return &GraphicsPage{
-		SinglePageBase: page,
+// newQualityChoice returns a segmented control with one segment per quality level
+func newQualityChoice(textWrapper *textwrapper.TextWrapper) *widgets.Choice {
+	segments := internalwidgets.NewSegmentedControl(0, 0, settings.Qualities, textWrapper, choiceFontSize)
+	values := make([]any, len(settings.Qualities))
+	for i, quality := range settings.Qualities {
+		values[i] = quality
 	}
+	return widgets.NewChoice(segments, form.SelectionControl(segments.Selection, values...), textWrapper)
 }
@@ -7,7 +7,11 @@ import (
 
 	"example.com/menu/cmd02/more06/builder"
 	"example.com/menu/cmd02/more06/navigator"
+	"example.com/menu/cmd02/more06/settings"
 	"example.com/menu/cmd02/more06/textwrapper"
+	"example.com/menu/internals/dialog"
+	itw "example.com/menu/internals/textwrapper"
+	"example.com/menu/internals/toast"
 	"github.com/hajimehoshi/ebiten/v2"
 )
 
@@ -17,6 +21,10 @@ type Game struct {
 	prevHeight  int
 	exit        bool
 	textWrapper *textwrapper.TextWrapper
+	settings    settings.Settings
+	// dialogText draws the window dialogs, they are built on the internals widgets
+	dialogText  *itw.TextWrapper
+	exitConfirm *dialog.Dialog
 }
 
 func NewGame() *Game {
@@ -28,11 +36,18 @@ func NewGame() *Game {
 	}
 	textWrapper.Color = color.RGBA{255, 255, 255, 255}
 
+	dialogText, err := itw.NewTextWrapper(fontPath, 18, false)
+	if err != nil {
+		log.Fatalf("Failed to create TextWrapper: %v", err)
+	}
+
 	screenWidth, screenHeight := 800, 600
 	g := &Game{
 		prevWidth:   screenWidth,
 		prevHeight:  screenHeight,
 		textWrapper: textWrapper,
+		settings:    settings.Defaults(),
+		dialogText:  dialogText,
 	}
 
 	onExit := func() {
@@ -41,11 +56,13 @@ func NewGame() *Game {
 	}
 
 	g.navigator = navigator.NewNavigator(onExit)
+	g.navigator.ConfirmExit = g.confirmExit
+	g.navigator.Toasts = toast.NewQueue(g.navigator.Overlays, dialogText)
 
 	mainMenu := builder.NewMainMenuPage(g.navigator, textWrapper, screenWidth, screenHeight)
 	settings := builder.NewSettingsPage(g.navigator, textWrapper, screenWidth, screenHeight)
-	audio := builder.NewAudioPage(g.navigator, textWrapper, screenWidth, screenHeight)
-	graphics := builder.NewGraphicsPage(g.navigator, textWrapper, screenWidth, screenHeight)
+	audio := builder.NewAudioPage(g.navigator, textWrapper, &g.settings.Audio, screenWidth, screenHeight)
+	graphics := builder.NewGraphicsPage(g.navigator, textWrapper, &g.settings.Graphics, screenWidth, screenHeight)
 	startGame := builder.NewLevelGamePage(g.navigator, textWrapper, screenWidth, screenHeight, "start", "Start Game")
 
 	g.navigator.AddPage("main", mainMenu)
@@ -61,11 +78,27 @@ func NewGame() *Game {
 	return g
 }
 
+// confirmExit asks before quitting, a second exit request while it is open is ignored
+func (g *Game) confirmExit(proceed func()) {
+	if g.exitConfirm != nil && g.exitConfirm.IsOpen() {
+		return
+	}
+	g.exitConfirm = dialog.Confirm(g.navigator.Overlays, g.dialogText, "Quit the game?", func(ok bool) {
+		if ok {
+			proceed()
+		}
+	})
+}
+
 func (g *Game) Update() error {
 	if g.exit {
 		return errors.New("game exited by user")
 	}
 
+	// Window overlays (e.g. toasts) come first, a modal keeps the input from the page
+	if g.navigator.Overlays.Update(0, 0, false) {
+		return nil
+	}
 	if err := g.navigator.CurrentActivePage().Update(); err != nil {
 		return err
 	}
@@ -77,6 +110,12 @@ func (g *Game) Draw(screen *ebiten.Image) {
 	//screen.Fill(color.RGBA{0x1F, 0x1F, 0x1F, 0xFF})
 
 	g.navigator.CurrentActivePage().Draw(screen)
+
+	// Open dropdown lists draw with the shared text wrapper, its size and color are kept
+	fontSize, textColor := g.textWrapper.GoTextFace.Size, g.textWrapper.Color
+	g.navigator.Overlays.Draw(screen)
+	g.textWrapper.SetFontSize(fontSize)
+	g.textWrapper.Color = textColor
 }
 
 func (g *Game) Layout(outsideWidth, outsideHeight int) (int, int) {
@@ -5,6 +5,8 @@ import (
 	"sync"
 
 	"example.com/menu/cmd02/more06/types"
+	"example.com/menu/internals/overlay"
+	"example.com/menu/internals/toast"
 )
 
 type Navigator struct {
@@ -12,12 +14,19 @@ type Navigator struct {
 	current types.Page
 	mu      sync.RWMutex
 	onExit  func()
+	// Overlays is the window overlay stack, drawn above every page
+	Overlays *overlay.Manager
+	// Toasts shows notifications in Overlays, it is set by the game and may be nil
+	Toasts *toast.Queue
+	// ConfirmExit, when set, is asked before SwitchTo("exit") runs onExit; it calls proceed to exit
+	ConfirmExit func(proceed func())
 }
 
 func NewNavigator(onExit func()) *Navigator {
 	return &Navigator{
-		pages:  make(map[string]types.Page),
-		onExit: onExit,
+		pages:    make(map[string]types.Page),
+		onExit:   onExit,
+		Overlays: overlay.NewManager(),
 	}
 }
 
@@ -37,17 +46,21 @@ func (n *Navigator) Layout(outsideWidth, outsideHeight int) (int, int) {
 }
 
 func (n *Navigator) SwitchTo(pageName string) {
-	n.mu.Lock()
-	defer n.mu.Unlock()
-
 	if pageName == "exit" {
-		log.Println("Exit requested")
-		if n.onExit != nil {
-			n.onExit()
+		if n.ConfirmExit != nil {
+			n.ConfirmExit(n.exit)
+			return
 		}
+		n.exit()
 		return
 	}
 
+	// Overlays are closed before taking the lock, their OnClose may switch pages too
+	n.closePageOverlays()
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
 	if page, exists := n.pages[pageName]; exists {
 		log.Printf("Switching to page: %s\n", pageName)
 		n.current = page
@@ -60,6 +73,21 @@ func (n *Navigator) SwitchTo(pageName string) {
 	}
 }
 
+func (n *Navigator) exit() {
+	log.Println("Exit requested")
+	if n.onExit != nil {
+		n.onExit()
+	}
+}
+
+// closePageOverlays closes the overlays of the current page and the window ones that are not persistent
+func (n *Navigator) closePageOverlays() {
+	if host, ok := n.CurrentActivePage().(overlay.Host); ok {
+		host.Overlays().CloseOnPageSwitch()
+	}
+	n.Overlays.CloseOnPageSwitch()
+}
+
 func (n *Navigator) CurrentActivePage() types.Page {
 	n.mu.RLock()
 	defer n.mu.RUnlock()
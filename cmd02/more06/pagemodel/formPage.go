@@ -0,0 +1,201 @@
+package pagemodel
+
+import (
+	"image/color"
+	"log"
+
+	"example.com/menu/cmd02/more06/navigator"
+	"example.com/menu/cmd02/more06/responsive"
+	"example.com/menu/cmd02/more06/textwrapper"
+	"example.com/menu/cmd02/more06/types"
+	"example.com/menu/cmd02/more06/widgets"
+	"example.com/menu/internals/form"
+	"example.com/menu/internals/tooltip"
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// FormControl is a widget bound to the form field Name, Tooltip explains the option
+type FormControl struct {
+	Name    string
+	Element interface {
+		types.Element
+		form.Control
+	}
+	Tooltip string
+}
+
+// FormPageBase is a settings page: a form, one widget per field and Apply/Revert/Defaults/Back buttons
+type FormPageBase struct {
+	*SinglePageBase
+	Form        *form.Form
+	title       string
+	status      string
+	statusErr   bool
+	layoutDirty bool
+	textWrapper *textwrapper.TextWrapper
+	tooltips    *tooltip.Manager
+}
+
+func NewFormPageBase(
+	nv *navigator.Navigator,
+	textWrapper *textwrapper.TextWrapper,
+	title string,
+	f *form.Form,
+	controls []FormControl,
+	backPage string,
+	screenWidth, screenHeight int,
+) *FormPageBase {
+	p := &FormPageBase{
+		Form:        f,
+		title:       title,
+		textWrapper: textWrapper,
+	}
+
+	breakpoints := []responsive.Breakpoint{
+		{Width: 0, LayoutMode: responsive.LayoutGrid},
+	}
+
+	var fields []types.Element
+	var tips []*tooltip.Target
+	for _, control := range controls {
+		if err := f.BindControl(control.Name, control.Element); err != nil {
+			log.Printf("FormPageBase %s: %v\n", title, err)
+			continue
+		}
+		fields = append(fields, control.Element)
+		if control.Tooltip != "" {
+			tips = append(tips, elementTooltip(control.Element, control.Tooltip))
+		}
+	}
+	fields = append(fields,
+		widgets.NewButton("Apply", p.apply, textWrapper),
+		widgets.NewButton("Revert", func() {
+			f.Revert()
+			p.setStatus("Changes reverted", false)
+		}, textWrapper),
+		widgets.NewButton("Defaults", func() {
+			f.ResetToDefaults()
+			p.setStatus("Defaults restored, apply to keep them", false)
+		}, textWrapper),
+		widgets.NewButton("Back", func() {
+			if f.IsDirty() {
+				log.Printf("%s: dropping unapplied changes to %v\n", title, f.DirtyFields())
+				f.Revert()
+			}
+			p.status = ""
+			nv.SwitchTo(backPage)
+		}, textWrapper),
+	)
+
+	// Option texts change width, the grid is laid out again on the next Update.
+	// Not right away: a change can come from a click, while the UI is locked.
+	f.OnChange = func(*form.Field) {
+		p.layoutDirty = true
+	}
+
+	ui := widgets.NewUI(title, breakpoints, fields, textWrapper, responsive.AlignCenter)
+	ui.LayoutUpdate(screenWidth, screenHeight)
+
+	p.SinglePageBase = &SinglePageBase{
+		ID:            title,
+		Label:         title,
+		Ui:            ui,
+		PrevWidth:     screenWidth,
+		PrevHeight:    screenHeight,
+		Navigator:     nv,
+		BackgroundClr: color.RGBA{0x4E, 0x4E, 0x4E, 0xFF},
+	}
+
+	p.tooltips = tooltip.NewManager(p.Overlays(), textWrapper)
+	p.tooltips.FontSize = 18
+	p.tooltips.MaxWidth = 320
+	for _, tip := range tips {
+		p.tooltips.Add(tip)
+	}
+	return p
+}
+
+// elementTooltip returns a tooltip over the laid out position of element,
+// also shown while it has the keyboard focus
+func elementTooltip(element types.Element, text string) *tooltip.Target {
+	target := &tooltip.Target{
+		Text: text,
+		Bounds: func() (float32, float32, float32, float32) {
+			pos := element.GetPosition()
+			return float32(pos.X), float32(pos.Y), float32(pos.Width), float32(pos.Height)
+		},
+	}
+	if focusable, ok := element.(interface{ IsFocused() bool }); ok {
+		target.Focused = focusable.IsFocused
+	}
+	return target
+}
+
+func (p *FormPageBase) apply() {
+	if err := p.Form.Submit(); err != nil {
+		if firstErr := p.Form.FirstError(); firstErr != nil {
+			err = firstErr
+		}
+		p.setStatus(err.Error(), true)
+		return
+	}
+	p.setStatus("", false)
+	if p.Navigator.Toasts != nil {
+		p.Navigator.Toasts.Success(p.title + " saved")
+		return
+	}
+	p.setStatus("Settings applied", false)
+}
+
+func (p *FormPageBase) setStatus(status string, isErr bool) {
+	p.status = status
+	p.statusErr = isErr
+}
+
+func (p *FormPageBase) Update() error {
+	if err := p.SinglePageBase.Update(); err != nil {
+		return err
+	}
+	p.Form.Update()
+	p.tooltips.Update(0, 0, p.Overlays().HasModal() || p.Navigator.Overlays.HasPopup())
+	if p.layoutDirty {
+		p.layoutDirty = false
+		p.Ui.LayoutUpdate(p.PrevWidth, p.PrevHeight)
+	}
+
+	// A * in the title marks unapplied changes
+	title := p.title
+	if p.Form.IsDirty() {
+		title += " *"
+	}
+	p.Ui.Title.Text = title
+	return nil
+}
+
+func (p *FormPageBase) Draw(screen *ebiten.Image) {
+	p.DrawBackGround(screen)
+	p.Ui.Draw(screen)
+	p.drawStatus(screen)
+	p.drawOverlays(screen)
+}
+
+func (p *FormPageBase) drawStatus(screen *ebiten.Image) {
+	if p.status == "" {
+		return
+	}
+
+	fontSize := p.textWrapper.GoTextFace.Size
+	baseColor := p.textWrapper.Color
+	defer func() {
+		p.textWrapper.SetFontSize(fontSize)
+		p.textWrapper.Color = baseColor
+	}()
+
+	p.textWrapper.SetFontSize(fontSize / 2)
+	p.textWrapper.Color = color.RGBA{0x9E, 0xE0, 0x9E, 0xFF}
+	if p.statusErr {
+		p.textWrapper.Color = color.RGBA{0xFF, 0x6E, 0x6E, 0xFF}
+	}
+	width, _ := p.textWrapper.MeasureText(p.status)
+	p.textWrapper.DrawText(screen, p.status, (float64(p.PrevWidth)-width)/2, 110)
+}
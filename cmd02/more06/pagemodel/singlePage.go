@@ -9,6 +9,7 @@ import (
 	"example.com/menu/cmd02/more06/textwrapper"
 	"example.com/menu/cmd02/more06/types"
 	"example.com/menu/cmd02/more06/widgets"
+	"example.com/menu/internals/overlay"
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/inpututil"
 )
@@ -21,6 +22,7 @@ type SinglePageBase struct {
 	PrevHeight    int
 	Navigator     *navigator.Navigator
 	BackgroundClr color.Color
+	overlays      *overlay.Manager
 }
 
 func NewSinglePageBase(nv *navigator.Navigator, textWrapper *textwrapper.TextWrapper, id string, label string, screenWidth, screenHeight int) *SinglePageBase {
@@ -66,17 +68,41 @@ func (p *SinglePageBase) Layout(outsideWidth, outsideHeight int) (int, int) {
 	return outsideWidth, outsideHeight
 }
 
+// Overlays returns the overlay stack of the page, the navigator closes it on a page switch
+func (p *SinglePageBase) Overlays() *overlay.Manager {
+	if p.overlays == nil {
+		p.overlays = overlay.NewManager()
+	}
+	return p.overlays
+}
+
 func (p *SinglePageBase) Update() error {
+	// A modal, or an overlay that used the click, keeps the input from the page
+	if p.Overlays().Update(0, 0, false) {
+		return nil
+	}
 	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
 		x, y := ebiten.CursorPosition()
 		p.Ui.HandleClick(x, y)
 	}
+	p.Ui.TickFields()
 	return nil
 }
 
 func (p *SinglePageBase) Draw(screen *ebiten.Image) {
 	p.DrawBackGround(screen)
 	p.Ui.Draw(screen)
+	p.drawOverlays(screen)
+}
+
+// drawOverlays keeps the font size and color of the shared text wrapper, tooltips change them
+func (p *SinglePageBase) drawOverlays(screen *ebiten.Image) {
+	fontSize, textColor := p.Ui.TextWrapper.GoTextFace.Size, p.Ui.TextWrapper.Color
+	defer func() {
+		p.Ui.TextWrapper.SetFontSize(fontSize)
+		p.Ui.TextWrapper.Color = textColor
+	}()
+	p.Overlays().Draw(screen)
 }
 
 func (p *SinglePageBase) DrawBackGround(screen *ebiten.Image) {
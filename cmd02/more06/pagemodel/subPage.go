@@ -64,6 +64,7 @@ func (p *SubPageBase) Update() error {
 		x, y := ebiten.CursorPosition()
 		p.Ui.HandleClick(x, y)
 	}
+	p.Ui.TickFields()
 	return nil
 }
 
@@ -0,0 +1,97 @@
+package settings
+
+import (
+	"errors"
+
+	"example.com/menu/internals/form"
+)
+
+type Audio struct {
+	MasterVolume int  `form:"master"`
+	MusicVolume  int  `form:"music"`
+	EffectsOn    bool `form:"effects"`
+}
+
+type Graphics struct {
+	Resolution string `form:"resolution"`
+	Quality    string `form:"quality"`
+	Fullscreen bool   `form:"fullscreen"`
+	VSync      bool   `form:"vsync"`
+	MaxFPS     int    `form:"maxFps"` // 0 means unlimited
+}
+
+type Settings struct {
+	Audio    Audio
+	Graphics Graphics
+}
+
+func Defaults() Settings {
+	return Settings{
+		Audio: Audio{
+			MasterVolume: 80,
+			MusicVolume:  60,
+			EffectsOn:    true,
+		},
+		Graphics: Graphics{
+			Resolution: "1280x720",
+			Quality:    "High",
+			Fullscreen: false,
+			VSync:      true,
+			MaxFPS:     60,
+		},
+	}
+}
+
+var Resolutions = []string{
+	"800x600", "1024x768", "1280x720", "1280x1024", "1366x768", "1440x900",
+	"1600x900", "1680x1050", "1920x1080", "1920x1200", "2560x1440", "3840x2160",
+}
+
+var Qualities = []string{"Low", "Medium", "High", "Ultra"}
+
+// NewAudioForm binds a form to the audio settings
+func NewAudioForm(audio *Audio) (*form.Form, error) {
+	f, err := form.New(audio, Defaults().Audio)
+	if err != nil {
+		return nil, err
+	}
+	f.Field("master").Validate(form.Range(0, 100))
+	f.Field("music").Validate(form.Range(0, 100))
+
+	// Music is mixed under the master volume, a louder setting would be clipped
+	f.AddValidator(func(values form.Values) error {
+		if values.Int("music") > values.Int("master") {
+			return &form.FieldError{Field: "music", Err: errors.New("music can not be louder than master")}
+		}
+		return nil
+	})
+	return f, nil
+}
+
+// NewGraphicsForm binds a form to the graphics settings
+func NewGraphicsForm(graphics *Graphics) (*form.Form, error) {
+	f, err := form.New(graphics, Defaults().Graphics)
+	if err != nil {
+		return nil, err
+	}
+	f.Field("resolution").Validate(form.OneOf(toAny(Resolutions)...))
+	f.Field("quality").Validate(form.OneOf(toAny(Qualities)...))
+	f.Field("maxFps").Validate(form.Range(0, 240))
+
+	// A frame cap under the refresh rate makes VSync stutter
+	f.AddValidator(func(values form.Values) error {
+		if values.Bool("vsync") && values.Int("maxFps") != 0 && values.Int("maxFps") < 60 {
+			return &form.FieldError{Field: "maxFps", Err: errors.New("needs 60 or more with VSync on")}
+		}
+		return nil
+	})
+	return f, nil
+}
+
+func toAny(values []string) []any {
+	out := make([]any, len(values))
+	for i, v := range values {
+		out[i] = v
+	}
+	return out
+}
@@ -93,6 +93,10 @@ func (tw *TextWrapper) DrawText(screen *ebiten.Image, textStr string, x, y float
 		tw.textOptions)
 }
 
+func (tw *TextWrapper) SetColor(color color.Color) {
+	tw.Color = color
+}
+
 func (tw *TextWrapper) GetFontMetrics() text.Metrics {
 	return tw.GoTextFace.Metrics()
 }
@@ -21,3 +21,8 @@ type Element interface {
 	ResetState()
 	GetSize() (int, int)
 }
+
+// Ticker is an Element that needs an update every frame, e.g. to read the keyboard
+type Ticker interface {
+	Tick()
+}
@@ -0,0 +1,106 @@
+package widgets
+
+import (
+	"sync"
+
+	"example.com/menu/cmd02/more06/textwrapper"
+	"example.com/menu/cmd02/more06/types"
+	"example.com/menu/internals/form"
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// ChoiceWidget is a Checkbox, RadioGroup, SegmentedControl or Dropdown from internals/widgets
+type ChoiceWidget interface {
+	Update(offsetX, offsetY float32, isAnimating bool)
+	Draw(screen *ebiten.Image)
+	SetBounds(x, y, width, height float32)
+	PreferredSize() (float32, float32)
+}
+
+// Choice places a ChoiceWidget in the UI so the responsive layout manager can lay it out.
+// The widget reads the mouse and keyboard itself in Tick, so IsClicked is always false.
+// Control is optional, it binds the widget to a form field.
+type Choice struct {
+	Widget      ChoiceWidget
+	Control     form.Control
+	Position    types.Position
+	TextWrapper *textwrapper.TextWrapper
+	mutex       sync.Mutex
+}
+
+func NewChoice(widget ChoiceWidget, control form.Control, tw *textwrapper.TextWrapper) *Choice {
+	return &Choice{
+		Widget:      widget,
+		Control:     control,
+		TextWrapper: tw,
+	}
+}
+
+func (c *Choice) Value() any {
+	if c.Control == nil {
+		return nil
+	}
+	return c.Control.Value()
+}
+
+func (c *Choice) SetValue(value any) {
+	if c.Control != nil {
+		c.Control.SetValue(value)
+	}
+}
+
+func (c *Choice) GetPosition() types.Position {
+	return c.Position
+}
+
+func (c *Choice) SetPosition(pos types.Position) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.Position = pos
+	c.Widget.SetBounds(float32(pos.X), float32(pos.Y), float32(pos.Width), float32(pos.Height))
+}
+
+// Update runs on layout, the widget reads its input in Tick
+func (c *Choice) Update() {}
+
+// Tick runs every frame
+func (c *Choice) Tick() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.Widget.Update(0, 0, false)
+}
+
+// Draw keeps the font size and color of the shared text wrapper
+func (c *Choice) Draw(screen *ebiten.Image) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	fontSize, textColor := c.TextWrapper.GoTextFace.Size, c.TextWrapper.Color
+	defer func() {
+		c.TextWrapper.SetFontSize(fontSize)
+		c.TextWrapper.Color = textColor
+	}()
+	c.Widget.Draw(screen)
+}
+
+// IsFocused reports whether the widget has the keyboard focus
+func (c *Choice) IsFocused() bool {
+	focusable, ok := c.Widget.(interface{ IsFocused() bool })
+	return ok && focusable.IsFocused()
+}
+
+func (c *Choice) IsClicked(x, y int) bool {
+	return false
+}
+
+func (c *Choice) HandleClick() {}
+
+func (c *Choice) ResetState() {}
+
+func (c *Choice) GetSize() (int, int) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	fontSize := c.TextWrapper.GoTextFace.Size
+	defer c.TextWrapper.SetFontSize(fontSize)
+	width, height := c.Widget.PreferredSize()
+	return int(width + 0.5), int(height + 0.5)
+}
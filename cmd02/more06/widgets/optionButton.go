@@ -0,0 +1,95 @@
+package widgets
+
+import (
+	"fmt"
+	"reflect"
+
+	"example.com/menu/cmd02/more06/textwrapper"
+)
+
+type Option struct {
+	Label string
+	Value any
+}
+
+// OptionButton is a button that cycles through a list of options on click,
+// it shows "Label: Option". It can be bound to a form field.
+type OptionButton struct {
+	*Button
+	Label   string
+	Options []Option
+	index   int
+}
+
+func NewOptionButton(label string, options []Option, tw *textwrapper.TextWrapper) *OptionButton {
+	ob := &OptionButton{
+		Label:   label,
+		Options: options,
+	}
+	ob.Button = NewButton("", ob.next, tw)
+	ob.updateText()
+	return ob
+}
+
+// BoolOptions returns On/Off options
+func BoolOptions() []Option {
+	return []Option{{"Off", false}, {"On", true}}
+}
+
+// IntOptions returns the whole numbers from min to max by step
+func IntOptions(min, max, step int, format string) []Option {
+	var options []Option
+	for v := min; v <= max; v += step {
+		options = append(options, Option{fmt.Sprintf(format, v), v})
+	}
+	return options
+}
+
+func StringOptions(values ...string) []Option {
+	options := make([]Option, len(values))
+	for i, v := range values {
+		options[i] = Option{v, v}
+	}
+	return options
+}
+
+func (ob *OptionButton) next() {
+	if len(ob.Options) == 0 {
+		return
+	}
+	ob.index = (ob.index + 1) % len(ob.Options)
+	ob.updateText()
+}
+
+func (ob *OptionButton) updateText() {
+	if len(ob.Options) == 0 {
+		ob.Text = ob.Label
+		return
+	}
+	ob.Text = ob.Label + ": " + ob.Options[ob.index].Label
+}
+
+// Value returns the value of the selected option
+func (ob *OptionButton) Value() any {
+	if len(ob.Options) == 0 {
+		return nil
+	}
+	return ob.Options[ob.index].Value
+}
+
+// SetValue selects the option holding value. A value that is not
+// in the list is added, so settings loaded from elsewhere still show.
+func (ob *OptionButton) SetValue(value any) {
+	ob.mutex.Lock()
+	defer ob.mutex.Unlock()
+	for i, option := range ob.Options {
+		if reflect.DeepEqual(option.Value, value) {
+			ob.index = i
+			ob.updateText()
+			return
+		}
+	}
+	ob.Options = append(ob.Options, Option{fmt.Sprint(value), value})
+	ob.index = len(ob.Options) - 1
+	ob.updateText()
+}
@@ -66,6 +66,18 @@ func (u *UI) LayoutUpdate(screenWidth, screenHeight int) {
 	u.Title.Y = 50
 }
 
+// TickFields runs once per frame for the fields that read their own input
+func (u *UI) TickFields() {
+	u.mutex.RLock()
+	defer u.mutex.RUnlock()
+
+	for _, field := range u.Fields {
+		if ticker, ok := field.(types.Ticker); ok {
+			ticker.Tick()
+		}
+	}
+}
+
 func (u *UI) HandleClick(x, y int) {
 	u.mutex.RLock()
 	defer u.mutex.RUnlock()
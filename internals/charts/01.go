@@ -4,6 +4,7 @@ import (
 	"image/color"
 	"math"
 
+	"example.com/menu/internals/charts/core"
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/text"
 	"github.com/hajimehoshi/ebiten/v2/vector"
@@ -78,20 +79,16 @@ func (g *Chart01) DrawAxes(screen *ebiten.Image) {
 }
 
 func (g *Chart01) PlotSineWave(screen *ebiten.Image) {
-	for x := 0.0; x < g.UsableWidth; x++ {
+	var xs, ys []float32
+	for x := 0.0; x <= g.UsableWidth; x++ {
 
 		normalizedX := x / g.UsableWidth * 2 * math.Pi
 		y := math.Sin(normalizedX) * 100
 
 		scaledY := (y - g.YMin) * g.YScale
 
-		vector.DrawFilledRect(screen,
-			float32(g.OffsetX+x),
-			float32(g.ScreenHeight-g.OffsetY-scaledY),
-			1,
-			1,
-			g.PlotColor,
-			false,
-		)
+		xs = append(xs, float32(g.OffsetX+x))
+		ys = append(ys, float32(g.ScreenHeight-g.OffsetY-scaledY))
 	}
+	core.StrokePolyline(&imageCanvas{dst: screen}, xs, ys, core.Stroke{Width: 1.5, Antialias: true}, g.PlotColor)
 }
@@ -3,8 +3,9 @@ package charts
 import (
 	"image/color"
 	"math"
-	"strconv"
 
+	"example.com/menu/internals/charts/axis"
+	"example.com/menu/internals/charts/core"
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/text"
 	"github.com/hajimehoshi/ebiten/v2/vector"
@@ -68,12 +69,15 @@ func (g *Chart02) DrawAxes() {
 }
 
 func (g *Chart02) PlotSineWave() {
-	for x := 0.0; x < g.UsableWidth; x++ {
+	var xs, ys []float32
+	for x := 0.0; x <= g.UsableWidth; x++ {
 		normalizedX := x / g.UsableWidth * 2 * math.Pi
 		y := math.Sin(normalizedX) * 100
 		scaledY := (y - g.YMin) * g.YScale
-		vector.DrawFilledRect(g.Screen, float32(g.OffsetX+x), float32(float64(g.Screen.Bounds().Dy())-g.OffsetY-scaledY), 1, 1, g.Green, false)
+		xs = append(xs, float32(g.OffsetX+x))
+		ys = append(ys, float32(float64(g.Screen.Bounds().Dy())-g.OffsetY-scaledY))
 	}
+	core.StrokePolyline(&imageCanvas{dst: g.Screen}, xs, ys, core.Stroke{Width: 1.5, Antialias: true}, g.Green)
 }
 
 func (g *Chart02) DrawAxisLabels() {
@@ -87,19 +91,37 @@ func (g *Chart02) DrawAxisLabels() {
 
 func (g *Chart02) DrawTicks() {
 	screenHeight := float64(g.Screen.Bounds().Dy())
-
-	for i := 0; i <= g.NumXTicks; i++ {
-		tickX := g.OffsetX + (g.UsableWidth/float64(g.NumXTicks))*float64(i)
-		vector.StrokeLine(g.Screen, float32(tickX), float32(screenHeight-g.OffsetY), float32(tickX), float32(screenHeight-g.OffsetY+5), 1, g.White, false)
-		label := int((float64(i) / float64(g.NumXTicks)) * 2 * math.Pi * 100)
-		text.Draw(g.Screen, strconv.Itoa(label), g.Face, int(tickX)-10, int(screenHeight-g.OffsetY+20), g.White)
+	labelWidth := func(label string) float64 { return float64(text.BoundString(g.Face, label).Dx()) }
+	labelHeight := func(label string) float64 { return float64(text.BoundString(g.Face, label).Dy()) }
+
+	// The sine wave spans one period, the x axis counts radians
+	xMin, xMax := 0.0, 2*math.Pi
+	tickX := func(v float64) float64 { return g.OffsetX + (v-xMin)/(xMax-xMin)*g.UsableWidth }
+	xTicks := axis.Fit((&axis.Linear{}).Ticks(xMin, xMax, g.NumXTicks), tickX, labelWidth, 6)
+	for _, t := range xTicks {
+		x := tickX(t.Value)
+		length := 5.0
+		if t.Minor {
+			length = 3
+		}
+		vector.StrokeLine(g.Screen, float32(x), float32(screenHeight-g.OffsetY), float32(x), float32(screenHeight-g.OffsetY+length), 1, g.White, false)
+		if t.Label != "" {
+			text.Draw(g.Screen, t.Label, g.Face, int(x-labelWidth(t.Label)/2), int(screenHeight-g.OffsetY+20), g.White)
+		}
 	}
 
-	for i := 0; i <= g.NumYTicks; i++ {
-		tickY := screenHeight - g.OffsetY - (g.UsableHeight/float64(g.NumYTicks))*float64(i)
-		vector.StrokeLine(g.Screen, float32(g.OffsetX), float32(tickY), float32(g.OffsetX-5), float32(tickY), 1, g.White, false)
-		label := int(g.YMin + (float64(g.YMax-g.YMin)/float64(g.NumYTicks))*float64(i))
-		text.Draw(g.Screen, strconv.Itoa(label), g.Face, int(g.OffsetX-30), int(tickY+5), g.White)
+	tickY := func(v float64) float64 { return screenHeight - g.OffsetY - (v-g.YMin)*g.YScale }
+	yTicks := axis.Fit((&axis.Linear{}).Ticks(g.YMin, g.YMax, g.NumYTicks), tickY, labelHeight, 2)
+	for _, t := range yTicks {
+		y := tickY(t.Value)
+		length := 5.0
+		if t.Minor {
+			length = 3
+		}
+		vector.StrokeLine(g.Screen, float32(g.OffsetX), float32(y), float32(g.OffsetX-length), float32(y), 1, g.White, false)
+		if t.Label != "" {
+			text.Draw(g.Screen, t.Label, g.Face, int(g.OffsetX-8-labelWidth(t.Label)), int(y+5), g.White)
+		}
 	}
 }
 
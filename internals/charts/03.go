@@ -2,15 +2,18 @@ package charts
 
 import (
 	_ "embed"
+	"image"
 	"image/color"
-	"strconv"
 
+	"example.com/menu/internals/charts/core"
 	"example.com/menu/internals/textwrapper"
 	"github.com/hajimehoshi/ebiten/v2"
-	"github.com/hajimehoshi/ebiten/v2/vector"
 )
 
+// Chart03 draws a Model with axes, ticks and a legend on screen, with the layout of core.Chart.
+// Without a Model it plots Data, one value per index.
 type Chart03 struct {
+	Model       *core.Model
 	Data        []float64
 	XLabel      string
 	YLabel      string
@@ -27,147 +30,52 @@ type Chart03 struct {
 }
 
 func (g *Chart03) DrawPlotline(screen *ebiten.Image) {
-	screenWidth := float64(screen.Bounds().Dx())
-	screenHeight := float64(screen.Bounds().Dy())
-
-	usableWidth := screenWidth - g.OffsetX*2
-	usableHeight := screenHeight - g.OffsetY*2
-
-	//green := color.RGBA{0, 255, 0, 255}
-
-	yMin := g.minValue()
-	yMax := g.maxValue()
-	if yMin > 0 {
-		yMin = 0
-	}
-	yRange := yMax - yMin
-	yScale := usableHeight / yRange
-
-	for x := 0.0; x < usableWidth; x++ {
-
-		dataIndex := int(x / usableWidth * float64(len(g.Data)))
-		if dataIndex < len(g.Data) {
-			y := g.Data[dataIndex]
-			scaledY := (y - yMin) * yScale
-			vector.DrawFilledRect(
-				screen,
-				float32(g.OffsetX+x),
-				float32(screenHeight-g.OffsetY-scaledY),
-				1*g.GutterWidth,
-				1,
-				g.PointColor,
-				false,
-			)
-		}
-	}
-
-	g.drawAxis(screen, g.minValue(), g.maxValue())
-
+	g.Draw(screen, &LineRenderer{}, g.PointColor)
 }
 
 func (g *Chart03) DrawBars(screen *ebiten.Image) {
-	screenWidth := float64(screen.Bounds().Dx())
-	screenHeight := float64(screen.Bounds().Dy())
-	usableWidth := screenWidth - g.OffsetX*2
-	usableHeight := screenHeight - g.OffsetY*2
-	min := g.minValue()
-	max := g.maxValue()
-
-	if min > 0 {
-		min = 0
-	}
-	adjustedRange := max - min
-
-	barWidth := usableWidth / float64(len(g.Data))
-	for i, y := range g.Data {
-		scaledY := ((y - min) / adjustedRange) * usableHeight
-		vector.DrawFilledRect(screen,
-			float32(g.OffsetX+barWidth*float64(i)),
-			float32(screenHeight-g.OffsetY-scaledY),
-			float32(barWidth*float64(g.GutterWidth)),
-			float32(scaledY),
-			g.BarColor,
-			false,
-		)
-	}
-
-	g.drawAxis(screen, min, max)
-
+	g.Draw(screen, &BarRenderer{Gutter: g.GutterWidth}, g.BarColor)
 }
 
-func (g *Chart03) drawAxis(screen *ebiten.Image, min, max float64) {
-	screenWidth := float64(screen.Bounds().Dx())
-	screenHeight := float64(screen.Bounds().Dy())
-	usableWidth := screenWidth - g.OffsetX*2
-	usableHeight := screenHeight - g.OffsetY*2
-
-	vector.StrokeLine(screen, float32(g.OffsetX), float32(g.OffsetY), float32(g.OffsetX), float32(screenHeight-g.OffsetY), 1, g.AxisColor, false)
-	vector.StrokeLine(screen, float32(g.OffsetX), float32(screenHeight-g.OffsetY), float32(screenWidth-g.OffsetX), float32(screenHeight-g.OffsetY), 1, g.AxisColor, false)
-
-	g.drawAxisLabels(screen)
-
-	for i := 0; i <= g.NumXTicks; i++ {
-		tickX := g.OffsetX + (usableWidth/float64(g.NumXTicks))*float64(i)
-		vector.StrokeLine(screen, float32(tickX), float32(screenHeight-g.OffsetY), float32(tickX), float32(screenHeight-g.OffsetY+5), 1, g.AxisColor, false)
-		labelInt := int((float64(i) / float64(g.NumXTicks)) * float64(len(g.Data)))
-
-		g.TextWrapper.DrawText(
-			screen,
-			strconv.Itoa(labelInt),
-			float64(tickX)-10,
-			float64(screenHeight-g.OffsetY+20),
-		)
-	}
-
-	for i := 0; i <= g.NumYTicks; i++ {
-		tickY := screenHeight - g.OffsetY - (usableHeight/float64(g.NumYTicks))*float64(i)
-		vector.StrokeLine(screen, float32(g.OffsetX), float32(tickY), float32(g.OffsetX-5), float32(tickY), 1, g.AxisColor, false)
-		labelInt := int(min + ((max-min)/float64(g.NumYTicks))*float64(i))
-
-		g.TextWrapper.DrawText(
-			screen,
-			strconv.Itoa(labelInt),
-			float64(g.OffsetX-30),
-			float64(tickY+5),
-		)
-	}
+// Draw renders the model with r, dataColor colors Data when there is no Model
+func (g *Chart03) Draw(screen *ebiten.Image, r Renderer, dataColor color.Color) {
+	g.draw(&imageCanvas{dst: screen, tw: g.TextWrapper}, screen.Bounds(), r, dataColor)
 }
 
-func (g *Chart03) drawAxisLabels(screen *ebiten.Image) {
-	screenWidth := float64(screen.Bounds().Dx())
-	screenHeight := float64(screen.Bounds().Dy())
+func (g *Chart03) draw(c core.Canvas, bounds image.Rectangle, r Renderer, dataColor color.Color) {
+	g.chart(g.model(dataColor)).Draw(c, bounds, adapt(r))
+}
 
-	g.TextWrapper.DrawText(
-		screen,
-		g.XLabel,
-		float64(screenWidth)-20,
-		float64(screenHeight-g.OffsetY+15),
-	)
+// Plot returns the rectangle and ranges the model is drawn with
+func (g *Chart03) Plot(screen *ebiten.Image, m *core.Model, r Renderer) *core.Plot {
+	return g.PlotRect(screen.Bounds(), m, r)
+}
 
-	g.TextWrapper.DrawText(
-		screen,
-		g.YLabel,
-		float64(g.OffsetX-20),
-		float64(g.OffsetY-20),
-	)
+// PlotRect is Plot for a chart drawn into bounds
+func (g *Chart03) PlotRect(bounds image.Rectangle, m *core.Model, r Renderer) *core.Plot {
+	return g.chart(m).PlotRect(bounds, adapt(r))
 }
 
-func (g *Chart03) minValue() float64 {
-	min := g.Data[0]
-	for _, v := range g.Data {
-		if v < min {
-			min = v
-		}
+// chart is the layout of the chart for the model m
+func (g *Chart03) chart(m *core.Model) *core.Chart {
+	return &core.Chart{
+		Model:     m,
+		XLabel:    g.XLabel,
+		YLabel:    g.YLabel,
+		NumXTicks: g.NumXTicks,
+		NumYTicks: g.NumYTicks,
+		OffsetX:   g.OffsetX,
+		OffsetY:   g.OffsetY,
+		AxisColor: g.AxisColor,
 	}
-	return min
 }
 
-func (g *Chart03) maxValue() float64 {
-	max := g.Data[0]
-	for _, v := range g.Data {
-		if v > max {
-			max = v
-		}
+// model returns the Model, or a single series model of Data
+func (g *Chart03) model(dataColor color.Color) *core.Model {
+	if g.Model != nil {
+		return g.Model
 	}
-	return max
+	s := core.SeriesFromValues("", g.Data)
+	s.Color = dataColor
+	return &core.Model{Series: []*core.Series{s}, Y: core.AxisRange{IncludeZero: true}}
 }
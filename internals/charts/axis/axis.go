@@ -0,0 +1,97 @@
+// Package axis computes the ticks and labels of chart axes.
+//
+// A Scale maps values onto an axis and picks ticks for a range: Linear
+// places them on 1, 2 and 5 steps, Log on powers of its base, Time on
+// calendar units and Category on named positions. Labels come from a
+// Formatter and Fit drops the ones that would overlap once drawn.
+package axis
+
+import "math"
+
+// Tick is a position on an axis, minor ticks have no label
+type Tick struct {
+	Value float64
+	Label string
+	Minor bool
+}
+
+// Scale maps values onto an axis and generates its ticks
+type Scale interface {
+	// Transform maps a value into the space the axis is linear in, NaN or Inf when it has no place
+	Transform(v float64) float64
+	// Untransform is the inverse of Transform
+	Untransform(u float64) float64
+	// Ticks returns the major ticks of [min, max], about count of them, and the minor ticks between
+	Ticks(min, max float64, count int) []Tick
+}
+
+// Normalize returns where v lies in [min, max] as 0..1, a nil scale is linear
+func Normalize(s Scale, v, min, max float64) float64 {
+	if s == nil {
+		return (v - min) / (max - min)
+	}
+	lo, hi := s.Transform(min), s.Transform(max)
+	return (s.Transform(v) - lo) / (hi - lo)
+}
+
+// Fit clears the labels that would overlap. It keeps every k-th labelled tick,
+// k as small as leaves gap pixels between neighbours. position returns the pixel
+// of a value along the axis, size the extent of a label along it.
+func Fit(ticks []Tick, position func(v float64) float64, size func(label string) float64, gap float64) []Tick {
+	var labelled []int
+	for i, t := range ticks {
+		if !t.Minor && t.Label != "" {
+			labelled = append(labelled, i)
+		}
+	}
+	fits := func(k int) bool {
+		for j := k; j < len(labelled); j += k {
+			a, b := ticks[labelled[j-k]], ticks[labelled[j]]
+			if math.Abs(position(b.Value)-position(a.Value)) < (size(a.Label)+size(b.Label))/2+gap {
+				return false
+			}
+		}
+		return true
+	}
+	k := 1
+	for k < len(labelled) && !fits(k) {
+		k++
+	}
+
+	fitted := make([]Tick, len(ticks))
+	copy(fitted, ticks)
+	for j, i := range labelled {
+		if j%k != 0 {
+			fitted[i].Label = ""
+		}
+	}
+	return fitted
+}
+
+// NiceStep rounds span / count to 1, 2 or 5 times a power of ten
+func NiceStep(span float64, count int) float64 {
+	if count < 1 {
+		count = 1
+	}
+	raw := math.Abs(span) / float64(count)
+	if raw == 0 || math.IsInf(raw, 0) || math.IsNaN(raw) {
+		return 1
+	}
+	exp := math.Floor(math.Log10(raw))
+	base := math.Pow(10, exp)
+	switch f := raw / base; {
+	case f < 1.5:
+		return base
+	case f < 3:
+		return 2 * base
+	case f < 7:
+		return 5 * base
+	}
+	return 10 * base
+}
+
+// NiceRange widens [min, max] to the nearest ticks of the nice step
+func NiceRange(min, max float64, count int) (float64, float64, float64) {
+	step := NiceStep(max-min, count)
+	return math.Floor(min/step) * step, math.Ceil(max/step) * step, step
+}
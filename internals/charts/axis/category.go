@@ -0,0 +1,21 @@
+package axis
+
+import "math"
+
+// Category names the whole numbers of an axis, for values that are the
+// position of a label like "Q1" or "shop" in Labels
+type Category struct {
+	Labels []string
+}
+
+func (s *Category) Transform(v float64) float64   { return v }
+func (s *Category) Untransform(u float64) float64 { return u }
+
+// Ticks puts a tick on every label inside [min, max], Fit drops the ones that do not fit
+func (s *Category) Ticks(min, max float64, count int) []Tick {
+	var ticks []Tick
+	for i := math.Max(math.Ceil(min), 0); i <= math.Floor(max) && int(i) < len(s.Labels); i++ {
+		ticks = append(ticks, Tick{Value: i, Label: s.Labels[int(i)]})
+	}
+	return ticks
+}
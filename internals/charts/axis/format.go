@@ -0,0 +1,81 @@
+package axis
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Formatter turns a tick value into its label
+type Formatter func(v float64) string
+
+// Fixed writes a fixed number of decimals
+func Fixed(decimals int) Formatter {
+	return func(v float64) string {
+		return noNegativeZero(strconv.FormatFloat(v, 'f', decimals, 64))
+	}
+}
+
+// General writes the shortest representation, e.g. 0.25 or 1e+21
+func General(v float64) string {
+	return noNegativeZero(strconv.FormatFloat(v, 'g', -1, 64))
+}
+
+// Percent writes fractions as percentages, 0.25 is 25%
+func Percent(decimals int) Formatter {
+	return func(v float64) string {
+		return noNegativeZero(strconv.FormatFloat(v*100, 'f', decimals, 64)) + "%"
+	}
+}
+
+var siPrefixes = []string{"y", "z", "a", "f", "p", "n", "µ", "m", "", "k", "M", "G", "T", "P", "E", "Z", "Y"}
+
+// SI writes values with a metric prefix, 1500 is 1.5k. Trailing zeros of the decimals are dropped.
+func SI(decimals int) Formatter {
+	return func(v float64) string {
+		if v == 0 || math.IsInf(v, 0) || math.IsNaN(v) {
+			return strconv.FormatFloat(v, 'f', 0, 64)
+		}
+		group := int(math.Floor(math.Log10(math.Abs(v)) / 3))
+		group = max(-8, min(8, group))
+		scaled := v / math.Pow(1000, float64(group))
+		// 999.96 rounds up into the next prefix
+		if rounded, _ := strconv.ParseFloat(strconv.FormatFloat(scaled, 'f', decimals, 64), 64); math.Abs(rounded) >= 1000 && group < 8 {
+			group++
+			scaled = v / math.Pow(1000, float64(group))
+		}
+		s := strconv.FormatFloat(scaled, 'f', decimals, 64)
+		if strings.Contains(s, ".") {
+			s = strings.TrimRight(strings.TrimRight(s, "0"), ".")
+		}
+		return noNegativeZero(s) + siPrefixes[group+8]
+	}
+}
+
+// noNegativeZero drops the sign of a value that rounded to zero, -0.00 is 0.00
+func noNegativeZero(s string) string {
+	if strings.HasPrefix(s, "-") && strings.Trim(s, "-0.") == "" {
+		return s[1:]
+	}
+	return s
+}
+
+// TimeFormat writes unix seconds with a time layout, in loc or local time when nil
+func TimeFormat(layout string, loc *time.Location) Formatter {
+	return func(v float64) string {
+		return unixTime(v, loc).Format(layout)
+	}
+}
+
+func unixTime(v float64, loc *time.Location) time.Time {
+	if loc == nil {
+		loc = time.Local
+	}
+	sec, frac := math.Modf(v)
+	return time.Unix(int64(sec), int64(frac*1e9)).In(loc)
+}
+
+func unixSeconds(t time.Time) float64 {
+	return float64(t.UnixNano()) / 1e9
+}
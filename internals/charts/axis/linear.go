@@ -0,0 +1,74 @@
+package axis
+
+import "math"
+
+// Linear places ticks on 1, 2 and 5 steps
+type Linear struct {
+	// Format labels the major ticks, nil shows as many decimals as the step needs
+	Format Formatter
+	// Minor is the number of minor intervals per step, 0 picks 4 or 5 by the step, -1 draws none
+	Minor int
+}
+
+func (l *Linear) Transform(v float64) float64   { return v }
+func (l *Linear) Untransform(u float64) float64 { return u }
+
+// maxLinearTicks caps the major ticks of a range, a span that overflows or a
+// huge count would otherwise loop for ages
+const maxLinearTicks = 1000
+
+func (l *Linear) Ticks(min, max float64, count int) []Tick {
+	if !(max > min) || math.IsInf(min, 0) || math.IsInf(max, 0) {
+		return nil
+	}
+	step := NiceStep(max-min, count)
+	format := l.Format
+	if format == nil {
+		format = Fixed(decimalsFor(step))
+	}
+
+	minor := l.Minor
+	if minor == 0 {
+		minor = 5
+		if mantissa(step) == 2 {
+			minor = 4
+		}
+	}
+
+	var ticks []Tick
+	// Work on step indices so rounding errors do not pile up
+	first, last := math.Ceil(min/step-1e-9), math.Floor(max/step+1e-9)
+	if !(last-first <= maxLinearTicks) {
+		return nil
+	}
+	for i := first - 1; i <= last; i++ {
+		v := cleanZero(i * step)
+		if i >= first {
+			ticks = append(ticks, Tick{Value: v, Label: format(v)})
+		}
+		for j := 1; minor > 0 && j < minor; j++ {
+			m := v + step*float64(j)/float64(minor)
+			if m >= min && m <= max {
+				ticks = append(ticks, Tick{Value: m, Minor: true})
+			}
+		}
+	}
+	return ticks
+}
+
+// mantissa returns 1, 2 or 5 for a nice step
+func mantissa(step float64) int {
+	return int(math.Round(step / math.Pow(10, math.Floor(math.Log10(step)+1e-9))))
+}
+
+func decimalsFor(step float64) int {
+	return max(0, -int(math.Floor(math.Log10(step)+1e-9)))
+}
+
+// cleanZero turns -0 into 0
+func cleanZero(v float64) float64 {
+	if v == 0 {
+		return 0
+	}
+	return v
+}
@@ -0,0 +1,70 @@
+package axis
+
+import "math"
+
+// Log places major ticks on powers of Base and minor ticks on their multiples
+type Log struct {
+	// Base is 10 when 0
+	Base float64
+	// Format labels the major ticks, nil writes 0.01, 100 or 1e6 for base 10
+	Format Formatter
+}
+
+func (l *Log) base() float64 {
+	if l.Base <= 1 {
+		return 10
+	}
+	return l.Base
+}
+
+// Transform returns NaN or -Inf for values that are not positive
+func (l *Log) Transform(v float64) float64 {
+	return math.Log(v) / math.Log(l.base())
+}
+
+func (l *Log) Untransform(u float64) float64 {
+	return math.Pow(l.base(), u)
+}
+
+func (l *Log) Ticks(min, max float64, count int) []Tick {
+	if !(min > 0 && max > min) {
+		return nil
+	}
+	base := l.base()
+	format := l.Format
+	if format == nil {
+		format = logLabel
+	}
+	lo, hi := math.Floor(l.Transform(min)+1e-9), math.Ceil(l.Transform(max)-1e-9)
+
+	// Skip powers when the decades do not fit
+	every := 1.0
+	if count > 0 && hi-lo > float64(count) {
+		every = math.Ceil((hi - lo) / float64(count))
+	}
+
+	var ticks []Tick
+	for p := lo; p <= hi; p++ {
+		decade := l.Untransform(p)
+		if math.Mod(p, every) == 0 && decade >= min*(1-1e-9) && decade <= max*(1+1e-9) {
+			ticks = append(ticks, Tick{Value: decade, Label: format(decade)})
+		}
+		if every > 1 || base != math.Floor(base) {
+			continue
+		}
+		for m := 2.0; m < base; m++ {
+			if v := m * decade; v >= min && v <= max {
+				ticks = append(ticks, Tick{Value: v, Minor: true})
+			}
+		}
+	}
+	return ticks
+}
+
+func logLabel(v float64) string {
+	exp := math.Round(math.Log10(v))
+	if math.Abs(exp) >= 4 && math.Abs(v-math.Pow(10, exp)) < v*1e-9 {
+		return "1e" + Fixed(0)(exp)
+	}
+	return General(v)
+}
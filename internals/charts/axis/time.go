@@ -0,0 +1,148 @@
+package axis
+
+import (
+	"math"
+	"time"
+)
+
+// Time reads values as unix seconds and places ticks on calendar units:
+// whole minutes, midnights, Mondays, the first of a month or of a year.
+type Time struct {
+	// Location is local time when nil
+	Location *time.Location
+	// Format labels the major ticks, nil picks a layout by the tick unit
+	Format Formatter
+}
+
+type timeUnit int
+
+const (
+	unitSecond timeUnit = iota
+	unitMinute
+	unitHour
+	unitDay
+	unitWeek
+	unitMonth
+	unitYear
+)
+
+type timeStep struct {
+	unit   timeUnit
+	n      int
+	layout string
+}
+
+var unitSeconds = map[timeUnit]float64{
+	unitSecond: 1,
+	unitMinute: 60,
+	unitHour:   3600,
+	unitDay:    86400,
+	unitWeek:   7 * 86400,
+	unitMonth:  30.44 * 86400,
+	unitYear:   365.25 * 86400,
+}
+
+var timeSteps = []timeStep{
+	{unitSecond, 1, "15:04:05"}, {unitSecond, 2, "15:04:05"}, {unitSecond, 5, "15:04:05"},
+	{unitSecond, 10, "15:04:05"}, {unitSecond, 15, "15:04:05"}, {unitSecond, 30, "15:04:05"},
+	{unitMinute, 1, "15:04"}, {unitMinute, 2, "15:04"}, {unitMinute, 5, "15:04"},
+	{unitMinute, 10, "15:04"}, {unitMinute, 15, "15:04"}, {unitMinute, 30, "15:04"},
+	{unitHour, 1, "15:04"}, {unitHour, 2, "15:04"}, {unitHour, 3, "15:04"},
+	{unitHour, 6, "Jan 2 15:04"}, {unitHour, 12, "Jan 2 15:04"},
+	{unitDay, 1, "Jan 2"}, {unitDay, 2, "Jan 2"},
+	{unitWeek, 1, "Jan 2"},
+	{unitMonth, 1, "Jan 2006"}, {unitMonth, 3, "Jan 2006"}, {unitMonth, 6, "Jan 2006"},
+	{unitYear, 1, "2006"},
+}
+
+func (s *Time) Transform(v float64) float64   { return v }
+func (s *Time) Untransform(u float64) float64 { return u }
+
+func (s *Time) location() *time.Location {
+	if s.Location == nil {
+		return time.Local
+	}
+	return s.Location
+}
+
+func (s *Time) Ticks(min, max float64, count int) []Tick {
+	if !(max > min) {
+		return nil
+	}
+	step := chooseTimeStep(max-min, count)
+	format := s.Format
+	if format == nil {
+		format = TimeFormat(step.layout, s.location())
+	}
+
+	var ticks []Tick
+	majors := map[int64]bool{}
+	for _, t := range s.times(min, max, step) {
+		majors[t.UnixNano()] = true
+		v := unixSeconds(t)
+		ticks = append(ticks, Tick{Value: v, Label: format(v)})
+	}
+	// Minor ticks on every unit between majors of a few units
+	if step.n > 1 && step.n <= 6 {
+		for _, t := range s.times(min, max, timeStep{unit: step.unit, n: 1}) {
+			if !majors[t.UnixNano()] {
+				ticks = append(ticks, Tick{Value: unixSeconds(t), Minor: true})
+			}
+		}
+	}
+	return ticks
+}
+
+// chooseTimeStep returns the smallest step with at most count ticks over span seconds
+func chooseTimeStep(span float64, count int) timeStep {
+	if count < 1 {
+		count = 1
+	}
+	for _, step := range timeSteps {
+		if span/(unitSeconds[step.unit]*float64(step.n)) <= float64(count) {
+			return step
+		}
+	}
+	years := NiceStep(span/unitSeconds[unitYear], count)
+	return timeStep{unit: unitYear, n: max(1, int(math.Round(years))), layout: "2006"}
+}
+
+// times lists the unit boundaries of the step inside [min, max]
+func (s *Time) times(min, max float64, step timeStep) []time.Time {
+	loc := s.location()
+	start := unixTime(min, loc)
+	end := unixTime(max, loc)
+	y, mo, d := start.Date()
+	midnight := time.Date(y, mo, d, 0, 0, 0, 0, loc)
+
+	var t time.Time
+	var next func(time.Time) time.Time
+	switch step.unit {
+	case unitSecond, unitMinute, unitHour:
+		// All these steps divide a day, so counting from midnight keeps them on round times
+		d := time.Duration(unitSeconds[step.unit]) * time.Second * time.Duration(step.n)
+		t = midnight.Add(time.Duration(math.Ceil(float64(start.Sub(midnight))/float64(d))) * d)
+		next = func(t time.Time) time.Time { return t.Add(d) }
+	case unitDay:
+		t = midnight
+		next = func(t time.Time) time.Time { return t.AddDate(0, 0, step.n) }
+	case unitWeek:
+		t = midnight.AddDate(0, 0, -(int(midnight.Weekday())+6)%7)
+		next = func(t time.Time) time.Time { return t.AddDate(0, 0, 7*step.n) }
+	case unitMonth:
+		m := int(mo) - (int(mo)-1)%step.n
+		t = time.Date(y, time.Month(m), 1, 0, 0, 0, 0, loc)
+		next = func(t time.Time) time.Time { return t.AddDate(0, step.n, 0) }
+	default:
+		t = time.Date(y-y%step.n, 1, 1, 0, 0, 0, 0, loc)
+		next = func(t time.Time) time.Time { return t.AddDate(step.n, 0, 0) }
+	}
+
+	var times []time.Time
+	for ; !t.After(end); t = next(t) {
+		if !t.Before(start) {
+			times = append(times, t)
+		}
+	}
+	return times
+}
@@ -0,0 +1,136 @@
+package charts
+
+import (
+	"image/color"
+	"math"
+	"strconv"
+
+	"example.com/menu/internals/charts/axis"
+	"example.com/menu/internals/charts/core"
+)
+
+// ColorScale maps the values of a heatmap to colors. lo and hi are the range of
+// the heatmap, v is never NaN.
+type ColorScale interface {
+	Color(v, lo, hi float64) color.RGBA
+}
+
+// Viridis runs from dark blue to yellow, it reads in grey and for most color blind people
+var Viridis = []color.RGBA{
+	{68, 1, 84, 255},
+	{59, 82, 139, 255},
+	{33, 145, 140, 255},
+	{94, 201, 98, 255},
+	{253, 231, 37, 255},
+}
+
+// Sequential colors values from low to high along a ramp
+type Sequential struct {
+	// Colors are stops spread evenly over the range, nil is Viridis
+	Colors []color.RGBA
+	// Scale spaces the stops, nil is linear. An axis.Log tells apart values across magnitudes.
+	Scale axis.Scale
+}
+
+func (s *Sequential) Color(v, lo, hi float64) color.RGBA {
+	stops := s.Colors
+	if len(stops) == 0 {
+		stops = Viridis
+	}
+	t := 0.0
+	if hi > lo {
+		t = axis.Normalize(s.Scale, v, lo, hi)
+	}
+	return rampColor(stops, t)
+}
+
+// Diverging colors the values below Center towards Low and above it towards High.
+// The range is made symmetric around Center, so equal distances get equal colors.
+type Diverging struct {
+	// Low, Mid and High are the colors of the far ends and of Center, zero values are blue, light grey and red
+	Low, Mid, High color.RGBA
+	Center         float64
+}
+
+func (d *Diverging) Color(v, lo, hi float64) color.RGBA {
+	low := rgbaOr(d.Low, color.RGBA{59, 76, 192, 255})
+	mid := rgbaOr(d.Mid, color.RGBA{221, 221, 221, 255})
+	high := rgbaOr(d.High, color.RGBA{180, 4, 38, 255})
+	span := math.Max(math.Abs(hi-d.Center), math.Abs(lo-d.Center))
+	if span == 0 {
+		return mid
+	}
+	t := math.Max(-1, math.Min(1, (v-d.Center)/span))
+	if t < 0 {
+		return lerpRGBA(mid, low, -t)
+	}
+	return lerpRGBA(mid, high, t)
+}
+
+// Categorical colors whole numbers as classes: 0 is the first color, 1 the second and so on.
+// Values outside the classes have no color.
+type Categorical struct {
+	// Colors of the classes, repeated when there are more Labels, nil is core.DefaultPalette
+	Colors []color.RGBA
+	// Labels names the classes in the color bar and on hover, without Labels there is a class per color
+	Labels []string
+}
+
+func (c *Categorical) colors() []color.RGBA {
+	if len(c.Colors) == 0 {
+		return core.DefaultPalette
+	}
+	return c.Colors
+}
+
+// count is the number of classes
+func (c *Categorical) count() int {
+	if len(c.Labels) > 0 {
+		return len(c.Labels)
+	}
+	return len(c.colors())
+}
+
+// class returns the class of v, false when v is not one
+func (c *Categorical) class(v float64) (int, bool) {
+	i := int(math.Round(v))
+	return i, i >= 0 && i < c.count()
+}
+
+func (c *Categorical) label(i int) string {
+	if i < len(c.Labels) {
+		return c.Labels[i]
+	}
+	return strconv.Itoa(i)
+}
+
+func (c *Categorical) Color(v, lo, hi float64) color.RGBA {
+	i, ok := c.class(v)
+	if !ok {
+		return color.RGBA{}
+	}
+	colors := c.colors()
+	return colors[i%len(colors)]
+}
+
+// rampColor interpolates the evenly spread stops at t in 0..1
+func rampColor(stops []color.RGBA, t float64) color.RGBA {
+	if len(stops) == 1 || math.IsNaN(t) {
+		return stops[0]
+	}
+	pos := math.Max(0, math.Min(1, t)) * float64(len(stops)-1)
+	i := min(int(pos), len(stops)-2)
+	return lerpRGBA(stops[i], stops[i+1], pos-float64(i))
+}
+
+func lerpRGBA(a, b color.RGBA, t float64) color.RGBA {
+	mix := func(x, y uint8) uint8 { return uint8(float64(x) + (float64(y)-float64(x))*t + 0.5) }
+	return color.RGBA{mix(a.R, b.R), mix(a.G, b.G), mix(a.B, b.B), mix(a.A, b.A)}
+}
+
+func rgbaOr(c, fallback color.RGBA) color.RGBA {
+	if c == (color.RGBA{}) {
+		return fallback
+	}
+	return c
+}
@@ -0,0 +1,84 @@
+package core
+
+import (
+	"image/color"
+	"math"
+)
+
+// AreaRenderer fills the space between each series and 0, or the series below when stacked.
+// The points of a series are expected in increasing x.
+type AreaRenderer struct {
+	Stacked bool
+	// Opacity of the fill, 0 means 0.35. The line on top is opaque.
+	Opacity float32
+}
+
+func (r *AreaRenderer) opacity() float32 {
+	if r.Opacity <= 0 || r.Opacity > 1 {
+		return 0.35
+	}
+	return r.Opacity
+}
+
+// AdjustRanges keeps 0, or the stacked sums, inside an automatic y range
+func (r *AreaRenderer) AdjustRanges(m *Model, plot *Plot) {
+	if r.Stacked {
+		fitStacked(m, plot)
+		return
+	}
+	if !m.Y.Fixed {
+		plot.YMin = math.Min(plot.YMin, 0)
+		plot.YMax = math.Max(plot.YMax, 0)
+	}
+}
+
+func (r *AreaRenderer) Draw(c Canvas, plot *Plot, series []*Series) {
+	var bases [][]float64
+	if r.Stacked {
+		bases = stack(series)
+	}
+	for i, s := range series {
+		var xs, tops, bottoms []float32
+		for j, p := range s.Points {
+			if math.IsNaN(p.X) || math.IsNaN(p.Y) {
+				continue
+			}
+			base := 0.0
+			if r.Stacked {
+				base = bases[i][j]
+			}
+			xs = append(xs, float32(plot.ScreenX(p.X)))
+			tops = append(tops, float32(plot.ScreenY(base+p.Y)))
+			bottoms = append(bottoms, float32(plot.baseline(base)))
+		}
+		if len(xs) < 2 {
+			continue
+		}
+
+		var area Shape
+		area.MoveTo(xs[0], tops[0])
+		for k := 1; k < len(xs); k++ {
+			area.LineTo(xs[k], tops[k])
+		}
+		for k := len(xs) - 1; k >= 0; k-- {
+			area.LineTo(xs[k], bottoms[k])
+		}
+		area.Close()
+		c.Fill(&area, Fade(s.Color, r.opacity()), true)
+		if s.Line != LineNone {
+			StrokePolyline(c, xs, tops, seriesStroke(s, true), s.Color)
+		}
+	}
+}
+
+func (r *AreaRenderer) DrawSwatch(c Canvas, s *Series, x, y, width, height float32) {
+	FillRect(c, x, y, width, height, Fade(s.Color, r.opacity()))
+	StrokeLine(c, x, y, x+width, y, s.lineWidth(), s.Color, false)
+}
+
+// Fade scales the opacity of a color
+func Fade(c color.Color, opacity float32) color.Color {
+	r, g, b, a := c.RGBA()
+	scale := func(v uint32) uint16 { return uint16(float32(v) * opacity) }
+	return color.RGBA64{scale(r), scale(g), scale(b), scale(a)}
+}
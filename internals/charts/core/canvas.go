@@ -0,0 +1,225 @@
+package core
+
+import (
+	"image"
+	"image/color"
+	"math"
+)
+
+// Canvas is what the charts draw on: an image or an SVG document here, an Ebiten
+// image on screen in package charts. The layout code is shared, so every canvas
+// gets the same shapes at the same coordinates.
+type Canvas interface {
+	Fill(s *Shape, clr color.Color, antialias bool)
+	// Stroke outlines the subpaths of s, dashes are already cut into subpaths
+	Stroke(s *Shape, st Stroke, clr color.Color)
+	// Text draws s with its top-left corner at x, y. A nil color is the default text color.
+	Text(s string, x, y float64, clr color.Color)
+	Measure(s string) (width, height float64)
+	// HasText is false when the canvas cannot draw text, labels are skipped then
+	HasText() bool
+	// Clip returns a canvas that draws only into the rectangle
+	Clip(x, y, width, height float64) Canvas
+}
+
+// PixelCanvas is a canvas that draws images, the export canvases are. On screen
+// the heatmap draws its cached Ebiten images itself.
+type PixelCanvas interface {
+	// Pixels stretches img over the rectangle, every pixel a sharp block
+	Pixels(img *image.RGBA, x, y, width, height float64)
+}
+
+// Subpath is a polyline, a polygon when closed
+type Subpath struct {
+	Xs, Ys []float32
+	Closed bool
+}
+
+// Shape is a list of subpaths in pixels. Arcs are flattened, so every canvas
+// draws the same outline.
+type Shape struct {
+	Subpaths []Subpath
+}
+
+func (s *Shape) MoveTo(x, y float32) {
+	s.Subpaths = append(s.Subpaths, Subpath{Xs: []float32{x}, Ys: []float32{y}})
+}
+
+func (s *Shape) LineTo(x, y float32) {
+	if len(s.Subpaths) == 0 {
+		s.MoveTo(x, y)
+		return
+	}
+	last := &s.Subpaths[len(s.Subpaths)-1]
+	last.Xs, last.Ys = append(last.Xs, x), append(last.Ys, y)
+}
+
+func (s *Shape) Close() {
+	if len(s.Subpaths) > 0 {
+		s.Subpaths[len(s.Subpaths)-1].Closed = true
+	}
+}
+
+// Arc adds a circular arc from the angle start to end, lines to its start from the current point.
+// Angles are in radians, clockwise on screen as they grow.
+func (s *Shape) Arc(cx, cy, radius float32, start, end float64) {
+	// Segments short enough to stay within a quarter pixel of the circle
+	step := math.Pi / 8
+	if radius > 0.5 {
+		step = min(step, 2*math.Acos(1-0.25/float64(radius)))
+	}
+	n := max(int(math.Ceil(math.Abs(end-start)/step)), 1)
+	for i := 0; i <= n; i++ {
+		a := start + (end-start)*float64(i)/float64(n)
+		s.LineTo(cx+radius*float32(math.Cos(a)), cy+radius*float32(math.Sin(a)))
+	}
+}
+
+func (s *Shape) Empty() bool {
+	return len(s.Subpaths) == 0
+}
+
+func RectShape(x, y, width, height float32) *Shape {
+	var s Shape
+	s.MoveTo(x, y)
+	s.LineTo(x+width, y)
+	s.LineTo(x+width, y+height)
+	s.LineTo(x, y+height)
+	s.Close()
+	return &s
+}
+
+func circleShape(cx, cy, radius float32) *Shape {
+	var s Shape
+	s.Arc(cx, cy, radius, 0, 2*math.Pi)
+	s.Close()
+	return &s
+}
+
+// Stroke is how a line is drawn
+type Stroke struct {
+	Width     float32
+	Join      LineJoin
+	Dash      []float32
+	RoundCap  bool
+	Antialias bool
+}
+
+// seriesStroke returns the stroke of a series, nil dash for solid lines
+func seriesStroke(s *Series, antialias bool) Stroke {
+	st := Stroke{Width: s.lineWidth(), Join: s.Join, Dash: s.Dash, Antialias: antialias}
+	if st.Dash != nil {
+		return st
+	}
+	switch s.Line {
+	case LineDashed:
+		st.Dash = []float32{6*st.Width + 2, 4*st.Width + 2}
+	case LineDotted:
+		// Zero length dashes with round caps are dots
+		st.Dash = []float32{0.01, 2*st.Width + 2}
+		st.RoundCap = true
+	}
+	return st
+}
+
+func FillRect(c Canvas, x, y, width, height float32, clr color.Color) {
+	c.Fill(RectShape(x, y, width, height), clr, false)
+}
+
+func StrokeRect(c Canvas, x, y, width, height, lineWidth float32, clr color.Color) {
+	c.Stroke(RectShape(x, y, width, height), Stroke{Width: lineWidth, Join: JoinMiter}, clr)
+}
+
+func StrokeLine(c Canvas, x0, y0, x1, y1, width float32, clr color.Color, antialias bool) {
+	var s Shape
+	s.MoveTo(x0, y0)
+	s.LineTo(x1, y1)
+	c.Stroke(&s, Stroke{Width: width, Antialias: antialias}, clr)
+}
+
+// StrokePolyline draws the line through consecutive points, a NaN point breaks it.
+// The points go into a few shapes, dashes continue around the corners.
+func StrokePolyline(c Canvas, xs, ys []float32, st Stroke, clr color.Color) {
+	// Keep each shape well below the vertex limit of one DrawTriangles call
+	const chunk = 2000
+	var s Shape
+	segments := 0
+	flush := func() {
+		if !s.Empty() {
+			c.Stroke(&s, st, clr)
+		}
+		s = Shape{}
+		segments = 0
+	}
+
+	dash, phase := 0, float32(0)
+	drawing := false
+	for i := 1; i < len(xs); i++ {
+		x0, y0, x1, y1 := xs[i-1], ys[i-1], xs[i], ys[i]
+		if isNaN32(x0) || isNaN32(y0) || isNaN32(x1) || isNaN32(y1) {
+			drawing = false
+			continue
+		}
+		if segments >= chunk {
+			flush()
+			drawing = false
+		}
+		segments++
+		if st.Dash == nil {
+			if !drawing {
+				s.MoveTo(x0, y0)
+				drawing = true
+			}
+			s.LineTo(x1, y1)
+			continue
+		}
+
+		length := float32(math.Hypot(float64(x1-x0), float64(y1-y0)))
+		if length == 0 {
+			continue
+		}
+		dx, dy := (x1-x0)/length, (y1-y0)/length
+		for pos := float32(0); pos < length; {
+			step := min(st.Dash[dash]-phase, length-pos)
+			if dash%2 == 0 {
+				if !drawing {
+					s.MoveTo(x0+dx*pos, y0+dy*pos)
+					drawing = true
+				}
+				s.LineTo(x0+dx*(pos+step), y0+dy*(pos+step))
+			}
+			pos += step
+			phase += step
+			if phase >= st.Dash[dash] {
+				phase = 0
+				dash = (dash + 1) % len(st.Dash)
+				drawing = false
+			}
+		}
+	}
+	flush()
+}
+
+func DrawMarker(c Canvas, marker MarkerShape, x, y, size float32, clr color.Color) {
+	half := size / 2
+	switch marker {
+	case MarkerCircle:
+		c.Fill(circleShape(x, y, half), clr, true)
+	case MarkerSquare:
+		FillRect(c, x-half, y-half, size, size, clr)
+	case MarkerTriangle:
+		var s Shape
+		s.MoveTo(x, y-half)
+		s.LineTo(x+half, y+half)
+		s.LineTo(x-half, y+half)
+		s.Close()
+		c.Fill(&s, clr, true)
+	case MarkerCross:
+		StrokeLine(c, x-half, y-half, x+half, y+half, 2, clr, true)
+		StrokeLine(c, x-half, y+half, x+half, y-half, 2, clr, true)
+	}
+}
+
+func isNaN32(v float32) bool {
+	return v != v
+}
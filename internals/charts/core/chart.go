@@ -0,0 +1,113 @@
+package core
+
+import (
+	"image"
+	"image/color"
+
+	"example.com/menu/internals/charts/axis"
+)
+
+// Chart draws a Model with axes, ticks and a legend on any Canvas.
+// Package charts draws it on screen with Chart03.
+type Chart struct {
+	Model     *Model
+	XLabel    string
+	YLabel    string
+	NumXTicks int
+	NumYTicks int
+	// OffsetX and OffsetY are the margins around the plot, for the ticks and labels
+	OffsetX   float64
+	OffsetY   float64
+	AxisColor color.RGBA
+}
+
+// Draw draws the model with r into bounds
+func (g *Chart) Draw(c Canvas, bounds image.Rectangle, r Renderer) {
+	m := g.Model
+	plot := g.PlotRect(bounds, r)
+
+	target := c
+	if m.X.Fixed || m.Y.Fixed {
+		// Points outside a fixed range must not spill over the axes
+		target = c.Clip(plot.X, plot.Y, plot.Width, plot.Height)
+	}
+	r.Draw(target, plot, m.Visible())
+
+	if h, ok := r.(AxesHider); !ok || !h.HideAxes() {
+		g.drawAxis(c, plot, m)
+	}
+	m.Legend.Draw(c, plot, m.Series, r)
+}
+
+// PlotRect returns the rectangle and ranges the model is drawn with into bounds
+func (g *Chart) PlotRect(bounds image.Rectangle, r Renderer) *Plot {
+	m := g.Model
+	plot := &Plot{
+		X:      float64(bounds.Min.X) + g.OffsetX,
+		Y:      float64(bounds.Min.Y) + g.OffsetY,
+		Width:  float64(bounds.Dx()) - g.OffsetX*2,
+		Height: float64(bounds.Dy()) - g.OffsetY*2,
+	}
+	plot.XMin, plot.XMax, plot.YMin, plot.YMax = m.Ranges()
+	plot.XScale, plot.YScale = m.X.Scale, m.Y.Scale
+	if a, ok := r.(RangeAdjuster); ok {
+		a.AdjustRanges(m, plot)
+	}
+	return plot
+}
+
+func (g *Chart) drawAxis(c Canvas, plot *Plot, m *Model) {
+	left, top := float32(plot.X), float32(plot.Y)
+	right, bottom := float32(plot.X+plot.Width), float32(plot.Y+plot.Height)
+
+	StrokeLine(c, left, top, left, bottom, 1, g.AxisColor, false)
+	StrokeLine(c, left, bottom, right, bottom, 1, g.AxisColor, false)
+
+	g.drawAxisLabels(c, plot)
+
+	xTicks := m.X.Ticks(plot.XMin, plot.XMax, g.NumXTicks)
+	yTicks := m.Y.Ticks(plot.YMin, plot.YMax, g.NumYTicks)
+	if c.HasText() {
+		xTicks = axis.Fit(xTicks, plot.ScreenX, func(label string) float64 {
+			w, _ := c.Measure(label)
+			return w
+		}, 8)
+		yTicks = axis.Fit(yTicks, plot.ScreenY, func(label string) float64 {
+			_, h := c.Measure(label)
+			return h
+		}, 2)
+	}
+
+	for _, t := range xTicks {
+		tickX := float32(plot.ScreenX(t.Value))
+		StrokeLine(c, tickX, bottom, tickX, bottom+TickLength(t), 1, g.AxisColor, false)
+		if c.HasText() && t.Label != "" {
+			w, _ := c.Measure(t.Label)
+			c.Text(t.Label, float64(tickX)-w/2, float64(bottom)+8, nil)
+		}
+	}
+
+	for _, t := range yTicks {
+		tickY := float32(plot.ScreenY(t.Value))
+		StrokeLine(c, left, tickY, left-TickLength(t), tickY, 1, g.AxisColor, false)
+		if c.HasText() && t.Label != "" {
+			w, h := c.Measure(t.Label)
+			c.Text(t.Label, float64(left)-8-w, float64(tickY)-h/2, nil)
+		}
+	}
+}
+
+func TickLength(t axis.Tick) float32 {
+	if t.Minor {
+		return 3
+	}
+	return 5
+}
+
+func (g *Chart) drawAxisLabels(c Canvas, plot *Plot) {
+	if !c.HasText() {
+		return
+	}
+	c.Text(g.XLabel, plot.X+plot.Width-20, plot.Y+plot.Height+25, nil)
+	c.Text(g.YLabel, plot.X-20, plot.Y-30, nil)
+}
@@ -0,0 +1,174 @@
+package core
+
+import (
+	"bufio"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"io"
+	"os"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/font/sfnt"
+)
+
+// ExportOptions sets the size and look of a chart drawn without a screen
+type ExportOptions struct {
+	// Width and Height are the size of the chart in pixels at 96 DPI, 0 means 800 x 600
+	Width, Height int
+	// DPI scales the output, 0 means 96. At 192 DPI a PNG has twice the pixels in each direction
+	// and the same layout.
+	DPI float64
+	// Background fills the chart first, nil leaves it transparent
+	Background color.Color
+	// TextColor is the color of the labels, nil is black
+	TextColor color.Color
+	// FontFile is a TrueType or OpenType font for the labels, empty is Go Regular
+	FontFile string
+	// FontSize is in pixels at 96 DPI, 0 means 12
+	FontSize float64
+}
+
+func (o ExportOptions) size() (int, int) {
+	if o.Width <= 0 || o.Height <= 0 {
+		return 800, 600
+	}
+	return o.Width, o.Height
+}
+
+func (o ExportOptions) scale() float64 {
+	if o.DPI <= 0 {
+		return 1
+	}
+	return o.DPI / 96
+}
+
+// exportFont is the font of an export with the face text is measured with.
+// The face is at 96 DPI, so text takes the same room at every scale.
+type exportFont struct {
+	font   *opentype.Font
+	size   float64
+	face   font.Face
+	family string
+}
+
+func newExportFont(opts ExportOptions) (*exportFont, error) {
+	data := goregular.TTF
+	if opts.FontFile != "" {
+		var err error
+		if data, err = os.ReadFile(opts.FontFile); err != nil {
+			return nil, fmt.Errorf("failed to read font file: %w", err)
+		}
+	}
+	f, err := opentype.Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse font: %w", err)
+	}
+	size := opts.FontSize
+	if size <= 0 {
+		size = 12
+	}
+	family, err := f.Name(nil, sfnt.NameIDFamily)
+	if err != nil || family == "" {
+		family = "sans-serif"
+	}
+	ef := &exportFont{font: f, size: size, family: family}
+	if ef.face, err = ef.faceAt(1); err != nil {
+		return nil, err
+	}
+	return ef, nil
+}
+
+// faceAt returns the face of the font for an image scale times the size of the chart
+func (f *exportFont) faceAt(scale float64) (font.Face, error) {
+	face, err := opentype.NewFace(f.font, &opentype.FaceOptions{Size: f.size, DPI: 72 * scale, Hinting: font.HintingNone})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create font face: %w", err)
+	}
+	return face, nil
+}
+
+// measure returns the advance of s and the line height, like TextWrapper.MeasureText
+func (f *exportFont) measure(s string) (float64, float64) {
+	width := font.MeasureString(f.face, s)
+	return float64(width) / 64, float64(f.face.Metrics().Height) / 64
+}
+
+func (f *exportFont) ascent() float64 {
+	return float64(f.face.Metrics().Ascent) / 64
+}
+
+func (o ExportOptions) textColor() color.Color {
+	if o.TextColor == nil {
+		return color.Black
+	}
+	return o.TextColor
+}
+
+// ExportImage draws into a new image the size of the options, in chart pixels from 0, 0
+func ExportImage(opts ExportOptions, paint func(c Canvas, bounds image.Rectangle)) (*image.RGBA, error) {
+	f, err := newExportFont(opts)
+	if err != nil {
+		return nil, err
+	}
+	width, height := opts.size()
+	scale := opts.scale()
+	img := image.NewRGBA(image.Rect(0, 0, int(float64(width)*scale+0.5), int(float64(height)*scale+0.5)))
+	if opts.Background != nil {
+		draw.Draw(img, img.Bounds(), image.NewUniform(opts.Background), image.Point{}, draw.Src)
+	}
+	c, err := newRasterCanvas(img, scale, f, opts.textColor())
+	if err != nil {
+		return nil, err
+	}
+	paint(c, image.Rect(0, 0, width, height))
+	return img, nil
+}
+
+// ExportSVG draws into an SVG document the size of the options
+func ExportSVG(w io.Writer, opts ExportOptions, paint func(c Canvas, bounds image.Rectangle)) error {
+	f, err := newExportFont(opts)
+	if err != nil {
+		return err
+	}
+	width, height := opts.size()
+	bw := bufio.NewWriter(w)
+	c := newSVGCanvas(bw, f, opts.textColor())
+	c.begin(width, height, opts.scale(), opts.Background)
+	paint(c, image.Rect(0, 0, width, height))
+	c.end()
+	if c.doc.err != nil {
+		return c.doc.err
+	}
+	return bw.Flush()
+}
+
+// ExportImage draws the chart with r into a new image, in software and without a GPU.
+// The layout is the one Draw gives a canvas of the same size.
+func (g *Chart) ExportImage(r Renderer, opts ExportOptions) (*image.RGBA, error) {
+	return ExportImage(opts, func(c Canvas, bounds image.Rectangle) {
+		g.Draw(c, bounds, r)
+	})
+}
+
+// ExportPNG writes the chart drawn by ExportImage as a PNG
+func (g *Chart) ExportPNG(w io.Writer, r Renderer, opts ExportOptions) error {
+	img, err := g.ExportImage(r, opts)
+	if err != nil {
+		return err
+	}
+	return png.Encode(w, img)
+}
+
+// ExportSVG writes the chart as an SVG document with the layout of ExportImage.
+// Text is measured with the export font and names its family, viewers without it
+// fall back to a sans-serif font.
+func (g *Chart) ExportSVG(w io.Writer, r Renderer, opts ExportOptions) error {
+	return ExportSVG(w, opts, func(c Canvas, bounds image.Rectangle) {
+		g.Draw(c, bounds, r)
+	})
+}
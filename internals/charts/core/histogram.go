@@ -0,0 +1,94 @@
+package core
+
+import (
+	"math"
+	"sort"
+
+	"example.com/menu/internals/charts/axis"
+)
+
+// Bin is one class of a histogram, Low included and High excluded
+type Bin struct {
+	Low, High float64
+	Count     int
+}
+
+// maxAutoBins is the most bins the automatic width gives, unless Sturges' rule asks for more
+const maxAutoBins = 1000
+
+// Bins sorts values into count classes of equal width. With count 0 the width
+// follows the Freedman-Diaconis rule, rounded to a nice step, or Sturges' rule
+// when the values are too concentrated for it, with at most maxAutoBins bins.
+// NaN values are ignored.
+func Bins(values []float64, count int) []Bin {
+	sorted := make([]float64, 0, len(values))
+	for _, v := range values {
+		if !math.IsNaN(v) && !math.IsInf(v, 0) {
+			sorted = append(sorted, v)
+		}
+	}
+	if len(sorted) == 0 {
+		return nil
+	}
+	sort.Float64s(sorted)
+	lo, hi := sorted[0], sorted[len(sorted)-1]
+	if lo == hi {
+		return []Bin{{Low: lo - 0.5, High: hi + 0.5, Count: len(sorted)}}
+	}
+
+	var width, start float64
+	if count > 0 {
+		width, start = (hi-lo)/float64(count), lo
+	} else {
+		n := float64(len(sorted))
+		iqr := quantile(sorted, 0.75) - quantile(sorted, 0.25)
+		sturges := math.Ceil(math.Log2(n)) + 1
+		width = 2 * iqr / math.Cbrt(n)
+		if width <= 0 {
+			width = (hi - lo) / sturges
+		}
+		// A few outliers far from a narrow middle would need billions of bins,
+		// the bins widen to keep their count below the limit
+		limit := math.Max(sturges, maxAutoBins)
+		width = axis.NiceStep(math.Max(width, (hi-lo)/limit), 1)
+		start = math.Floor(lo/width) * width
+		for (hi-start)/width >= limit {
+			width = axis.NiceStep(width*2, 1)
+			start = math.Floor(lo/width) * width
+		}
+		count = int(math.Floor((hi-start)/width)) + 1
+	}
+
+	bins := make([]Bin, count)
+	for i := range bins {
+		bins[i].Low = start + width*float64(i)
+		bins[i].High = start + width*float64(i+1)
+	}
+	for _, v := range sorted {
+		i := int((v - start) / width)
+		// The largest value closes the last bin
+		bins[min(max(i, 0), count-1)].Count++
+	}
+	return bins
+}
+
+// quantile interpolates between the sorted values
+func quantile(sorted []float64, q float64) float64 {
+	pos := q * float64(len(sorted)-1)
+	i := int(pos)
+	if i+1 >= len(sorted) {
+		return sorted[len(sorted)-1]
+	}
+	return sorted[i] + (sorted[i+1]-sorted[i])*(pos-float64(i))
+}
+
+// NewHistogram bins the values into a series of counts at the bin centers,
+// drawn as touching bars by a BarRenderer with Gutter 1
+func NewHistogram(name string, values []float64, count int) *Series {
+	bins := Bins(values, count)
+	s := &Series{Name: name, Points: make([]Point, len(bins))}
+	for i, b := range bins {
+		s.Points[i] = Point{(b.Low + b.High) / 2, float64(b.Count)}
+	}
+	return s
+}
@@ -0,0 +1,116 @@
+package core
+
+import (
+	"image/color"
+
+	"example.com/menu/internals/utils"
+)
+
+type LegendPosition int
+
+const (
+	LegendTopRight LegendPosition = iota
+	LegendTopLeft
+	LegendBottomRight
+	LegendBottomLeft
+)
+
+// Legend lists the named series in a corner of the plot
+type Legend struct {
+	Visible     bool
+	Position    LegendPosition
+	Background  color.Color
+	BorderColor color.Color
+	TextColor   color.Color
+	// ShowHidden keeps hidden series in the list, greyed out
+	ShowHidden bool
+}
+
+func (l *Legend) Toggle() {
+	l.Visible = !l.Visible
+}
+
+func (l *Legend) entries(series []*Series) []*Series {
+	entries := make([]*Series, 0, len(series))
+	for _, s := range series {
+		if s.Name != "" && (!s.Hidden || l.ShowHidden) {
+			entries = append(entries, s)
+		}
+	}
+	return entries
+}
+
+// legendBox is the layout of a drawn legend
+type legendBox struct {
+	x, y, width, height float32
+	rowHeight           float32
+	entries             []*Series
+}
+
+const legendPadding, legendSwatch, legendGap, legendMargin = 6, 20, 6, 8
+
+// layout places the legend in its corner of the plot, ok is false when nothing is drawn
+func (l *Legend) layout(plot *Plot, series []*Series, c Canvas) (box legendBox, ok bool) {
+	box.entries = l.entries(series)
+	if !l.Visible || len(box.entries) == 0 || !c.HasText() {
+		return box, false
+	}
+	textWidth, rowHeight := 0.0, 0.0
+	for _, s := range box.entries {
+		w, h := c.Measure(s.Name)
+		textWidth, rowHeight = max(textWidth, w), max(rowHeight, h)
+	}
+	box.rowHeight = float32(rowHeight) + 4
+	box.width = float32(2*legendPadding+legendSwatch+legendGap) + float32(textWidth)
+	box.height = 2*legendPadding + box.rowHeight*float32(len(box.entries))
+
+	box.x = float32(plot.X+plot.Width) - box.width - legendMargin
+	box.y = float32(plot.Y) + legendMargin
+	if l.Position == LegendTopLeft || l.Position == LegendBottomLeft {
+		box.x = float32(plot.X) + legendMargin
+	}
+	if l.Position == LegendBottomRight || l.Position == LegendBottomLeft {
+		box.y = float32(plot.Y+plot.Height) - box.height - legendMargin
+	}
+	return box, true
+}
+
+// Contains reports whether x, y is on the legend drawn for the plot, c measures the names
+func (l *Legend) Contains(plot *Plot, series []*Series, c Canvas, x, y float32) bool {
+	box, ok := l.layout(plot, series, c)
+	return ok && x >= box.x && x < box.x+box.width && y >= box.y && y < box.y+box.height
+}
+
+// EntryAt returns the series of the legend row at x, y, nil when there is none
+func (l *Legend) EntryAt(plot *Plot, series []*Series, c Canvas, x, y float32) *Series {
+	box, ok := l.layout(plot, series, c)
+	if !ok || x < box.x || x >= box.x+box.width {
+		return nil
+	}
+	row := int((y - box.y - legendPadding) / box.rowHeight)
+	if y < box.y+legendPadding || row >= len(box.entries) {
+		return nil
+	}
+	return box.entries[row]
+}
+
+// Draw draws the legend inside the plot, the renderer draws the sample of each series
+func (l *Legend) Draw(c Canvas, plot *Plot, series []*Series, r Renderer) {
+	box, ok := l.layout(plot, series, c)
+	if !ok {
+		return
+	}
+	FillRect(c, box.x, box.y, box.width, box.height, utils.ColorOr(l.Background, color.RGBA{0, 0, 0, 160}))
+	StrokeRect(c, box.x, box.y, box.width, box.height, 1, utils.ColorOr(l.BorderColor, color.RGBA{128, 128, 128, 255}))
+
+	textColor := utils.ColorOr(l.TextColor, color.White)
+	for i, s := range box.entries {
+		rowY := box.y + legendPadding + box.rowHeight*float32(i)
+		r.DrawSwatch(c, s, box.x+legendPadding, rowY+2, legendSwatch, box.rowHeight-4)
+		clr := textColor
+		if s.Hidden {
+			clr = color.RGBA{128, 128, 128, 255}
+		}
+		c.Text(s.Name, float64(box.x+legendPadding+legendSwatch+legendGap), float64(rowY)+2, clr)
+	}
+}
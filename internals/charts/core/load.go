@@ -0,0 +1,503 @@
+package core
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"example.com/menu/internals/charts/axis"
+)
+
+// HeaderMode says whether the first CSV row names the columns
+type HeaderMode int
+
+const (
+	// HeaderAuto takes the first row as names when it has text where the next row has values
+	HeaderAuto HeaderMode = iota
+	HeaderYes
+	HeaderNo
+)
+
+// LoadOptions maps the columns of a table onto a model. Columns are picked by
+// their header name, or by their number from 0 when no header has that name.
+type LoadOptions struct {
+	// Comma separates CSV fields, 0 picks ',', ';' or tab by their count in the first line
+	Comma  rune
+	Header HeaderMode
+	// DecimalComma reads "1,5" as 1.5, for files that separate fields with ';'
+	DecimalComma bool
+	// X is the column of the x values, empty numbers the rows from 0. Dates become
+	// unix seconds on an axis.Time scale, text becomes positions on an axis.Category scale.
+	X string
+	// Y are the columns that become series, empty takes every other column whose first value is a number
+	Y []string
+	// TimeLayouts are tried in order on dates, empty tries RFC 3339, "2006-01-02 15:04:05", "2006-01-02" and "2006-01"
+	TimeLayouts []string
+	// Location of dates without a zone, nil is time.Local
+	Location *time.Location
+}
+
+var defaultTimeLayouts = []string{time.RFC3339, "2006-01-02 15:04:05", "2006-01-02T15:04:05", "2006-01-02", "2006-01"}
+
+// RowError is a row that could not be read, Line counts from 1 in the file
+type RowError struct {
+	Line int
+	// Column is the name of the bad field, empty when the whole row is wrong
+	Column string
+	Err    error
+}
+
+func (e *RowError) Error() string {
+	if e.Column == "" {
+		return fmt.Sprintf("line %d: %v", e.Line, e.Err)
+	}
+	return fmt.Sprintf("line %d, column %q: %v", e.Line, e.Column, e.Err)
+}
+
+func (e *RowError) Unwrap() error {
+	return e.Err
+}
+
+func (e *RowError) withLine(line int) *RowError {
+	e.Line = line
+	return e
+}
+
+// RowErrors are the malformed rows of a table. The loaders return them along with
+// a model of the other rows, so one bad line does not lose a whole file.
+type RowErrors []*RowError
+
+func (e RowErrors) Error() string {
+	const shown = 3
+	messages := make([]string, 0, shown+1)
+	for i, err := range e {
+		if i == shown {
+			messages = append(messages, fmt.Sprintf("and %d more", len(e)-shown))
+			break
+		}
+		messages = append(messages, err.Error())
+	}
+	return fmt.Sprintf("%d malformed rows: %s", len(e), strings.Join(messages, "; "))
+}
+
+// LoadFile reads a .json file with LoadJSON and any other file with LoadCSV
+func LoadFile(path string, opts LoadOptions) (*Model, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		return LoadJSON(f, opts)
+	}
+	return LoadCSV(f, opts)
+}
+
+// LoadCSV reads a table with one row per x into a model with a series per y column.
+// Empty fields are gaps. Malformed rows are left out and returned as RowErrors
+// with the model, other errors return no model.
+func LoadCSV(r io.Reader, opts LoadOptions) (*Model, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	data = bytes.TrimPrefix(data, []byte("\ufeff"))
+	comma := opts.Comma
+	if comma == 0 {
+		comma = detectComma(data)
+	}
+
+	cr := csv.NewReader(bytes.NewReader(data))
+	cr.Comma = comma
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+	var records []tableRow
+	var errs RowErrors
+	for {
+		fields, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			errs = append(errs, &RowError{Line: parseErr.StartLine, Err: parseErr.Err})
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		line, _ := cr.FieldPos(0)
+		records = append(records, tableRow{line: line, fields: fields})
+	}
+	if len(records) == 0 {
+		return nil, errors.New("core: no rows to load")
+	}
+
+	t := &table{rows: records, errs: errs}
+	if opts.Header == HeaderYes || opts.Header == HeaderAuto && looksLikeHeader(records, opts) {
+		for _, name := range records[0].fields {
+			t.header = append(t.header, strings.TrimSpace(name))
+		}
+		t.rows = records[1:]
+	} else {
+		for i := range records[0].fields {
+			t.header = append(t.header, strconv.Itoa(i))
+		}
+	}
+	return t.model(opts)
+}
+
+// LoadJSON reads an array of records, objects with a field per column:
+// [{"day": "2024-05-01", "web": 10, "store": 4}, ...]. The columns are in the
+// order they first appear. Numbers may be strings, null or a missing field is a gap.
+func LoadJSON(r io.Reader, opts LoadOptions) (*Model, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('[') {
+		return nil, errors.New("core: JSON data is not an array of records")
+	}
+
+	t := &table{}
+	columns := map[string]int{}
+	for dec.More() {
+		line := lineAt(data, dec.InputOffset())
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		fields, err := t.record(raw, columns)
+		if err != nil {
+			t.errs = append(t.errs, err.withLine(line))
+			continue
+		}
+		t.rows = append(t.rows, tableRow{line: line, fields: fields})
+	}
+	// Records before a new column do not have it
+	for i := range t.rows {
+		for len(t.rows[i].fields) < len(t.header) {
+			t.rows[i].fields = append(t.rows[i].fields, "")
+		}
+	}
+	if len(t.rows) == 0 && len(t.errs) == 0 {
+		return nil, errors.New("core: no rows to load")
+	}
+	return t.model(opts)
+}
+
+// table is CSV or JSON data as text fields, before the columns are parsed
+type table struct {
+	header []string
+	rows   []tableRow
+	errs   RowErrors
+}
+
+type tableRow struct {
+	line   int
+	fields []string
+}
+
+// record turns a JSON object into fields, adding its new keys to the header
+func (t *table) record(raw json.RawMessage, columns map[string]int) ([]string, *RowError) {
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	if tok, _ := dec.Token(); tok != json.Delim('{') {
+		return nil, &RowError{Err: errors.New("record is not an object")}
+	}
+	fields := make([]string, len(t.header))
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, &RowError{Err: err}
+		}
+		key := tok.(string)
+		var value any
+		if err := dec.Decode(&value); err != nil {
+			return nil, &RowError{Column: key, Err: err}
+		}
+		column, ok := columns[key]
+		if !ok {
+			column = len(t.header)
+			columns[key] = column
+			t.header = append(t.header, key)
+		}
+		for len(fields) <= column {
+			fields = append(fields, "")
+		}
+		switch v := value.(type) {
+		case json.Number:
+			fields[column] = v.String()
+		case string:
+			fields[column] = v
+		case nil:
+		default:
+			return nil, &RowError{Column: key, Err: fmt.Errorf("%v is not a number, date or text", v)}
+		}
+	}
+	return fields, nil
+}
+
+// xKind is how the x column is read
+type xKind int
+
+const (
+	xIndex xKind = iota
+	xNumber
+	xTime
+	xCategory
+)
+
+// model parses the picked columns into series
+func (t *table) model(opts LoadOptions) (*Model, error) {
+	xColumn := -1
+	if opts.X != "" {
+		var err error
+		if xColumn, err = t.column(opts.X); err != nil {
+			return nil, err
+		}
+	}
+	yColumns, err := t.yColumns(xColumn, opts)
+	if err != nil {
+		return nil, err
+	}
+	kind := t.xKind(xColumn, opts)
+
+	series := make([]*Series, len(yColumns))
+	for i, c := range yColumns {
+		series[i] = &Series{Name: t.header[c]}
+	}
+	var labels []string
+	categories := map[string]int{}
+	errs := t.errs
+rows:
+	for i, row := range t.rows {
+		if len(row.fields) != len(t.header) {
+			errs = append(errs, &RowError{Line: row.line, Err: fmt.Errorf("has %d fields, the header has %d", len(row.fields), len(t.header))})
+			continue
+		}
+		x := float64(i)
+		if xColumn >= 0 {
+			field := strings.TrimSpace(row.fields[xColumn])
+			ok := true
+			switch kind {
+			case xNumber:
+				x, ok = parseNumber(field, opts.DecimalComma)
+			case xTime:
+				x, ok = parseTime(field, opts)
+			case xCategory:
+				position, seen := categories[field]
+				if !seen {
+					position = len(labels)
+					categories[field] = position
+					labels = append(labels, field)
+				}
+				x = float64(position)
+			}
+			if !ok {
+				errs = append(errs, &RowError{Line: row.line, Column: t.header[xColumn], Err: fmt.Errorf("%q is not a %s", field, kind)})
+				continue
+			}
+		}
+
+		ys := make([]float64, len(yColumns))
+		for j, c := range yColumns {
+			field := strings.TrimSpace(row.fields[c])
+			if field == "" {
+				ys[j] = math.NaN()
+				continue
+			}
+			v, ok := parseNumber(field, opts.DecimalComma)
+			if !ok {
+				errs = append(errs, &RowError{Line: row.line, Column: t.header[c], Err: fmt.Errorf("%q is not a number", field)})
+				continue rows
+			}
+			ys[j] = v
+		}
+		for j, s := range series {
+			s.Append(x, ys[j])
+		}
+	}
+
+	m := NewModel(series...)
+	switch kind {
+	case xTime:
+		m.X.Scale = &axis.Time{Location: opts.Location}
+	case xCategory:
+		m.X.Scale = &axis.Category{Labels: labels}
+	}
+	if len(errs) > 0 {
+		return m, errs
+	}
+	return m, nil
+}
+
+func (k xKind) String() string {
+	switch k {
+	case xTime:
+		return "date"
+	case xCategory:
+		return "label"
+	}
+	return "number"
+}
+
+// column finds a column by name, then by number
+func (t *table) column(name string) (int, error) {
+	for i, h := range t.header {
+		if h == name {
+			return i, nil
+		}
+	}
+	if i, err := strconv.Atoi(name); err == nil && i >= 0 && i < len(t.header) {
+		return i, nil
+	}
+	return -1, fmt.Errorf("core: no column %q in %s", name, strings.Join(t.header, ", "))
+}
+
+func (t *table) yColumns(xColumn int, opts LoadOptions) ([]int, error) {
+	var columns []int
+	for _, name := range opts.Y {
+		c, err := t.column(name)
+		if err != nil {
+			return nil, err
+		}
+		columns = append(columns, c)
+	}
+	if len(opts.Y) > 0 {
+		return columns, nil
+	}
+	for c := range t.header {
+		if c == xColumn {
+			continue
+		}
+		if field := t.firstValue(c); field != "" {
+			if _, ok := parseNumber(field, opts.DecimalComma); ok {
+				columns = append(columns, c)
+			}
+		}
+	}
+	if len(columns) == 0 {
+		return nil, errors.New("core: no numeric columns to load")
+	}
+	return columns, nil
+}
+
+// xKind reads the x column as numbers, dates or labels by its first value
+func (t *table) xKind(xColumn int, opts LoadOptions) xKind {
+	if xColumn < 0 {
+		return xIndex
+	}
+	field := t.firstValue(xColumn)
+	if _, ok := parseNumber(field, opts.DecimalComma); ok || field == "" {
+		return xNumber
+	}
+	if _, ok := parseTime(field, opts); ok {
+		return xTime
+	}
+	return xCategory
+}
+
+// firstValue returns the first field of the column that is not empty
+func (t *table) firstValue(column int) string {
+	for _, row := range t.rows {
+		if column < len(row.fields) {
+			if field := strings.TrimSpace(row.fields[column]); field != "" {
+				return field
+			}
+		}
+	}
+	return ""
+}
+
+func parseNumber(field string, decimalComma bool) (float64, bool) {
+	if decimalComma {
+		field = strings.Replace(field, ",", ".", 1)
+	}
+	v, err := strconv.ParseFloat(field, 64)
+	return v, err == nil
+}
+
+// parseTime returns the date as unix seconds
+func parseTime(field string, opts LoadOptions) (float64, bool) {
+	layouts := opts.TimeLayouts
+	if len(layouts) == 0 {
+		layouts = defaultTimeLayouts
+	}
+	loc := opts.Location
+	if loc == nil {
+		loc = time.Local
+	}
+	for _, layout := range layouts {
+		if t, err := time.ParseInLocation(layout, field, loc); err == nil {
+			return float64(t.UnixNano()) / 1e9, true
+		}
+	}
+	return 0, false
+}
+
+// looksLikeHeader is true when the first row has text in a column where the second has a value
+func looksLikeHeader(records []tableRow, opts LoadOptions) bool {
+	isValue := func(field string) bool {
+		field = strings.TrimSpace(field)
+		_, number := parseNumber(field, opts.DecimalComma)
+		_, date := parseTime(field, opts)
+		return number || date
+	}
+	first := records[0].fields
+	for i, field := range first {
+		if strings.TrimSpace(field) == "" || isValue(field) {
+			continue
+		}
+		if len(records) == 1 || i < len(records[1].fields) && isValue(records[1].fields[i]) {
+			return true
+		}
+	}
+	return false
+}
+
+// detectComma picks the most common of ',', ';' and tab outside quotes in the first line
+func detectComma(data []byte) rune {
+	counts := map[rune]int{}
+	quoted := false
+	for _, b := range data {
+		if b == '\n' && !quoted {
+			break
+		}
+		switch b {
+		case '"':
+			quoted = !quoted
+		case ',', ';', '\t':
+			if !quoted {
+				counts[rune(b)]++
+			}
+		}
+	}
+	comma := ','
+	for _, c := range []rune{';', '\t'} {
+		if counts[c] > counts[comma] {
+			comma = c
+		}
+	}
+	return comma
+}
+
+// lineAt returns the line of the first value after offset, past spaces and commas
+func lineAt(data []byte, offset int64) int {
+	i := int(offset)
+	for i < len(data) && strings.IndexByte(" \t\r\n,", data[i]) >= 0 {
+		i++
+	}
+	return bytes.Count(data[:i], []byte("\n")) + 1
+}
@@ -0,0 +1,322 @@
+// Package core holds the charts without Ebiten: the model, loading data, the
+// renderers and the chart layout on a Canvas, and the export to PNG and SVG.
+// Package charts draws them on Ebiten images and adds the interactive widgets.
+package core
+
+import (
+	"image/color"
+	"math"
+
+	"example.com/menu/internals/charts/axis"
+)
+
+// Point is one x/y pair of a series
+type Point struct {
+	X, Y float64
+}
+
+type LineStyle int
+
+const (
+	LineSolid LineStyle = iota
+	LineDashed
+	LineDotted
+	LineNone // markers only
+)
+
+// LineJoin is the shape of the corners of a line
+type LineJoin int
+
+const (
+	JoinRound LineJoin = iota
+	JoinMiter
+	JoinBevel
+)
+
+// Interpolation is how a line gets from one point to the next
+type Interpolation int
+
+const (
+	InterpolateLinear Interpolation = iota
+	// StepAfter keeps each value until the next x
+	StepAfter
+	// StepBefore takes each value from the previous x on
+	StepBefore
+	// StepMiddle changes the value half way between two x
+	StepMiddle
+	// Monotone is a smooth curve that does not overshoot the points, the x are expected in increasing order
+	Monotone
+)
+
+type MarkerShape int
+
+const (
+	MarkerNone MarkerShape = iota
+	MarkerCircle
+	MarkerSquare
+	MarkerTriangle
+	MarkerCross
+)
+
+// DefaultPalette colors the series added to a Model without a color
+var DefaultPalette = []color.RGBA{
+	{66, 133, 244, 255},
+	{234, 67, 53, 255},
+	{251, 188, 5, 255},
+	{52, 168, 83, 255},
+	{171, 71, 188, 255},
+	{0, 172, 193, 255},
+	{255, 112, 67, 255},
+	{158, 157, 36, 255},
+}
+
+// Series is a named list of points with its own style
+type Series struct {
+	Name      string
+	Points    []Point
+	Color     color.Color
+	Line      LineStyle
+	LineWidth float32 // 0 means 1
+	Join      LineJoin
+	// Dash is a custom pattern of drawn and skipped lengths in pixels, it overrides Line
+	Dash          []float32
+	Interpolation Interpolation
+	// Fill covers the space between the line and 0 with FillOpacity of the color, 0 means 0.25
+	Fill        bool
+	FillOpacity float32
+	Marker      MarkerShape
+	MarkerSize  float32 // 0 means 6
+	Hidden      bool
+}
+
+// NewSeries pairs xs and ys, the longer slice is cut to the shorter one
+func NewSeries(name string, xs, ys []float64) *Series {
+	n := min(len(xs), len(ys))
+	points := make([]Point, n)
+	for i := 0; i < n; i++ {
+		points[i] = Point{xs[i], ys[i]}
+	}
+	return &Series{Name: name, Points: points}
+}
+
+// SeriesFromValues uses the index of each value as its x
+func SeriesFromValues(name string, values []float64) *Series {
+	points := make([]Point, len(values))
+	for i, v := range values {
+		points[i] = Point{float64(i), v}
+	}
+	return &Series{Name: name, Points: points}
+}
+
+// Append adds a point at the end of the series
+func (s *Series) Append(x, y float64) {
+	s.Points = append(s.Points, Point{x, y})
+}
+
+func (s *Series) lineWidth() float32 {
+	if s.LineWidth <= 0 {
+		return 1
+	}
+	return s.LineWidth
+}
+
+func (s *Series) fillOpacity() float32 {
+	if s.FillOpacity <= 0 || s.FillOpacity > 1 {
+		return 0.25
+	}
+	return s.FillOpacity
+}
+
+func (s *Series) markerSize() float32 {
+	if s.MarkerSize <= 0 {
+		return 6
+	}
+	return s.MarkerSize
+}
+
+// MarkerRadius is half the size the markers are drawn with
+func (s *Series) MarkerRadius() float32 {
+	return s.markerSize() / 2
+}
+
+// AxisRange is the range of one axis. The zero value fits the data on a linear scale.
+type AxisRange struct {
+	// Fixed uses Min and Max as they are
+	Fixed    bool
+	Min, Max float64
+	// Padding is the fraction of the data span added at both ends of an automatic range
+	Padding float64
+	// IncludeZero stretches an automatic range to 0
+	IncludeZero bool
+	// Scale places the values and ticks, nil is axis.Linear
+	Scale axis.Scale
+}
+
+// FixedRange returns a range that ignores the data
+func FixedRange(min, max float64) AxisRange {
+	return AxisRange{Fixed: true, Min: min, Max: max}
+}
+
+// Ticks returns the ticks of the scale, nil scales are linear
+func (r AxisRange) Ticks(min, max float64, count int) []axis.Tick {
+	if r.Scale == nil {
+		return (&axis.Linear{}).Ticks(min, max, count)
+	}
+	return r.Scale.Ticks(min, max, count)
+}
+
+func (r AxisRange) Transform(v float64) float64 {
+	if r.Scale == nil {
+		return v
+	}
+	return r.Scale.Transform(v)
+}
+
+func (r AxisRange) Untransform(u float64) float64 {
+	if r.Scale == nil {
+		return u
+	}
+	return r.Scale.Untransform(u)
+}
+
+// resolve turns the data bounds into the drawn range. The padding is applied
+// where the scale is linear, so a log axis pads by a part of its decades.
+func (r AxisRange) resolve(values func(yield func(float64))) (float64, float64) {
+	if r.Fixed && r.Max > r.Min {
+		return r.Min, r.Max
+	}
+	lo, hi := math.Inf(1), math.Inf(-1)
+	values(func(v float64) {
+		if u := r.Transform(v); !math.IsNaN(u) && !math.IsInf(u, 0) {
+			lo, hi = math.Min(lo, u), math.Max(hi, u)
+		}
+	})
+	if lo > hi {
+		// Nothing to fit, show one unit of the scale
+		lo, hi = r.Transform(1)-1, r.Transform(1)
+	}
+	if zero := r.Transform(0); r.IncludeZero && !math.IsInf(zero, 0) && !math.IsNaN(zero) {
+		lo, hi = math.Min(lo, zero), math.Max(hi, zero)
+	}
+	span := hi - lo
+	if span == 0 {
+		// A flat series still needs some height
+		span = math.Max(math.Abs(hi), 1)
+		lo -= span / 2
+		hi += span / 2
+	}
+	pad := span * r.Padding
+	return r.Untransform(lo - pad), r.Untransform(hi + pad)
+}
+
+// Model is the data of a chart: its series, the axis ranges and the legend
+type Model struct {
+	Series []*Series
+	X, Y   AxisRange
+	Legend Legend
+}
+
+func NewModel(series ...*Series) *Model {
+	m := &Model{Legend: Legend{Visible: true, Position: LegendTopRight}}
+	for _, s := range series {
+		m.Add(s)
+	}
+	return m
+}
+
+// Add appends a series and gives it a palette color when it has none
+func (m *Model) Add(s *Series) *Series {
+	if s.Color == nil {
+		s.Color = DefaultPalette[len(m.Series)%len(DefaultPalette)]
+	}
+	m.Series = append(m.Series, s)
+	return s
+}
+
+// Find returns the series with the given name
+func (m *Model) Find(name string) *Series {
+	for _, s := range m.Series {
+		if s.Name == name {
+			return s
+		}
+	}
+	return nil
+}
+
+// Visible returns the series that are not hidden
+func (m *Model) Visible() []*Series {
+	visible := make([]*Series, 0, len(m.Series))
+	for _, s := range m.Series {
+		if !s.Hidden {
+			visible = append(visible, s)
+		}
+	}
+	return visible
+}
+
+// DataBounds returns the bounds of the visible points, ok is false without any
+func (m *Model) DataBounds() (xMin, xMax, yMin, yMax float64, ok bool) {
+	xMin, yMin = math.Inf(1), math.Inf(1)
+	xMax, yMax = math.Inf(-1), math.Inf(-1)
+	for _, s := range m.Visible() {
+		for _, p := range s.Points {
+			if math.IsNaN(p.X) || math.IsNaN(p.Y) {
+				continue
+			}
+			xMin, xMax = math.Min(xMin, p.X), math.Max(xMax, p.X)
+			yMin, yMax = math.Min(yMin, p.Y), math.Max(yMax, p.Y)
+			ok = true
+		}
+	}
+	return xMin, xMax, yMin, yMax, ok
+}
+
+// Ranges returns the drawn axis ranges
+func (m *Model) Ranges() (xMin, xMax, yMin, yMax float64) {
+	visible := m.Visible()
+	xMin, xMax = m.X.resolve(func(yield func(float64)) {
+		for _, s := range visible {
+			for _, p := range s.Points {
+				yield(p.X)
+			}
+		}
+	})
+	yMin, yMax = m.Y.resolve(func(yield func(float64)) {
+		for _, s := range visible {
+			for _, p := range s.Points {
+				yield(p.Y)
+			}
+		}
+	})
+	return xMin, xMax, yMin, yMax
+}
+
+// Plot maps model coordinates into a screen rectangle
+type Plot struct {
+	X, Y, Width, Height    float64
+	XMin, XMax, YMin, YMax float64
+	// XScale and YScale are linear when nil
+	XScale, YScale axis.Scale
+}
+
+func (p *Plot) ScreenX(x float64) float64 {
+	return p.X + axis.Normalize(p.XScale, x, p.XMin, p.XMax)*p.Width
+}
+
+// ScreenY grows upwards, YMin is at the bottom of the rectangle
+func (p *Plot) ScreenY(y float64) float64 {
+	return p.Y + p.Height - axis.Normalize(p.YScale, y, p.YMin, p.YMax)*p.Height
+}
+
+// baseline returns the screen y bars and areas grow from, v clamped into the plot.
+// A log axis has no 0, its baseline is the bottom.
+func (p *Plot) baseline(v float64) float64 {
+	if _, isLog := p.YScale.(*axis.Log); isLog && v <= 0 {
+		return p.Y + p.Height
+	}
+	return p.ScreenY(math.Max(p.YMin, math.Min(p.YMax, v)))
+}
+
+func (p *Plot) ToScreen(pt Point) (float32, float32) {
+	return float32(p.ScreenX(pt.X)), float32(p.ScreenY(pt.Y))
+}
@@ -0,0 +1,218 @@
+package core
+
+import (
+	"image/color"
+	"math"
+)
+
+// interpolate returns the polyline drawn through the screen points xs, ys.
+// Steps add the corners, Monotone flattens a Fritsch–Carlson spline. NaN points stay as gaps.
+func interpolate(xs, ys []float32, mode Interpolation) ([]float32, []float32) {
+	if mode == InterpolateLinear || len(xs) < 2 {
+		return xs, ys
+	}
+	outX := make([]float32, 0, len(xs)*2)
+	outY := make([]float32, 0, len(ys)*2)
+	for start := 0; start < len(xs); {
+		if isNaN32(xs[start]) || isNaN32(ys[start]) {
+			outX, outY = append(outX, xs[start]), append(outY, ys[start])
+			start++
+			continue
+		}
+		end := start + 1
+		for end < len(xs) && !isNaN32(xs[end]) && !isNaN32(ys[end]) {
+			end++
+		}
+		if mode == Monotone {
+			outX, outY = appendMonotone(outX, outY, xs[start:end], ys[start:end])
+		} else {
+			outX, outY = appendSteps(outX, outY, xs[start:end], ys[start:end], mode)
+		}
+		start = end
+	}
+	return outX, outY
+}
+
+func appendSteps(outX, outY, xs, ys []float32, mode Interpolation) ([]float32, []float32) {
+	outX, outY = append(outX, xs[0]), append(outY, ys[0])
+	for i := 1; i < len(xs); i++ {
+		switch mode {
+		case StepAfter:
+			outX, outY = append(outX, xs[i]), append(outY, ys[i-1])
+		case StepBefore:
+			outX, outY = append(outX, xs[i-1]), append(outY, ys[i])
+		case StepMiddle:
+			mid := (xs[i-1] + xs[i]) / 2
+			outX, outY = append(outX, mid, mid), append(outY, ys[i-1], ys[i])
+		}
+		outX, outY = append(outX, xs[i]), append(outY, ys[i])
+	}
+	return outX, outY
+}
+
+// appendMonotone samples the monotone cubic through the points about every 2 pixels
+func appendMonotone(outX, outY, xs, ys []float32) ([]float32, []float32) {
+	n := len(xs)
+	if n < 3 {
+		return append(outX, xs...), append(outY, ys...)
+	}
+	slopes := make([]float64, n-1)
+	for i := range slopes {
+		if dx := float64(xs[i+1] - xs[i]); dx != 0 {
+			slopes[i] = float64(ys[i+1]-ys[i]) / dx
+		}
+	}
+	tangents := make([]float64, n)
+	tangents[0], tangents[n-1] = slopes[0], slopes[n-2]
+	for i := 1; i < n-1; i++ {
+		// A peak or a change of direction stays flat, so the curve does not overshoot
+		if slopes[i-1]*slopes[i] > 0 {
+			tangents[i] = (slopes[i-1] + slopes[i]) / 2
+		}
+	}
+	for i, m := range slopes {
+		if m == 0 {
+			tangents[i], tangents[i+1] = 0, 0
+			continue
+		}
+		a, b := tangents[i]/m, tangents[i+1]/m
+		if s := a*a + b*b; s > 9 {
+			t := 3 / math.Sqrt(s)
+			tangents[i], tangents[i+1] = t*a*m, t*b*m
+		}
+	}
+
+	outX, outY = append(outX, xs[0]), append(outY, ys[0])
+	for i := 0; i < n-1; i++ {
+		x0, y0 := float64(xs[i]), float64(ys[i])
+		dx, dy := float64(xs[i+1])-x0, float64(ys[i+1])-y0
+		steps := max(int(math.Abs(dx)/2), 1)
+		for k := 1; k <= steps; k++ {
+			t := float64(k) / float64(steps)
+			// Cubic Hermite basis
+			h10 := t * (1 - t) * (1 - t)
+			h01 := t * t * (3 - 2*t)
+			h11 := t * t * (t - 1)
+			y := y0 + h01*dy + (h10*tangents[i]+h11*tangents[i+1])*dx
+			outX, outY = append(outX, float32(x0+t*dx)), append(outY, float32(y))
+		}
+	}
+	return outX, outY
+}
+
+// fillUnder fills the space between the line through xs, ys and the screen y base
+func fillUnder(c Canvas, xs, ys []float32, base float32, clr color.Color) {
+	// Chunks share their edge point, so the pieces meet without a gap
+	const chunk = 2000
+	for start := 0; start < len(xs)-1; {
+		if isNaN32(xs[start]) || isNaN32(ys[start]) {
+			start++
+			continue
+		}
+		end := start + 1
+		for end < len(xs) && end-start <= chunk && !isNaN32(xs[end]) && !isNaN32(ys[end]) {
+			end++
+		}
+		if end-start >= 2 {
+			var s Shape
+			s.MoveTo(xs[start], base)
+			for i := start; i < end; i++ {
+				s.LineTo(xs[i], ys[i])
+			}
+			s.LineTo(xs[end-1], base)
+			s.Close()
+			c.Fill(&s, clr, true)
+		}
+		if end < len(xs) && !isNaN32(xs[end]) && !isNaN32(ys[end]) {
+			start = end - 1
+		} else {
+			start = end
+		}
+	}
+}
+
+// LTTB reduces the points to threshold points with Largest-Triangle-Three-Buckets.
+// The first and last points are kept and the peaks of the line survive, so a long
+// series looks the same with far fewer segments. The x are expected in increasing
+// order. Points with a NaN are gaps: they are kept and every run between them is
+// reduced on its own, to its share of threshold. A threshold below 3 returns the
+// points as they are.
+func LTTB(points []Point, threshold int) []Point {
+	if threshold < 3 || len(points) <= threshold {
+		return points
+	}
+	gaps := 0
+	for _, p := range points {
+		if isGap(p) {
+			gaps++
+		}
+	}
+	if gaps == 0 {
+		return lttb(points, threshold)
+	}
+
+	valid := len(points) - gaps
+	budget := max(threshold-gaps, 0)
+	sampled := make([]Point, 0, threshold)
+	start := 0
+	for i := 0; i <= len(points); i++ {
+		if i < len(points) && !isGap(points[i]) {
+			continue
+		}
+		if run := points[start:i]; len(run) > 0 {
+			sampled = append(sampled, lttb(run, max(budget*len(run)/valid, 3))...)
+		}
+		if i < len(points) {
+			sampled = append(sampled, points[i])
+		}
+		start = i + 1
+	}
+	return sampled
+}
+
+// isGap reports whether the point breaks the line
+func isGap(p Point) bool {
+	return math.IsNaN(p.X) || math.IsNaN(p.Y)
+}
+
+// lttb is LTTB for points without gaps
+func lttb(points []Point, threshold int) []Point {
+	if len(points) <= threshold {
+		return points
+	}
+
+	sampled := make([]Point, 0, threshold)
+	sampled = append(sampled, points[0])
+	// The points between the first and the last go into threshold-2 buckets
+	bucket := float64(len(points)-2) / float64(threshold-2)
+	previous := 0
+	for b := 0; b < threshold-2; b++ {
+		from := int(float64(b)*bucket) + 1
+		to := int(float64(b+1)*bucket) + 1
+
+		// The average of the next bucket is the third corner of the triangle
+		nextFrom, nextTo := to, min(int(float64(b+2)*bucket)+1, len(points))
+		if b == threshold-3 {
+			nextFrom, nextTo = len(points)-1, len(points)
+		}
+		var avgX, avgY float64
+		for _, p := range points[nextFrom:nextTo] {
+			avgX += p.X
+			avgY += p.Y
+		}
+		count := float64(nextTo - nextFrom)
+		avgX, avgY = avgX/count, avgY/count
+
+		a := points[previous]
+		best, largest := from, -1.0
+		for i := from; i < to; i++ {
+			area := math.Abs((a.X-avgX)*(points[i].Y-a.Y) - (a.X-points[i].X)*(avgY-a.Y))
+			if area > largest {
+				best, largest = i, area
+			}
+		}
+		sampled = append(sampled, points[best])
+		previous = best
+	}
+	return append(sampled, points[len(points)-1])
+}
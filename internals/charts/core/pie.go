@@ -0,0 +1,108 @@
+package core
+
+import (
+	"fmt"
+	"math"
+)
+
+// AxesHider is implemented by renderers that draw without axes, like PieRenderer
+type AxesHider interface {
+	HideAxes() bool
+}
+
+// PieRenderer draws every series as a slice, sized by the sum of its positive values.
+// Slices start at 12 o'clock and go clockwise in the order of the series.
+type PieRenderer struct {
+	// Hole is the inner radius of a donut as a fraction of the radius, 0 draws a pie
+	Hole float32
+	// Explode pulls slices out of the pie by some pixels, by series name
+	Explode map[string]float32
+	// Labels writes the name and share of each slice next to it, on canvases with text
+	Labels bool
+	// MinLabelShare hides the labels of slices smaller than this fraction, 0 means 0.03
+	MinLabelShare float64
+}
+
+func (r *PieRenderer) HideAxes() bool {
+	return true
+}
+
+// SliceValue is the size of the slice of a series
+func SliceValue(s *Series) float64 {
+	total := 0.0
+	for _, p := range s.Points {
+		if p.Y > 0 {
+			total += p.Y
+		}
+	}
+	return total
+}
+
+func (r *PieRenderer) Draw(c Canvas, plot *Plot, series []*Series) {
+	total := 0.0
+	for _, s := range series {
+		total += SliceValue(s)
+	}
+	if total == 0 {
+		return
+	}
+
+	explode := float32(0)
+	for _, d := range r.Explode {
+		explode = max(explode, d)
+	}
+	// Room for the labels around the pie
+	labelRoom := float32(0)
+	if r.Labels {
+		labelRoom = 60
+	}
+	cx, cy := float32(plot.X+plot.Width/2), float32(plot.Y+plot.Height/2)
+	radius := min(float32(plot.Width), float32(plot.Height))/2 - explode - labelRoom
+	if radius <= 0 {
+		return
+	}
+	minShare := r.MinLabelShare
+	if minShare <= 0 {
+		minShare = 0.03
+	}
+
+	angle := -math.Pi / 2
+	for _, s := range series {
+		share := SliceValue(s) / total
+		if share == 0 {
+			continue
+		}
+		start, end := angle, angle+share*2*math.Pi
+		angle = end
+		mid := (start + end) / 2
+		dx, dy := float32(math.Cos(mid)), float32(math.Sin(mid))
+		x, y := cx+dx*r.Explode[s.Name], cy+dy*r.Explode[s.Name]
+
+		var slice Shape
+		if r.Hole > 0 {
+			slice.Arc(x, y, radius, start, end)
+			slice.Arc(x, y, radius*r.Hole, end, start)
+		} else {
+			slice.MoveTo(x, y)
+			slice.Arc(x, y, radius, start, end)
+		}
+		slice.Close()
+		c.Fill(&slice, s.Color, true)
+
+		if !r.Labels || !c.HasText() || share < minShare {
+			continue
+		}
+		label := fmt.Sprintf("%s %.0f%%", s.Name, share*100)
+		w, h := c.Measure(label)
+		lx, ly := float64(x+dx*(radius+8)), float64(y+dy*(radius+8))
+		// Labels on the left end at the pie, on the right start at it
+		if dx < 0 {
+			lx -= w
+		}
+		c.Text(label, lx, ly-h/2, nil)
+	}
+}
+
+func (r *PieRenderer) DrawSwatch(c Canvas, s *Series, x, y, width, height float32) {
+	drawSquareSwatch(c, s, x, y, width, height)
+}
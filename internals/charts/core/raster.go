@@ -0,0 +1,250 @@
+package core
+
+import (
+	"image"
+	"image/color"
+	"math"
+
+	xdraw "golang.org/x/image/draw"
+	"golang.org/x/image/font"
+	"golang.org/x/image/math/fixed"
+	"golang.org/x/image/vector"
+)
+
+// rasterCanvas draws on an image in software. Chart pixels are scaled by scale,
+// text is measured at the size of the chart so the layout does not change with it.
+type rasterCanvas struct {
+	img       *image.RGBA
+	scale     float32
+	bounds    image.Rectangle
+	font      *exportFont
+	face      font.Face
+	textColor color.Color
+	z         *vector.Rasterizer
+}
+
+func newRasterCanvas(img *image.RGBA, scale float64, f *exportFont, textColor color.Color) (*rasterCanvas, error) {
+	face, err := f.faceAt(scale)
+	if err != nil {
+		return nil, err
+	}
+	return &rasterCanvas{
+		img:       img,
+		scale:     float32(scale),
+		bounds:    img.Bounds(),
+		font:      f,
+		face:      face,
+		textColor: textColor,
+		z:         &vector.Rasterizer{},
+	}, nil
+}
+
+// polygon is a closed outline in image pixels
+type polygon struct {
+	xs, ys []float32
+}
+
+func (c *rasterCanvas) Fill(s *Shape, clr color.Color, antialias bool) {
+	polygons := make([]polygon, 0, len(s.Subpaths))
+	for _, sub := range s.Subpaths {
+		polygons = append(polygons, polygon{c.scaled(sub.Xs), c.scaled(sub.Ys)})
+	}
+	c.draw(polygons, clr)
+}
+
+func (c *rasterCanvas) Stroke(s *Shape, st Stroke, clr color.Color) {
+	st.Width *= c.scale
+	var polygons []polygon
+	for _, sub := range s.Subpaths {
+		polygons = appendStroke(polygons, c.scaled(sub.Xs), c.scaled(sub.Ys), sub.Closed, st)
+	}
+	c.draw(polygons, clr)
+}
+
+func (c *rasterCanvas) Text(s string, x, y float64, clr color.Color) {
+	if clr == nil {
+		clr = c.textColor
+	}
+	scale := float64(c.scale)
+	d := &font.Drawer{
+		Dst:  c.img,
+		Src:  image.NewUniform(clr),
+		Face: c.face,
+		// The Drawer starts at the baseline, the canvas at the top of the line
+		Dot: fixed.Point26_6{X: fixed.Int26_6(x * scale * 64), Y: fixed.Int26_6((y + c.font.ascent()) * scale * 64)},
+	}
+	d.DrawString(s)
+}
+
+func (c *rasterCanvas) Measure(s string) (float64, float64) {
+	return c.font.measure(s)
+}
+
+func (c *rasterCanvas) HasText() bool {
+	return true
+}
+
+func (c *rasterCanvas) Clip(x, y, width, height float64) Canvas {
+	scale := float64(c.scale)
+	rect := image.Rect(int(x*scale), int(y*scale), int(math.Ceil((x+width)*scale)), int(math.Ceil((y+height)*scale)))
+	clipped := *c
+	clipped.bounds = c.bounds.Intersect(rect)
+	return &clipped
+}
+
+func (c *rasterCanvas) Pixels(img *image.RGBA, x, y, width, height float64) {
+	scale := float64(c.scale)
+	rect := image.Rect(int(math.Round(x*scale)), int(math.Round(y*scale)), int(math.Round((x+width)*scale)), int(math.Round((y+height)*scale)))
+	dst := c.img.SubImage(c.bounds).(*image.RGBA)
+	xdraw.NearestNeighbor.Scale(dst, rect, img, img.Bounds(), xdraw.Over, nil)
+}
+
+func (c *rasterCanvas) scaled(vs []float32) []float32 {
+	out := make([]float32, len(vs))
+	for i, v := range vs {
+		out[i] = v * c.scale
+	}
+	return out
+}
+
+// draw fills the union of the polygons. The rasterizer only covers their bounding
+// box, so a small marker does not cost a pass over the whole image.
+func (c *rasterCanvas) draw(polygons []polygon, clr color.Color) {
+	minX, minY := float32(math.Inf(1)), float32(math.Inf(1))
+	maxX, maxY := float32(math.Inf(-1)), float32(math.Inf(-1))
+	for _, p := range polygons {
+		for i := range p.xs {
+			minX, maxX = min(minX, p.xs[i]), max(maxX, p.xs[i])
+			minY, maxY = min(minY, p.ys[i]), max(maxY, p.ys[i])
+		}
+	}
+	rect := image.Rect(int(math.Floor(float64(minX))), int(math.Floor(float64(minY))), int(math.Ceil(float64(maxX))), int(math.Ceil(float64(maxY))))
+	rect = rect.Intersect(c.bounds)
+	if rect.Empty() {
+		return
+	}
+
+	ox, oy := float32(rect.Min.X), float32(rect.Min.Y)
+	c.z.Reset(rect.Dx(), rect.Dy())
+	for _, p := range polygons {
+		if len(p.xs) < 3 {
+			continue
+		}
+		// The rasterizer adds up the coverage of the polygons, all of them
+		// turn the same way so overlaps do not cancel out
+		reverse := signedArea(p) < 0
+		for k := range p.xs {
+			i := k
+			if reverse {
+				i = len(p.xs) - 1 - k
+			}
+			if k == 0 {
+				c.z.MoveTo(p.xs[i]-ox, p.ys[i]-oy)
+			} else {
+				c.z.LineTo(p.xs[i]-ox, p.ys[i]-oy)
+			}
+		}
+		c.z.ClosePath()
+	}
+	c.z.Draw(c.img, rect, image.NewUniform(clr), image.Point{})
+}
+
+func signedArea(p polygon) float32 {
+	area := float32(0)
+	for i := range p.xs {
+		j := (i + 1) % len(p.xs)
+		area += p.xs[i]*p.ys[j] - p.xs[j]*p.ys[i]
+	}
+	return area / 2
+}
+
+// appendStroke adds the outline of a polyline as polygons: a quad per segment,
+// the joins between them and round caps at the ends
+func appendStroke(polygons []polygon, xs, ys []float32, closed bool, st Stroke) []polygon {
+	half := st.Width / 2
+	n := len(xs)
+	if closed && n > 1 && xs[0] == xs[n-1] && ys[0] == ys[n-1] {
+		n--
+	}
+	if n == 0 {
+		return polygons
+	}
+	segments := n - 1
+	if closed {
+		segments = n
+	}
+
+	// Unit normals of the segments, zero for segments without a length
+	nx, ny := make([]float32, segments), make([]float32, segments)
+	for i := 0; i < segments; i++ {
+		j := (i + 1) % n
+		dx, dy := xs[j]-xs[i], ys[j]-ys[i]
+		if length := float32(math.Hypot(float64(dx), float64(dy))); length > 0 {
+			nx[i], ny[i] = -dy/length, dx/length
+		}
+	}
+
+	for i := 0; i < segments; i++ {
+		j := (i + 1) % n
+		ox, oy := nx[i]*half, ny[i]*half
+		if ox == 0 && oy == 0 {
+			continue
+		}
+		polygons = append(polygons, polygon{
+			xs: []float32{xs[i] + ox, xs[j] + ox, xs[j] - ox, xs[i] - ox},
+			ys: []float32{ys[i] + oy, ys[j] + oy, ys[j] - oy, ys[i] - oy},
+		})
+	}
+
+	// Joins at the inner points, and at the first point of a closed line
+	first, last := 1, n-1
+	if closed {
+		first, last = 0, n
+	}
+	for v := first; v < last; v++ {
+		prev, next := (v-1+segments)%segments, v%segments
+		polygons = appendJoin(polygons, xs[v], ys[v], nx[prev], ny[prev], nx[next], ny[next], half, st.Join)
+	}
+
+	if st.RoundCap && !closed {
+		polygons = append(polygons, circlePolygon(xs[0], ys[0], half))
+		if n > 1 {
+			polygons = append(polygons, circlePolygon(xs[n-1], ys[n-1], half))
+		}
+	}
+	return polygons
+}
+
+// appendJoin fills the gap on the outer side of a corner between two segments
+func appendJoin(polygons []polygon, x, y, n1x, n1y, n2x, n2y, half float32, join LineJoin) []polygon {
+	if (n1x == 0 && n1y == 0) || (n2x == 0 && n2y == 0) {
+		return polygons
+	}
+	if join == JoinRound {
+		return append(polygons, circlePolygon(x, y, half))
+	}
+	// The outer side is the one the normals turn away from
+	side := float32(1)
+	if n1x*n2y-n1y*n2x > 0 {
+		side = -1
+	}
+	ax, ay := x+side*n1x*half, y+side*n1y*half
+	bx, by := x+side*n2x*half, y+side*n2y*half
+	if join == JoinMiter {
+		// The tip lies along the sum of the normals, 1/cos of half the angle away
+		mx, my := n1x+n2x, n1y+n2y
+		if cos := (1 + n1x*n2x + n1y*n2y) / 2; cos > 0 {
+			if ratio := 1 / float32(math.Sqrt(float64(cos))); ratio <= 10 {
+				length := float32(math.Hypot(float64(mx), float64(my)))
+				tx, ty := x+side*mx/length*half*ratio, y+side*my/length*half*ratio
+				return append(polygons, polygon{xs: []float32{x, ax, tx, bx}, ys: []float32{y, ay, ty, by}})
+			}
+		}
+	}
+	return append(polygons, polygon{xs: []float32{x, ax, bx}, ys: []float32{y, ay, by}})
+}
+
+func circlePolygon(cx, cy, radius float32) polygon {
+	s := circleShape(cx, cy, radius)
+	return polygon{s.Subpaths[0].Xs, s.Subpaths[0].Ys}
+}
@@ -0,0 +1,241 @@
+package core
+
+import (
+	"math"
+	"sort"
+
+	"example.com/menu/internals/charts/axis"
+)
+
+// Renderer draws the visible series of a model into the plot rectangle
+type Renderer interface {
+	Draw(c Canvas, plot *Plot, series []*Series)
+	// DrawSwatch draws the legend sample of a series into the given box
+	DrawSwatch(c Canvas, s *Series, x, y, width, height float32)
+}
+
+// RangeAdjuster is implemented by renderers that widen the automatic ranges, e.g. to fit whole bars
+type RangeAdjuster interface {
+	AdjustRanges(m *Model, plot *Plot)
+}
+
+// ---------------------
+
+// LineRenderer connects the points of each series and draws their markers
+type LineRenderer struct {
+	// Crisp turns anti-aliasing off, for lines that should stay on whole pixels
+	Crisp bool
+	// MaxPoints thins longer series with LTTB, 0 means twice the plot width and below 0 keeps all points
+	MaxPoints int
+}
+
+func (r *LineRenderer) maxPoints(plot *Plot) int {
+	if r.MaxPoints == 0 {
+		return max(int(plot.Width)*2, 3)
+	}
+	return r.MaxPoints
+}
+
+func (r *LineRenderer) Draw(c Canvas, plot *Plot, series []*Series) {
+	for _, s := range series {
+		points := LTTB(s.Points, r.maxPoints(plot))
+		xs := make([]float32, len(points))
+		ys := make([]float32, len(points))
+		for i, p := range points {
+			xs[i], ys[i] = plot.ToScreen(p)
+		}
+		lineXs, lineYs := interpolate(xs, ys, s.Interpolation)
+		if s.Fill {
+			fillUnder(c, lineXs, lineYs, float32(plot.baseline(0)), Fade(s.Color, s.fillOpacity()))
+		}
+		if s.Line != LineNone {
+			StrokePolyline(c, lineXs, lineYs, seriesStroke(s, !r.Crisp), s.Color)
+		}
+		if s.Marker == MarkerNone {
+			continue
+		}
+		for i := range xs {
+			DrawMarker(c, s.Marker, xs[i], ys[i], s.markerSize(), s.Color)
+		}
+	}
+}
+
+func (r *LineRenderer) DrawSwatch(c Canvas, s *Series, x, y, width, height float32) {
+	cy := y + height/2
+	if s.Fill {
+		FillRect(c, x, cy, width, height/2, Fade(s.Color, s.fillOpacity()))
+	}
+	if s.Line != LineNone {
+		StrokePolyline(c, []float32{x, x + width}, []float32{cy, cy}, seriesStroke(s, !r.Crisp), s.Color)
+	}
+	if s.Marker != MarkerNone {
+		DrawMarker(c, s.Marker, x+width/2, cy, min(s.markerSize(), height), s.Color)
+	}
+}
+
+// ---------------------
+
+// BarRenderer draws a bar per point from 0, the series side by side around each x or stacked
+type BarRenderer struct {
+	// Gutter is the part of the space between two x values covered by bars, 0 means 0.8
+	Gutter float32
+	// Stacked puts the series on top of each other, negative values below 0
+	Stacked bool
+}
+
+func (r *BarRenderer) gutter() float64 {
+	if r.Gutter <= 0 || r.Gutter > 1 {
+		return 0.8
+	}
+	return float64(r.Gutter)
+}
+
+// AdjustRanges makes room for half a bar group at both ends and for the bars down to 0
+func (r *BarRenderer) AdjustRanges(m *Model, plot *Plot) {
+	if _, isLog := m.X.Scale.(*axis.Log); !m.X.Fixed && !isLog {
+		step := minStep(m.Visible())
+		plot.XMin -= step / 2
+		plot.XMax += step / 2
+	}
+	if r.Stacked {
+		fitStacked(m, plot)
+		return
+	}
+	if _, isLog := m.Y.Scale.(*axis.Log); !m.Y.Fixed && !isLog {
+		plot.YMin = math.Min(plot.YMin, 0)
+		plot.YMax = math.Max(plot.YMax, 0)
+	}
+}
+
+func (r *BarRenderer) Draw(c Canvas, plot *Plot, series []*Series) {
+	if len(series) == 0 {
+		return
+	}
+	slot := minStep(series) / (plot.XMax - plot.XMin) * plot.Width
+	group := slot * r.gutter()
+	barWidth := group / float64(len(series))
+	if r.Stacked {
+		barWidth = group
+	}
+	var bases [][]float64
+	if r.Stacked {
+		bases = stack(series)
+	}
+	for i, s := range series {
+		for j, p := range s.Points {
+			if math.IsNaN(p.X) || math.IsNaN(p.Y) {
+				continue
+			}
+			x := plot.ScreenX(p.X) - group/2
+			base := 0.0
+			if r.Stacked {
+				base = bases[i][j]
+			} else {
+				x += barWidth * float64(i)
+			}
+			from, to := plot.baseline(base), plot.ScreenY(base+p.Y)
+			FillRect(c, float32(x), float32(math.Min(from, to)), float32(barWidth), float32(math.Abs(to-from)), s.Color)
+		}
+	}
+}
+
+func (r *BarRenderer) DrawSwatch(c Canvas, s *Series, x, y, width, height float32) {
+	drawSquareSwatch(c, s, x, y, width, height)
+}
+
+// drawSquareSwatch fills the largest square in the middle of the box
+func drawSquareSwatch(c Canvas, s *Series, x, y, width, height float32) {
+	size := min(width, height)
+	FillRect(c, x+(width-size)/2, y+(height-size)/2, size, size, s.Color)
+}
+
+// minStep is the smallest distance between two distinct x values, 1 without any
+func minStep(series []*Series) float64 {
+	var xs []float64
+	for _, s := range series {
+		for _, p := range s.Points {
+			if !math.IsNaN(p.X) {
+				xs = append(xs, p.X)
+			}
+		}
+	}
+	sort.Float64s(xs)
+	step := math.Inf(1)
+	for i := 1; i < len(xs); i++ {
+		if d := xs[i] - xs[i-1]; d > 0 && d < step {
+			step = d
+		}
+	}
+	if math.IsInf(step, 1) {
+		return 1
+	}
+	return step
+}
+
+// stack returns the value every point starts from: the sum of the earlier series
+// at the same x. Positive and negative values are stacked apart.
+func stack(series []*Series) [][]float64 {
+	above, below := map[float64]float64{}, map[float64]float64{}
+	bases := make([][]float64, len(series))
+	for i, s := range series {
+		bases[i] = make([]float64, len(s.Points))
+		for j, p := range s.Points {
+			if math.IsNaN(p.X) || math.IsNaN(p.Y) {
+				continue
+			}
+			if p.Y >= 0 {
+				bases[i][j] = above[p.X]
+				above[p.X] += p.Y
+			} else {
+				bases[i][j] = below[p.X]
+				below[p.X] += p.Y
+			}
+		}
+	}
+	return bases
+}
+
+// fitStacked sets an automatic y range to the sums of the stacked series and 0
+func fitStacked(m *Model, plot *Plot) {
+	if m.Y.Fixed {
+		return
+	}
+	series := m.Visible()
+	bases := stack(series)
+	plot.YMin, plot.YMax = m.Y.resolve(func(yield func(float64)) {
+		yield(0)
+		for i, s := range series {
+			for j, p := range s.Points {
+				yield(bases[i][j] + p.Y)
+			}
+		}
+	})
+}
+
+// ---------------------
+
+// ScatterRenderer draws the points of each series as markers, a circle when a series has none
+type ScatterRenderer struct{}
+
+func (r *ScatterRenderer) Draw(c Canvas, plot *Plot, series []*Series) {
+	for _, s := range series {
+		for _, p := range s.Points {
+			if math.IsNaN(p.X) || math.IsNaN(p.Y) {
+				continue
+			}
+			x, y := plot.ToScreen(p)
+			DrawMarker(c, scatterMarker(s), x, y, s.markerSize(), s.Color)
+		}
+	}
+}
+
+func (r *ScatterRenderer) DrawSwatch(c Canvas, s *Series, x, y, width, height float32) {
+	DrawMarker(c, scatterMarker(s), x+width/2, y+height/2, min(s.markerSize(), height), s.Color)
+}
+
+func scatterMarker(s *Series) MarkerShape {
+	if s.Marker == MarkerNone {
+		return MarkerCircle
+	}
+	return s.Marker
+}
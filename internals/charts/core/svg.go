@@ -0,0 +1,169 @@
+package core
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/xml"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// svgDocument is the output shared by a canvas and its clipped copies, the first write error stops it
+type svgDocument struct {
+	w     io.Writer
+	err   error
+	clips int
+}
+
+// svgCanvas writes the shapes as SVG elements in chart pixels, the document
+// is scaled by its width and height
+type svgCanvas struct {
+	doc       *svgDocument
+	font      *exportFont
+	textColor color.Color
+	// clipID is the clip path the elements are drawn with, empty for none
+	clipID string
+}
+
+func newSVGCanvas(w io.Writer, f *exportFont, textColor color.Color) *svgCanvas {
+	return &svgCanvas{doc: &svgDocument{w: w}, font: f, textColor: textColor}
+}
+
+func (c *svgCanvas) printf(format string, args ...any) {
+	if c.doc.err == nil {
+		_, c.doc.err = fmt.Fprintf(c.doc.w, format, args...)
+	}
+}
+
+func (c *svgCanvas) begin(width, height int, scale float64, background color.Color) {
+	c.printf("<svg xmlns=\"http://www.w3.org/2000/svg\" width=\"%s\" height=\"%s\" viewBox=\"0 0 %d %d\">\n",
+		svgNumber(float64(width)*scale), svgNumber(float64(height)*scale), width, height)
+	if background != nil {
+		c.printf("<rect width=\"%d\" height=\"%d\"%s/>\n", width, height, svgPaint("fill", background))
+	}
+}
+
+func (c *svgCanvas) end() {
+	c.printf("</svg>\n")
+}
+
+func (c *svgCanvas) Fill(s *Shape, clr color.Color, antialias bool) {
+	crisp := ""
+	if !antialias {
+		crisp = ` shape-rendering="crispEdges"`
+	}
+	c.printf("<path d=\"%s\"%s%s%s/>\n", svgPath(s, true), svgPaint("fill", clr), crisp, c.clipAttr())
+}
+
+func (c *svgCanvas) Stroke(s *Shape, st Stroke, clr color.Color) {
+	joins := map[LineJoin]string{JoinRound: "round", JoinMiter: "miter", JoinBevel: "bevel"}
+	attrs := fmt.Sprintf(` fill="none" stroke-width="%s" stroke-linejoin="%s"`, svgNumber(float64(st.Width)), joins[st.Join])
+	if st.Join == JoinMiter {
+		attrs += ` stroke-miterlimit="10"`
+	}
+	if st.RoundCap {
+		attrs += ` stroke-linecap="round"`
+	}
+	if !st.Antialias {
+		attrs += ` shape-rendering="crispEdges"`
+	}
+	c.printf("<path d=\"%s\"%s%s%s/>\n", svgPath(s, false), svgPaint("stroke", clr), attrs, c.clipAttr())
+}
+
+func (c *svgCanvas) Text(s string, x, y float64, clr color.Color) {
+	if clr == nil {
+		clr = c.textColor
+	}
+	var escaped strings.Builder
+	xml.EscapeText(&escaped, []byte(s))
+	// SVG places text by its baseline, the canvas by the top of the line
+	c.printf("<text x=\"%s\" y=\"%s\" font-family=\"%s, sans-serif\" font-size=\"%s\"%s>%s</text>\n",
+		svgNumber(x), svgNumber(y+c.font.ascent()), c.font.family, svgNumber(c.font.size), svgPaint("fill", clr), escaped.String())
+}
+
+func (c *svgCanvas) Measure(s string) (float64, float64) {
+	return c.font.measure(s)
+}
+
+func (c *svgCanvas) HasText() bool {
+	return true
+}
+
+func (c *svgCanvas) Clip(x, y, width, height float64) Canvas {
+	c.doc.clips++
+	clipped := *c
+	clipped.clipID = "clip" + strconv.Itoa(c.doc.clips)
+	c.printf("<clipPath id=\"%s\"><rect x=\"%s\" y=\"%s\" width=\"%s\" height=\"%s\"/></clipPath>\n",
+		clipped.clipID, svgNumber(x), svgNumber(y), svgNumber(width), svgNumber(height))
+	return &clipped
+}
+
+// pixels embeds img as a PNG, viewers keep its pixels sharp with image-rendering
+func (c *svgCanvas) Pixels(img *image.RGBA, x, y, width, height float64) {
+	var data bytes.Buffer
+	if err := png.Encode(&data, img); err != nil {
+		if c.doc.err == nil {
+			c.doc.err = err
+		}
+		return
+	}
+	c.printf("<image x=\"%s\" y=\"%s\" width=\"%s\" height=\"%s\" preserveAspectRatio=\"none\" style=\"image-rendering:pixelated\" href=\"data:image/png;base64,%s\"%s/>\n",
+		svgNumber(x), svgNumber(y), svgNumber(width), svgNumber(height), base64.StdEncoding.EncodeToString(data.Bytes()), c.clipAttr())
+}
+
+func (c *svgCanvas) clipAttr() string {
+	if c.clipID == "" {
+		return ""
+	}
+	return ` clip-path="url(#` + c.clipID + `)"`
+}
+
+// svgPath writes the subpaths as path data, closed when filled
+func svgPath(s *Shape, filled bool) string {
+	var b strings.Builder
+	for _, sub := range s.Subpaths {
+		for i := range sub.Xs {
+			if i == 0 {
+				b.WriteString("M")
+			} else {
+				b.WriteString(" L")
+			}
+			b.WriteString(svgNumber(float64(sub.Xs[i])))
+			b.WriteString(" ")
+			b.WriteString(svgNumber(float64(sub.Ys[i])))
+		}
+		if sub.Closed || filled {
+			b.WriteString(" Z")
+		}
+	}
+	return b.String()
+}
+
+// svgPaint is the color attribute with its opacity, colors are premultiplied in Go and not in SVG
+func svgPaint(attr string, clr color.Color) string {
+	r, g, b, a := clr.RGBA()
+	if a == 0 {
+		return fmt.Sprintf(` %s="none"`, attr)
+	}
+	unmultiply := func(v uint32) uint32 { return min(v*0xffff/a, 0xffff) >> 8 }
+	paint := fmt.Sprintf(` %s="#%02x%02x%02x"`, attr, unmultiply(r), unmultiply(g), unmultiply(b))
+	if a < 0xffff {
+		paint += fmt.Sprintf(` %s-opacity="%s"`, attr, strconv.FormatFloat(float64(a)/0xffff, 'f', 3, 64))
+	}
+	return paint
+}
+
+// svgNumber keeps two decimals, enough for the pixels of a chart
+func svgNumber(v float64) string {
+	s := strconv.FormatFloat(v, 'f', 2, 64)
+	s = strings.TrimRight(strings.TrimRight(s, "0"), ".")
+	if s == "-0" {
+		return "0"
+	}
+	return s
+}
@@ -0,0 +1,120 @@
+package charts
+
+import (
+	"image"
+	"image/color"
+	"math"
+
+	"example.com/menu/internals/charts/core"
+	"example.com/menu/internals/textwrapper"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+var whiteImage *ebiten.Image
+
+// whiteSubImage is the source of DrawTriangles, the 1px border keeps the edges from bleeding
+func whiteSubImage() *ebiten.Image {
+	if whiteImage == nil {
+		whiteImage = ebiten.NewImage(3, 3)
+		whiteImage.Fill(color.White)
+	}
+	return whiteImage.SubImage(image.Rect(1, 1, 2, 2)).(*ebiten.Image)
+}
+
+// fillPath fills a closed vector path with a solid color
+func fillPath(dst *ebiten.Image, path *vector.Path, clr color.Color, antialias bool) {
+	vs, is := path.AppendVerticesAndIndicesForFilling(nil, nil)
+	drawSolid(dst, vs, is, clr, antialias)
+}
+
+// drawSolid draws triangles in a solid color
+func drawSolid(dst *ebiten.Image, vs []ebiten.Vertex, is []uint16, clr color.Color, antialias bool) {
+	r, g, b, a := clr.RGBA()
+	for i := range vs {
+		vs[i].SrcX, vs[i].SrcY = 1, 1
+		vs[i].ColorR = float32(r) / 0xffff
+		vs[i].ColorG = float32(g) / 0xffff
+		vs[i].ColorB = float32(b) / 0xffff
+		vs[i].ColorA = float32(a) / 0xffff
+	}
+	op := &ebiten.DrawTrianglesOptions{AntiAlias: antialias, FillRule: ebiten.NonZero}
+	dst.DrawTriangles(vs, is, whiteSubImage(), op)
+}
+
+// imageCanvas draws on an Ebiten image, text with the TextWrapper when there is one
+type imageCanvas struct {
+	dst *ebiten.Image
+	tw  *textwrapper.TextWrapper
+}
+
+func (c *imageCanvas) Fill(s *core.Shape, clr color.Color, antialias bool) {
+	path := shapePath(s)
+	fillPath(c.dst, &path, clr, antialias)
+}
+
+func (c *imageCanvas) Stroke(s *core.Shape, st core.Stroke, clr color.Color) {
+	path := shapePath(s)
+	strokePath(c.dst, &path, st, clr)
+}
+
+func (c *imageCanvas) Text(s string, x, y float64, clr color.Color) {
+	if c.tw == nil {
+		return
+	}
+	if clr != nil {
+		previous := c.tw.Color
+		defer c.tw.SetColor(previous)
+		c.tw.SetColor(clr)
+	}
+	c.tw.DrawText(c.dst, s, x, y)
+}
+
+func (c *imageCanvas) Measure(s string) (float64, float64) {
+	if c.tw == nil {
+		return 0, 0
+	}
+	return c.tw.MeasureText(s)
+}
+
+func (c *imageCanvas) HasText() bool {
+	return c.tw != nil
+}
+
+func (c *imageCanvas) Clip(x, y, width, height float64) core.Canvas {
+	rect := image.Rect(int(x), int(y), int(math.Ceil(x+width)), int(math.Ceil(y+height)))
+	return &imageCanvas{dst: c.dst.SubImage(rect).(*ebiten.Image), tw: c.tw}
+}
+
+// shapePath converts the shape into an Ebiten vector path
+func shapePath(s *core.Shape) vector.Path {
+	var path vector.Path
+	for _, sub := range s.Subpaths {
+		path.MoveTo(sub.Xs[0], sub.Ys[0])
+		for i := 1; i < len(sub.Xs); i++ {
+			path.LineTo(sub.Xs[i], sub.Ys[i])
+		}
+		if sub.Closed {
+			path.Close()
+		}
+	}
+	return path
+}
+
+// strokePath draws the outline of a vector path with one DrawTriangles call
+func strokePath(dst *ebiten.Image, path *vector.Path, st core.Stroke, clr color.Color) {
+	op := &vector.StrokeOptions{Width: st.Width, MiterLimit: 10}
+	switch st.Join {
+	case core.JoinMiter:
+		op.LineJoin = vector.LineJoinMiter
+	case core.JoinBevel:
+		op.LineJoin = vector.LineJoinBevel
+	default:
+		op.LineJoin = vector.LineJoinRound
+	}
+	if st.RoundCap {
+		op.LineCap = vector.LineCapRound
+	}
+	vs, is := path.AppendVerticesAndIndicesForStroke(nil, nil, op)
+	drawSolid(dst, vs, is, clr, st.Antialias)
+}
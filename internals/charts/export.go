@@ -0,0 +1,89 @@
+package charts
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+
+	"example.com/menu/internals/charts/core"
+	"example.com/menu/internals/textwrapper"
+)
+
+// exportOptions takes the text size and color the options leave open from the TextWrapper
+func exportOptions(opts core.ExportOptions, tw *textwrapper.TextWrapper) core.ExportOptions {
+	if tw == nil {
+		return opts
+	}
+	if opts.FontSize <= 0 {
+		opts.FontSize = tw.GoTextFace.Size
+	}
+	if opts.TextColor == nil {
+		opts.TextColor = tw.Color
+	}
+	return opts
+}
+
+// exportable fails for renderers from outside the package, they only draw on Ebiten images
+func exportable(r Renderer) error {
+	if _, ok := r.(headlessRenderer); !ok {
+		return fmt.Errorf("charts: renderer %T cannot be exported", r)
+	}
+	return nil
+}
+
+// ExportImage draws the chart with r into a new image, in software and without a GPU.
+// The layout is the one Draw gives a screen of the same size. dataColor colors Data
+// when there is no Model, as in Draw. Text the options leave open has the size and
+// color of the TextWrapper. Only the renderers of this package can be exported.
+func (g *Chart03) ExportImage(r Renderer, dataColor color.Color, opts core.ExportOptions) (*image.RGBA, error) {
+	if err := exportable(r); err != nil {
+		return nil, err
+	}
+	return core.ExportImage(exportOptions(opts, g.TextWrapper), func(c core.Canvas, bounds image.Rectangle) {
+		g.draw(c, bounds, r, dataColor)
+	})
+}
+
+// ExportPNG writes the chart drawn by ExportImage as a PNG
+func (g *Chart03) ExportPNG(w io.Writer, r Renderer, dataColor color.Color, opts core.ExportOptions) error {
+	img, err := g.ExportImage(r, dataColor, opts)
+	if err != nil {
+		return err
+	}
+	return png.Encode(w, img)
+}
+
+// ExportSVG writes the chart as an SVG document with the layout of ExportImage
+func (g *Chart03) ExportSVG(w io.Writer, r Renderer, dataColor color.Color, opts core.ExportOptions) error {
+	if err := exportable(r); err != nil {
+		return err
+	}
+	return core.ExportSVG(w, exportOptions(opts, g.TextWrapper), func(c core.Canvas, bounds image.Rectangle) {
+		g.draw(c, bounds, r, dataColor)
+	})
+}
+
+// ExportImage draws the heatmap into a new image of the size of the options, like ExportImage of a chart
+func (h *Heatmap) ExportImage(opts core.ExportOptions) (*image.RGBA, error) {
+	return core.ExportImage(exportOptions(opts, h.TextWrapper), func(c core.Canvas, bounds image.Rectangle) {
+		h.draw(c, bounds)
+	})
+}
+
+// ExportPNG writes the heatmap drawn by ExportImage as a PNG
+func (h *Heatmap) ExportPNG(w io.Writer, opts core.ExportOptions) error {
+	img, err := h.ExportImage(opts)
+	if err != nil {
+		return err
+	}
+	return png.Encode(w, img)
+}
+
+// ExportSVG writes the heatmap as an SVG document, the cells are an embedded PNG
+func (h *Heatmap) ExportSVG(w io.Writer, opts core.ExportOptions) error {
+	return core.ExportSVG(w, exportOptions(opts, h.TextWrapper), func(c core.Canvas, bounds image.Rectangle) {
+		h.draw(c, bounds)
+	})
+}
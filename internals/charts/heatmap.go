@@ -0,0 +1,571 @@
+package charts
+
+import (
+	"image"
+	"image/color"
+	"math"
+	"strconv"
+
+	"example.com/menu/internals/charts/axis"
+	"example.com/menu/internals/charts/core"
+	"example.com/menu/internals/layout"
+	"example.com/menu/internals/textwrapper"
+	"example.com/menu/internals/utils"
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+const (
+	heatmapPadding  = 8
+	colorBarWidth   = 14
+	colorBarGap     = 16
+	colorBarSteps   = 256
+	minMarginedCell = 4
+)
+
+// Heatmap draws a matrix of values as a grid of colored cells, with row and
+// column labels, a color bar and the value under the cursor.
+//
+// The cells are laid out like the cells of a layout.Grid: rows from the top,
+// columns from the left and CellMargin pixels apart. They are drawn as one image
+// with a pixel per cell, so a 500 x 500 matrix is a single draw. The image is made
+// again after SetValues or Invalidate.
+type Heatmap struct {
+	X, Y          float32
+	Width, Height float32
+	// Values are the rows of the matrix, top row first. NaN cells have no value,
+	// short rows are filled up with NaN.
+	Values    [][]float64
+	RowLabels []string
+	ColLabels []string
+	// Scale colors the values, nil is Sequential with Viridis
+	Scale ColorScale
+	// Min and Max fix the range of the scale when Min < Max, otherwise it spans the values
+	Min, Max float64
+	// Format writes the values on hover and in the color bar, nil picks the decimals from the range
+	Format axis.Formatter
+	// CellMargin is the space between the cells, filled with Background. It is left
+	// out when there is no Background or the cells are smaller than 4 pixels.
+	CellMargin float32
+	// Background fills the heatmap first, nil leaves it transparent
+	Background color.Color
+	// NaNColor fills the cells without a value, nil leaves them empty
+	NaNColor      color.Color
+	HideColorBar  bool
+	ColorBarLabel string
+	// BorderColor outlines the color bar and its ticks, nil is grey
+	BorderColor  color.Color
+	HoverColor   color.Color
+	TooltipColor color.Color
+	TextWrapper  *textwrapper.TextWrapper
+	Disabled     bool
+
+	hovering bool
+	hoverRow int
+	hoverCol int
+	cursorX  float32
+	cursorY  float32
+
+	// valid is false when the pixels no longer match the values, uploaded when the images do not
+	valid      bool
+	uploaded   bool
+	lo, hi     float64
+	cellPixels *image.RGBA
+	barPixels  *image.RGBA
+	cellImage  *ebiten.Image
+	barImage   *ebiten.Image
+}
+
+func NewHeatmap(x, y, width, height float32, values [][]float64, tw *textwrapper.TextWrapper) *Heatmap {
+	return &Heatmap{
+		X:           x,
+		Y:           y,
+		Width:       width,
+		Height:      height,
+		Values:      values,
+		TextWrapper: tw,
+	}
+}
+
+func (h *Heatmap) SetBounds(x, y, width, height float32) {
+	h.X, h.Y, h.Width, h.Height = x, y, width, height
+}
+
+func (h *Heatmap) Bounds() (float32, float32, float32, float32) {
+	return h.X, h.Y, h.Width, h.Height
+}
+
+// SetValues replaces the matrix
+func (h *Heatmap) SetValues(values [][]float64) {
+	h.Values = values
+	h.Invalidate()
+}
+
+// Invalidate makes the cells again on the next draw, call it after changing
+// Values in place or the Scale, Min, Max or NaNColor
+func (h *Heatmap) Invalidate() {
+	h.valid = false
+}
+
+// Range returns the range the scale colors, from Min and Max or the values
+func (h *Heatmap) Range() (float64, float64) {
+	h.update()
+	return h.lo, h.hi
+}
+
+// Hovered returns the cell under the cursor
+func (h *Heatmap) Hovered() (row, col int, ok bool) {
+	return h.hoverRow, h.hoverCol, h.hovering
+}
+
+func (h *Heatmap) scale() ColorScale {
+	if h.Scale == nil {
+		return &Sequential{}
+	}
+	return h.Scale
+}
+
+// size is the number of rows and of columns, the longest row counts
+func (h *Heatmap) size() (int, int) {
+	cols := 0
+	for _, row := range h.Values {
+		cols = max(cols, len(row))
+	}
+	return len(h.Values), cols
+}
+
+func (h *Heatmap) value(row, col int) float64 {
+	if row < 0 || row >= len(h.Values) || col < 0 || col >= len(h.Values[row]) {
+		return math.NaN()
+	}
+	return h.Values[row][col]
+}
+
+func (h *Heatmap) format(v float64) string {
+	if c, ok := h.scale().(*Categorical); ok {
+		if i, ok := c.class(v); ok {
+			return c.label(i)
+		}
+	}
+	if h.Format != nil {
+		return h.Format(v)
+	}
+	return strconv.FormatFloat(v, 'g', 6, 64)
+}
+
+// update colors the cells and the color bar when the values changed
+func (h *Heatmap) update() {
+	if h.valid {
+		return
+	}
+	h.valid, h.uploaded = true, false
+	h.lo, h.hi = h.Min, h.Max
+	if !(h.Min < h.Max) {
+		h.lo, h.hi = valueRange(h.Values)
+	}
+
+	scale := h.scale()
+	noValue := color.RGBAModel.Convert(utils.ColorOr(h.NaNColor, color.Transparent)).(color.RGBA)
+	rows, cols := h.size()
+	h.cellPixels = image.NewRGBA(image.Rect(0, 0, cols, rows))
+	for r := 0; r < rows; r++ {
+		for c := 0; c < cols; c++ {
+			clr := noValue
+			if v := h.value(r, c); !math.IsNaN(v) {
+				clr = scale.Color(v, h.lo, h.hi)
+			}
+			i := h.cellPixels.PixOffset(c, r)
+			h.cellPixels.Pix[i], h.cellPixels.Pix[i+1], h.cellPixels.Pix[i+2], h.cellPixels.Pix[i+3] = clr.R, clr.G, clr.B, clr.A
+		}
+	}
+	h.barPixels = h.colorBarPixels(scale)
+}
+
+// valueRange returns the smallest and largest finite value, 0, 0 when there is none
+func valueRange(values [][]float64) (float64, float64) {
+	lo, hi := math.Inf(1), math.Inf(-1)
+	for _, row := range values {
+		for _, v := range row {
+			if !math.IsNaN(v) && !math.IsInf(v, 0) {
+				lo, hi = math.Min(lo, v), math.Max(hi, v)
+			}
+		}
+	}
+	if lo > hi {
+		return 0, 0
+	}
+	return lo, hi
+}
+
+// barScale places the values along the color bar
+func (h *Heatmap) barScale() axis.Scale {
+	switch s := h.scale().(type) {
+	case *Sequential:
+		if s.Scale != nil {
+			return s.Scale
+		}
+	case *Categorical:
+		labels := make([]string, s.count())
+		for i := range labels {
+			labels[i] = s.label(i)
+		}
+		return &axis.Category{Labels: labels}
+	}
+	return &axis.Linear{Format: h.Format, Minor: -1}
+}
+
+// colorBarPixels is a column of colors from the top of the bar, a pixel per class of a categorical scale
+func (h *Heatmap) colorBarPixels(scale ColorScale) *image.RGBA {
+	if c, ok := scale.(*Categorical); ok {
+		img := image.NewRGBA(image.Rect(0, 0, 1, c.count()))
+		for i := 0; i < c.count(); i++ {
+			img.SetRGBA(0, i, c.Color(float64(i), h.lo, h.hi))
+		}
+		return img
+	}
+	bs := h.barScale()
+	lo, hi := bs.Transform(h.lo), bs.Transform(h.hi)
+	img := image.NewRGBA(image.Rect(0, 0, 1, colorBarSteps))
+	for i := 0; i < colorBarSteps; i++ {
+		t := 1 - (float64(i)+0.5)/colorBarSteps
+		img.SetRGBA(0, i, scale.Color(bs.Untransform(lo+t*(hi-lo)), h.lo, h.hi))
+	}
+	return img
+}
+
+// barTicks are the labelled values of the color bar
+func (h *Heatmap) barTicks() []axis.Tick {
+	if c, ok := h.scale().(*Categorical); ok {
+		return h.barScale().Ticks(0, float64(c.count()-1), c.count())
+	}
+	return h.barScale().Ticks(h.lo, h.hi, 6)
+}
+
+// heatmapLayout places the parts of a heatmap, from its top left corner
+type heatmapLayout struct {
+	gridX, gridY   float64
+	gridW, gridH   float64
+	cellW, cellH   float64
+	rows, cols     int
+	margin         float64
+	bar            bool
+	barX, barY     float64
+	barW, barH     float64
+	rowStep        int
+	colStep        int
+	rowLabelsWidth float64
+	lineHeight     float64
+}
+
+func (h *Heatmap) layout(c core.Canvas, width, height float64) heatmapLayout {
+	h.update()
+	l := heatmapLayout{bar: !h.HideColorBar}
+	l.rows, l.cols = h.size()
+	text := c.HasText()
+	if text {
+		_, l.lineHeight = c.Measure("0")
+	}
+
+	top, bottom := float64(heatmapPadding), float64(heatmapPadding)
+	left, right := float64(heatmapPadding), float64(heatmapPadding)
+	colLabelsWidth := 0.0
+	if text {
+		for _, label := range h.RowLabels {
+			w, _ := c.Measure(label)
+			l.rowLabelsWidth = max(l.rowLabelsWidth, w)
+		}
+		for _, label := range h.ColLabels {
+			w, _ := c.Measure(label)
+			colLabelsWidth = max(colLabelsWidth, w)
+		}
+		if l.rowLabelsWidth > 0 {
+			left += l.rowLabelsWidth + 6
+		}
+		if len(h.ColLabels) > 0 {
+			bottom += l.lineHeight + 6
+		}
+	}
+	if l.bar {
+		tickLabels := 0.0
+		if text {
+			for _, t := range h.barTicks() {
+				w, _ := c.Measure(t.Label)
+				tickLabels = max(tickLabels, w)
+			}
+			if h.ColorBarLabel != "" {
+				top += l.lineHeight + 4
+			}
+		}
+		right += colorBarGap + colorBarWidth + 6 + tickLabels
+	}
+
+	l.gridX, l.gridY = left, top
+	l.gridW, l.gridH = max(width-left-right, 0), max(height-top-bottom, 0)
+	if l.rows > 0 && l.cols > 0 {
+		l.cellW, l.cellH = l.gridW/float64(l.cols), l.gridH/float64(l.rows)
+	}
+	if h.Background != nil && l.cellW >= minMarginedCell && l.cellH >= minMarginedCell {
+		l.margin = math.Min(float64(h.CellMargin), math.Min(l.cellW, l.cellH)/2)
+	}
+	l.barX, l.barY = l.gridX+l.gridW+colorBarGap, l.gridY
+	l.barW, l.barH = colorBarWidth, l.gridH
+
+	// Every label when they fit, otherwise every n-th
+	l.rowStep, l.colStep = 1, 1
+	if l.cellH > 0 {
+		l.rowStep = max(int(math.Ceil((l.lineHeight+2)/l.cellH)), 1)
+	}
+	if l.cellW > 0 {
+		l.colStep = max(int(math.Ceil((colLabelsWidth+8)/l.cellW)), 1)
+	}
+	return l
+}
+
+// cellRect is the cell without the margins, from the top left corner of the heatmap
+func (l heatmapLayout) cellRect(row, col int) (x, y, width, height float64) {
+	x0, x1 := float64(col)*l.cellW, float64(col+1)*l.cellW
+	y0, y1 := float64(row)*l.cellH, float64(row+1)*l.cellH
+	// The margin is shared by the neighbours, the outer cells reach the edge of the grid
+	if col > 0 {
+		x0 += l.margin / 2
+	}
+	if col < l.cols-1 {
+		x1 -= l.margin / 2
+	}
+	if row > 0 {
+		y0 += l.margin / 2
+	}
+	if row < l.rows-1 {
+		y1 -= l.margin / 2
+	}
+	return l.gridX + x0, l.gridY + y0, x1 - x0, y1 - y0
+}
+
+// barPosition returns the y of a value on the color bar
+func (h *Heatmap) barPosition(l heatmapLayout, v float64) float64 {
+	if c, ok := h.scale().(*Categorical); ok {
+		return l.barY + l.barH*(v+0.5)/float64(c.count())
+	}
+	return l.barY + l.barH*(1-axis.Normalize(h.barScale(), v, h.lo, h.hi))
+}
+
+// Cell returns the rectangle of a cell, from the top left corner of the heatmap
+func (h *Heatmap) Cell(row, col int) layout.Cell {
+	l := h.layout(&imageCanvas{tw: h.TextWrapper}, float64(h.Width), float64(h.Height))
+	x, y, width, height := l.cellRect(row, col)
+	return layout.Cell{
+		X:      int(math.Round(x)),
+		Y:      int(math.Round(y)),
+		Width:  int(math.Round(x+width)) - int(math.Round(x)),
+		Height: int(math.Round(y+height)) - int(math.Round(y)),
+	}
+}
+
+// CellAt returns the cell at x, y from the top left corner of the heatmap, the
+// margins count to the nearest cell
+func (h *Heatmap) CellAt(x, y float32) (row, col int, ok bool) {
+	l := h.layout(&imageCanvas{tw: h.TextWrapper}, float64(h.Width), float64(h.Height))
+	return l.cellAt(float64(x), float64(y))
+}
+
+func (l heatmapLayout) cellAt(x, y float64) (int, int, bool) {
+	if l.cellW <= 0 || l.cellH <= 0 || x < l.gridX || y < l.gridY || x >= l.gridX+l.gridW || y >= l.gridY+l.gridH {
+		return 0, 0, false
+	}
+	col := min(int((x-l.gridX)/l.cellW), l.cols-1)
+	row := min(int((y-l.gridY)/l.cellH), l.rows-1)
+	return row, col, true
+}
+
+func (h *Heatmap) Update(offsetX, offsetY float32, isAnimating bool) {
+	h.hovering = false
+	if h.Disabled || isAnimating {
+		return
+	}
+	cursorX, cursorY := ebiten.CursorPosition()
+	h.cursorX, h.cursorY = float32(cursorX)-offsetX-h.X, float32(cursorY)-offsetY-h.Y
+	h.hoverRow, h.hoverCol, h.hovering = h.CellAt(h.cursorX, h.cursorY)
+}
+
+func (h *Heatmap) Draw(screen *ebiten.Image) {
+	c := &imageCanvas{dst: screen, tw: h.TextWrapper}
+	bounds := image.Rect(int(h.X), int(h.Y), int(h.X+h.Width), int(h.Y+h.Height))
+	h.draw(c, bounds)
+	if h.hovering {
+		h.drawHover(c, bounds)
+	}
+}
+
+func (h *Heatmap) draw(c core.Canvas, bounds image.Rectangle) {
+	ox, oy := float64(bounds.Min.X), float64(bounds.Min.Y)
+	l := h.layout(c, float64(bounds.Dx()), float64(bounds.Dy()))
+	if h.Background != nil {
+		core.FillRect(c, float32(ox), float32(oy), float32(bounds.Dx()), float32(bounds.Dy()), h.Background)
+	}
+	if l.rows == 0 || l.cols == 0 {
+		return
+	}
+	if _, ok := c.(*imageCanvas); ok && !h.uploaded {
+		h.cellImage = upload(h.cellImage, h.cellPixels)
+		h.barImage = upload(h.barImage, h.barPixels)
+		h.uploaded = true
+	}
+
+	drawPixels(c, h.cellPixels, h.cellImage, ox+l.gridX, oy+l.gridY, l.gridW, l.gridH)
+	if l.margin > 0 {
+		h.drawMargins(c, l, ox, oy)
+	}
+	if c.HasText() {
+		h.drawLabels(c, l, ox, oy)
+	}
+	if l.bar {
+		h.drawColorBar(c, l, ox, oy)
+	}
+}
+
+// upload copies the pixels into an Ebiten image, made again when the size changed
+func upload(img *ebiten.Image, pixels *image.RGBA) *ebiten.Image {
+	size := pixels.Bounds().Size()
+	if img == nil || img.Bounds().Size() != size {
+		if img != nil {
+			img.Deallocate()
+		}
+		img = ebiten.NewImage(size.X, size.Y)
+	}
+	img.WritePixels(pixels.Pix)
+	return img
+}
+
+// drawPixels stretches the pixels over the rectangle, on screen from their uploaded image
+func drawPixels(c core.Canvas, pixels *image.RGBA, uploaded *ebiten.Image, x, y, width, height float64) {
+	switch c := c.(type) {
+	case *imageCanvas:
+		size := pixels.Bounds().Size()
+		op := &ebiten.DrawImageOptions{}
+		op.GeoM.Scale(width/float64(size.X), height/float64(size.Y))
+		op.GeoM.Translate(x, y)
+		c.dst.DrawImage(uploaded, op)
+	case core.PixelCanvas:
+		c.Pixels(pixels, x, y, width, height)
+	}
+}
+
+// drawMargins covers the lines between the cells with the background, in one shape
+func (h *Heatmap) drawMargins(c core.Canvas, l heatmapLayout, ox, oy float64) {
+	var s core.Shape
+	add := func(x, y, width, height float64) {
+		s.Subpaths = append(s.Subpaths, core.RectShape(float32(x), float32(y), float32(width), float32(height)).Subpaths...)
+	}
+	for col := 1; col < l.cols; col++ {
+		add(ox+l.gridX+float64(col)*l.cellW-l.margin/2, oy+l.gridY, l.margin, l.gridH)
+	}
+	for row := 1; row < l.rows; row++ {
+		add(ox+l.gridX, oy+l.gridY+float64(row)*l.cellH-l.margin/2, l.gridW, l.margin)
+	}
+	c.Fill(&s, h.Background, false)
+}
+
+func (h *Heatmap) drawLabels(c core.Canvas, l heatmapLayout, ox, oy float64) {
+	for row := 0; row < l.rows && row < len(h.RowLabels); row += l.rowStep {
+		w, lh := c.Measure(h.RowLabels[row])
+		y := oy + l.gridY + (float64(row)+0.5)*l.cellH - lh/2
+		c.Text(h.RowLabels[row], ox+l.gridX-6-w, y, nil)
+	}
+	for col := 0; col < l.cols && col < len(h.ColLabels); col += l.colStep {
+		w, _ := c.Measure(h.ColLabels[col])
+		x := ox + l.gridX + (float64(col)+0.5)*l.cellW - w/2
+		c.Text(h.ColLabels[col], x, oy+l.gridY+l.gridH+6, nil)
+	}
+}
+
+func (h *Heatmap) drawColorBar(c core.Canvas, l heatmapLayout, ox, oy float64) {
+	border := utils.ColorOr(h.BorderColor, color.RGBA{128, 128, 128, 255})
+	x, y := ox+l.barX, oy+l.barY
+	drawPixels(c, h.barPixels, h.barImage, x, y, l.barW, l.barH)
+	core.StrokeRect(c, float32(x), float32(y), float32(l.barW), float32(l.barH), 1, border)
+	if !c.HasText() {
+		return
+	}
+	if h.ColorBarLabel != "" {
+		c.Text(h.ColorBarLabel, x, y-l.lineHeight-4, nil)
+	}
+
+	position := func(v float64) float64 { return oy + h.barPosition(l, v) }
+	ticks := axis.Fit(h.barTicks(), position, func(label string) float64 {
+		_, lh := c.Measure(label)
+		return lh
+	}, 2)
+	right := float32(x + l.barW)
+	for _, t := range ticks {
+		ty := position(t.Value)
+		if math.IsNaN(ty) {
+			continue
+		}
+		core.StrokeLine(c, right, float32(ty), right+core.TickLength(t), float32(ty), 1, border, false)
+		if t.Label != "" {
+			_, lh := c.Measure(t.Label)
+			c.Text(t.Label, float64(right)+6, ty-lh/2, nil)
+		}
+	}
+}
+
+// drawHover outlines the hovered cell, marks its value on the color bar and shows it in a tooltip
+func (h *Heatmap) drawHover(c core.Canvas, bounds image.Rectangle) {
+	ox, oy := float64(bounds.Min.X), float64(bounds.Min.Y)
+	l := h.layout(c, float64(bounds.Dx()), float64(bounds.Dy()))
+	if h.hoverRow >= l.rows || h.hoverCol >= l.cols {
+		return
+	}
+	hover := utils.ColorOr(h.HoverColor, color.White)
+	x, y, width, height := l.cellRect(h.hoverRow, h.hoverCol)
+	// Cells of a large matrix are too small to show an outline, it grows around them
+	grow := math.Max(0, (6-math.Min(width, height))/2)
+	core.StrokeRect(c, float32(ox+x-grow), float32(oy+y-grow), float32(width+2*grow), float32(height+2*grow), 2, hover)
+
+	v := h.value(h.hoverRow, h.hoverCol)
+	if l.bar && !math.IsNaN(v) {
+		if by := oy + h.barPosition(l, v); !math.IsNaN(by) {
+			by = math.Max(oy+l.barY, math.Min(oy+l.barY+l.barH, by))
+			core.StrokeLine(c, float32(ox+l.barX-3), float32(by), float32(ox+l.barX+l.barW+3), float32(by), 2, hover, false)
+		}
+	}
+
+	if !c.HasText() {
+		return
+	}
+	name := func(labels []string, i int, unit string) string {
+		if i < len(labels) {
+			return labels[i]
+		}
+		return unit + " " + strconv.Itoa(i)
+	}
+	value := "no value"
+	if !math.IsNaN(v) {
+		value = h.format(v)
+	}
+	lines := []string{name(h.RowLabels, h.hoverRow, "row") + ", " + name(h.ColLabels, h.hoverCol, "column"), value}
+
+	const padding = 6
+	boxW, lineHeight := 0.0, 0.0
+	for _, line := range lines {
+		w, lh := c.Measure(line)
+		boxW, lineHeight = max(boxW, w), max(lineHeight, lh)
+	}
+	boxW += 2 * padding
+	boxH := lineHeight*float64(len(lines)) + 2*padding
+
+	// Right of the cursor, flipped to the left and kept inside the heatmap near the edges
+	bx, by := ox+float64(h.cursorX)+16, oy+float64(h.cursorY)+16
+	if bx+boxW > ox+float64(bounds.Dx()) {
+		bx = ox + float64(h.cursorX) - 16 - boxW
+	}
+	if by+boxH > oy+float64(bounds.Dy()) {
+		by = oy + float64(bounds.Dy()) - boxH
+	}
+	bx, by = math.Max(bx, ox), math.Max(by, oy)
+
+	core.FillRect(c, float32(bx), float32(by), float32(boxW), float32(boxH), utils.ColorOr(h.TooltipColor, color.RGBA{20, 20, 24, 230}))
+	core.StrokeRect(c, float32(bx), float32(by), float32(boxW), float32(boxH), 1, color.RGBA{128, 128, 128, 255})
+	for i, line := range lines {
+		c.Text(line, bx+padding, by+padding+lineHeight*float64(i), color.White)
+	}
+}
@@ -0,0 +1,461 @@
+package charts
+
+import (
+	"image"
+	"image/color"
+	"math"
+	"strconv"
+	"time"
+
+	"example.com/menu/internals/charts/axis"
+	"example.com/menu/internals/charts/core"
+	"example.com/menu/internals/utils"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+// SelectedPoint is a point picked by a click or a selection box
+type SelectedPoint struct {
+	Series *core.Series
+	Index  int
+	Point  core.Point
+}
+
+type chartDrag int
+
+const (
+	dragNone chartDrag = iota
+	dragPan
+	dragZoomBox
+	dragSelectBox
+)
+
+// InteractiveChart makes a Chart03 a page element that can be inspected and navigated.
+//
+// Hovering the plot shows a crosshair and the values of every series at the
+// nearest x. A click on a legend entry hides or shows its series, a click on a
+// point selects it and Shift+drag selects the points in a box. The wheel zooms
+// around the cursor, a drag pans, a right drag zooms into a box and a double
+// click goes back to the model ranges.
+//
+// All positions are relative to the chart rectangle, so the chart works on a
+// page that the navigator slides or offsets.
+type InteractiveChart struct {
+	X, Y          float32
+	Width, Height float32
+	Chart         *Chart03
+	Renderer      Renderer
+	// ZoomStep is the zoom factor of one wheel notch, 0 means 1.2
+	ZoomStep float64
+	// SnapDistance is how far from a point, in pixels, a click or the tooltip still reaches it, 0 means 12
+	SnapDistance   float32
+	CrosshairColor color.Color
+	SelectionColor color.Color
+	TooltipColor   color.Color
+	Disabled       bool
+	// OnSelectionChanged is called with the selected points, empty when the selection is cleared
+	OnSelectionChanged func(selected []SelectedPoint)
+	// OnViewChanged is called with the ranges after a zoom, a pan or a reset
+	OnViewChanged func(xMin, xMax, yMin, yMax float64)
+
+	// saved are the model ranges from before the first zoom or pan
+	saved    *[2]core.AxisRange
+	hovering bool
+	cursorX  float32
+	cursorY  float32
+	pressed  bool
+	drag     chartDrag
+	pressX   float32
+	pressY   float32
+	// panFrom is the view at the start of a pan
+	panFrom   *core.Plot
+	lastClick time.Time
+	selected  []SelectedPoint
+}
+
+// NewInteractiveChart wraps a chart, a chart without a Model gets one from its Data
+func NewInteractiveChart(x, y, width, height float32, chart *Chart03, r Renderer) *InteractiveChart {
+	if chart.Model == nil {
+		chart.Model = chart.model(chart.PointColor)
+	}
+	// Hidden series stay in the legend so a click can bring them back
+	chart.Model.Legend.ShowHidden = true
+	return &InteractiveChart{
+		X:        x,
+		Y:        y,
+		Width:    width,
+		Height:   height,
+		Chart:    chart,
+		Renderer: r,
+	}
+}
+
+func (c *InteractiveChart) SetBounds(x, y, width, height float32) {
+	c.X, c.Y, c.Width, c.Height = x, y, width, height
+}
+
+func (c *InteractiveChart) Bounds() (float32, float32, float32, float32) {
+	return c.X, c.Y, c.Width, c.Height
+}
+
+func (c *InteractiveChart) zoomStep() float64 {
+	if c.ZoomStep <= 1 {
+		return 1.2
+	}
+	return c.ZoomStep
+}
+
+func (c *InteractiveChart) snapDistance() float32 {
+	if c.SnapDistance <= 0 {
+		return 12
+	}
+	return c.SnapDistance
+}
+
+// plot is the plot in chart coordinates, 0, 0 is the top left corner of the chart
+func (c *InteractiveChart) plot() *core.Plot {
+	return c.Chart.PlotRect(image.Rect(0, 0, int(c.Width), int(c.Height)), c.Chart.Model, c.Renderer)
+}
+
+func (c *InteractiveChart) hasAxes() bool {
+	h, ok := c.Renderer.(core.AxesHider)
+	return !ok || !h.HideAxes()
+}
+
+// Selected returns the selected points
+func (c *InteractiveChart) Selected() []SelectedPoint {
+	return c.selected
+}
+
+func (c *InteractiveChart) setSelection(selected []SelectedPoint) {
+	if len(selected) == 0 && len(c.selected) == 0 {
+		return
+	}
+	c.selected = selected
+	if c.OnSelectionChanged != nil {
+		c.OnSelectionChanged(selected)
+	}
+}
+
+func (c *InteractiveChart) ClearSelection() {
+	c.setSelection(nil)
+}
+
+// IsZoomed reports whether the view differs from the model ranges
+func (c *InteractiveChart) IsZoomed() bool {
+	return c.saved != nil
+}
+
+// ResetView gives the model its own ranges back
+func (c *InteractiveChart) ResetView() {
+	if c.saved == nil {
+		return
+	}
+	m := c.Chart.Model
+	m.X, m.Y = c.saved[0], c.saved[1]
+	c.saved = nil
+	c.viewChanged()
+}
+
+// setView fixes the model ranges to a view given in transformed units
+func (c *InteractiveChart) setView(xLo, xHi, yLo, yHi float64) {
+	m := c.Chart.Model
+	if c.saved == nil {
+		c.saved = &[2]core.AxisRange{m.X, m.Y}
+	}
+	m.X = core.AxisRange{Fixed: true, Min: m.X.Untransform(xLo), Max: m.X.Untransform(xHi), Scale: m.X.Scale}
+	m.Y = core.AxisRange{Fixed: true, Min: m.Y.Untransform(yLo), Max: m.Y.Untransform(yHi), Scale: m.Y.Scale}
+	c.viewChanged()
+}
+
+func (c *InteractiveChart) viewChanged() {
+	if c.OnViewChanged != nil {
+		plot := c.plot()
+		c.OnViewChanged(plot.XMin, plot.XMax, plot.YMin, plot.YMax)
+	}
+}
+
+// transformed returns the view of a plot in the units its scales are linear in
+func (c *InteractiveChart) transformed(plot *core.Plot) (xLo, xHi, yLo, yHi float64) {
+	m := c.Chart.Model
+	return m.X.Transform(plot.XMin), m.X.Transform(plot.XMax), m.Y.Transform(plot.YMin), m.Y.Transform(plot.YMax)
+}
+
+// zoom scales the view by factor around the chart position x, y
+func (c *InteractiveChart) zoom(plot *core.Plot, x, y float32, factor float64) {
+	xLo, xHi, yLo, yHi := c.transformed(plot)
+	fx := (float64(x) - plot.X) / plot.Width
+	fy := (plot.Y + plot.Height - float64(y)) / plot.Height
+	xAt, yAt := xLo+fx*(xHi-xLo), yLo+fy*(yHi-yLo)
+	xSpan, ySpan := (xHi-xLo)*factor, (yHi-yLo)*factor
+	c.setView(xAt-fx*xSpan, xAt+(1-fx)*xSpan, yAt-fy*ySpan, yAt+(1-fy)*ySpan)
+}
+
+// pan moves the view of the press by the cursor travel
+func (c *InteractiveChart) pan(x, y float32) {
+	from := c.panFrom
+	xLo, xHi, yLo, yHi := c.transformed(from)
+	dx := -float64(x-c.pressX) / from.Width * (xHi - xLo)
+	dy := float64(y-c.pressY) / from.Height * (yHi - yLo)
+	c.setView(xLo+dx, xHi+dx, yLo+dy, yHi+dy)
+}
+
+// zoomToBox shows the rectangle between the press and x, y
+func (c *InteractiveChart) zoomToBox(plot *core.Plot, x, y float32) {
+	xLo, xHi, yLo, yHi := c.transformed(plot)
+	fx0 := (float64(min(c.pressX, x)) - plot.X) / plot.Width
+	fx1 := (float64(max(c.pressX, x)) - plot.X) / plot.Width
+	fy0 := (plot.Y + plot.Height - float64(max(c.pressY, y))) / plot.Height
+	fy1 := (plot.Y + plot.Height - float64(min(c.pressY, y))) / plot.Height
+	c.setView(xLo+fx0*(xHi-xLo), xLo+fx1*(xHi-xLo), yLo+fy0*(yHi-yLo), yLo+fy1*(yHi-yLo))
+}
+
+// pointsIn returns the visible points inside the box between the press and x, y
+func (c *InteractiveChart) pointsIn(plot *core.Plot, x, y float32) []SelectedPoint {
+	left, right := min(c.pressX, x), max(c.pressX, x)
+	top, bottom := min(c.pressY, y), max(c.pressY, y)
+	var selected []SelectedPoint
+	for _, s := range c.Chart.Model.Visible() {
+		for i, p := range s.Points {
+			px, py := plot.ToScreen(p)
+			if px >= left && px <= right && py >= top && py <= bottom {
+				selected = append(selected, SelectedPoint{s, i, p})
+			}
+		}
+	}
+	return selected
+}
+
+// nearestPoint returns the visible point closest to x, y within the snap distance
+func (c *InteractiveChart) nearestPoint(plot *core.Plot, x, y float32) (SelectedPoint, bool) {
+	best, found := float32(c.snapDistance()), false
+	var nearest SelectedPoint
+	for _, s := range c.Chart.Model.Visible() {
+		for i, p := range s.Points {
+			px, py := plot.ToScreen(p)
+			if d := float32(math.Hypot(float64(px-x), float64(py-y))); d <= best {
+				best, found = d, true
+				nearest = SelectedPoint{s, i, p}
+			}
+		}
+	}
+	return nearest, found
+}
+
+// snapped returns the x value closest to the cursor and the point of every series at it
+func (c *InteractiveChart) snapped(plot *core.Plot) (float64, []SelectedPoint, bool) {
+	snapX, best := 0.0, math.Inf(1)
+	for _, s := range c.Chart.Model.Visible() {
+		for _, p := range s.Points {
+			if d := math.Abs(plot.ScreenX(p.X) - float64(c.cursorX)); d < best && !math.IsNaN(p.Y) {
+				snapX, best = p.X, d
+			}
+		}
+	}
+	if math.IsInf(best, 1) {
+		return 0, nil, false
+	}
+	var points []SelectedPoint
+	for _, s := range c.Chart.Model.Visible() {
+		index, distance := -1, float64(c.snapDistance())
+		for i, p := range s.Points {
+			if d := math.Abs(plot.ScreenX(p.X) - plot.ScreenX(snapX)); d <= distance && !math.IsNaN(p.Y) {
+				index, distance = i, d
+			}
+		}
+		if index >= 0 {
+			points = append(points, SelectedPoint{s, index, s.Points[index]})
+		}
+	}
+	return snapX, points, true
+}
+
+func (c *InteractiveChart) Update(offsetX, offsetY float32, isAnimating bool) {
+	if c.Disabled || isAnimating {
+		c.hovering, c.pressed, c.drag = false, false, dragNone
+		return
+	}
+	cursorX, cursorY := ebiten.CursorPosition()
+	x, y := float32(cursorX)-offsetX-c.X, float32(cursorY)-offsetY-c.Y
+	c.cursorX, c.cursorY = x, y
+
+	m := c.Chart.Model
+	plot := c.plot()
+	tw := c.Chart.TextWrapper
+	inChart := x >= 0 && y >= 0 && x < c.Width && y < c.Height
+	inPlot := float64(x) >= plot.X && float64(x) < plot.X+plot.Width && float64(y) >= plot.Y && float64(y) < plot.Y+plot.Height
+	onLegend := m.Legend.Contains(plot, m.Series, &imageCanvas{tw: tw}, x, y)
+	c.hovering = inPlot && !onLegend && c.hasAxes()
+
+	if inChart && c.hasAxes() {
+		if _, wheel := ebiten.Wheel(); wheel != 0 && inPlot {
+			c.zoom(plot, x, y, math.Pow(c.zoomStep(), -wheel))
+		}
+	}
+
+	switch {
+	case inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) && inChart:
+		if entry := m.Legend.EntryAt(plot, m.Series, &imageCanvas{tw: tw}, x, y); entry != nil {
+			entry.Hidden = !entry.Hidden
+			return
+		}
+		if !inPlot || onLegend {
+			return
+		}
+		if time.Since(c.lastClick) < 300*time.Millisecond && c.hasAxes() {
+			c.lastClick = time.Time{}
+			c.ResetView()
+			return
+		}
+		c.lastClick = time.Now()
+		c.pressed, c.pressX, c.pressY, c.panFrom = true, x, y, plot
+		c.drag = dragNone
+		if ebiten.IsKeyPressed(ebiten.KeyShift) {
+			c.drag = dragSelectBox
+		}
+	case inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonRight) && inPlot && !onLegend && c.hasAxes():
+		c.pressed, c.pressX, c.pressY = true, x, y
+		c.drag = dragZoomBox
+	}
+
+	if !c.pressed {
+		return
+	}
+	moved := math.Hypot(float64(x-c.pressX), float64(y-c.pressY)) > 3
+	leftDown := ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft)
+	rightDown := ebiten.IsMouseButtonPressed(ebiten.MouseButtonRight)
+
+	if c.drag == dragNone && moved && leftDown && c.hasAxes() {
+		c.drag = dragPan
+		c.lastClick = time.Time{}
+	}
+	if c.drag == dragPan && leftDown {
+		c.pan(x, y)
+	}
+	if leftDown || rightDown {
+		return
+	}
+
+	// Released
+	c.pressed = false
+	switch c.drag {
+	case dragZoomBox:
+		if moved {
+			c.zoomToBox(plot, x, y)
+		}
+	case dragSelectBox:
+		if moved {
+			c.setSelection(c.pointsIn(plot, x, y))
+			break
+		}
+		fallthrough
+	case dragNone:
+		if p, ok := c.nearestPoint(plot, x, y); ok {
+			c.setSelection([]SelectedPoint{p})
+		} else {
+			c.ClearSelection()
+		}
+	}
+	c.drag = dragNone
+}
+
+func (c *InteractiveChart) Draw(screen *ebiten.Image) {
+	rect := image.Rect(int(c.X), int(c.Y), int(c.X+c.Width), int(c.Y+c.Height))
+	area := screen.SubImage(rect).(*ebiten.Image)
+	c.Chart.Draw(area, c.Renderer, nil)
+
+	// The rest is drawn from chart coordinates
+	ox, oy := c.X, c.Y
+	plot := c.plot()
+	selection := utils.ColorOr(c.SelectionColor, color.RGBA{255, 255, 255, 255})
+	for _, p := range c.selected {
+		if p.Series.Hidden {
+			continue
+		}
+		x, y := plot.ToScreen(p.Point)
+		vector.StrokeCircle(area, ox+x, oy+y, p.Series.MarkerRadius()+4, 2, selection, true)
+	}
+
+	if c.pressed && (c.drag == dragZoomBox || c.drag == dragSelectBox) {
+		left, top := ox+min(c.pressX, c.cursorX), oy+min(c.pressY, c.cursorY)
+		w, h := float32(math.Abs(float64(c.cursorX-c.pressX))), float32(math.Abs(float64(c.cursorY-c.pressY)))
+		vector.DrawFilledRect(area, left, top, w, h, core.Fade(selection, 0.15), false)
+		vector.StrokeRect(area, left, top, w, h, 1, selection, false)
+		return
+	}
+	if c.hovering && !c.pressed {
+		c.drawCrosshair(area, plot, ox, oy)
+	}
+}
+
+// drawCrosshair draws the lines at the snapped x and the cursor y and a tooltip with the values
+func (c *InteractiveChart) drawCrosshair(screen *ebiten.Image, plot *core.Plot, ox, oy float32) {
+	snapX, points, ok := c.snapped(plot)
+	crosshair := utils.ColorOr(c.CrosshairColor, color.RGBA{255, 255, 255, 110})
+	x := ox + float32(plot.ScreenX(snapX))
+	if !ok {
+		x = ox + c.cursorX
+	}
+	top, bottom := oy+float32(plot.Y), oy+float32(plot.Y+plot.Height)
+	left, right := ox+float32(plot.X), ox+float32(plot.X+plot.Width)
+	vector.StrokeLine(screen, x, top, x, bottom, 1, crosshair, false)
+	vector.StrokeLine(screen, left, oy+c.cursorY, right, oy+c.cursorY, 1, crosshair, false)
+
+	tw := c.Chart.TextWrapper
+	if !ok || tw == nil || len(points) == 0 {
+		return
+	}
+	m := c.Chart.Model
+	lines := []string{formatValue(m.X.Scale, snapX)}
+	for _, p := range points {
+		px, py := plot.ToScreen(p.Point)
+		vector.DrawFilledCircle(screen, ox+px, oy+py, 4, p.Series.Color, true)
+		name := p.Series.Name
+		if name == "" {
+			name = "y"
+		}
+		lines = append(lines, name+": "+formatValue(m.Y.Scale, p.Point.Y))
+	}
+
+	const padding, swatch = 6, 10
+	width, lineHeight := 0.0, 0.0
+	for _, line := range lines {
+		w, h := tw.MeasureText(line)
+		width, lineHeight = max(width, w), max(lineHeight, h)
+	}
+	boxW := float32(width) + 2*padding + swatch + 4
+	boxH := float32(lineHeight)*float32(len(lines)) + 2*padding
+
+	// Right of the cursor, flipped to the left and kept inside the chart near the edges
+	bx, by := ox+c.cursorX+16, oy+c.cursorY+16
+	if bx+boxW > ox+c.Width {
+		bx = ox + c.cursorX - 16 - boxW
+	}
+	if by+boxH > oy+c.Height {
+		by = oy + c.Height - boxH
+	}
+	bx, by = max(bx, ox), max(by, oy)
+
+	vector.DrawFilledRect(screen, bx, by, boxW, boxH, utils.ColorOr(c.TooltipColor, color.RGBA{20, 20, 24, 230}), false)
+	vector.StrokeRect(screen, bx, by, boxW, boxH, 1, color.RGBA{128, 128, 128, 255}, false)
+	previous := tw.Color
+	defer tw.SetColor(previous)
+	tw.SetColor(color.White)
+	for i, line := range lines {
+		ly := by + padding + float32(lineHeight)*float32(i)
+		if i > 0 {
+			s := points[i-1].Series
+			vector.DrawFilledRect(screen, bx+padding, ly+float32(lineHeight)/2-swatch/2, swatch, swatch, s.Color, false)
+		}
+		tw.DrawText(screen, line, float64(bx+padding+swatch+4), float64(ly))
+	}
+}
+
+// formatValue writes a value for the tooltip, as a date on a time axis
+func formatValue(scale axis.Scale, v float64) string {
+	if t, ok := scale.(*axis.Time); ok {
+		return axis.TimeFormat("2006-01-02 15:04:05", t.Location)(v)
+	}
+	return strconv.FormatFloat(v, 'g', 6, 64)
+}
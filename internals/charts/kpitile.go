@@ -0,0 +1,207 @@
+package charts
+
+import (
+	"image/color"
+	"math"
+	"strconv"
+
+	"example.com/menu/internals/charts/axis"
+	"example.com/menu/internals/charts/core"
+	"example.com/menu/internals/textwrapper"
+	"example.com/menu/internals/utils"
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+const (
+	tilePadding = 10
+	// tileMinSparkline is the least height a sparkline gets below the text, lower tiles put it on the right
+	tileMinSparkline = 24
+)
+
+// KPITile shows a key figure as a big number under its label, the change since
+// the previous value as an arrow and the history as a sparkline.
+//
+// Tall tiles put the sparkline under the number, flat ones like a row of a HUD
+// next to it, so the tile fits whatever rectangle a layout gives it.
+type KPITile struct {
+	X, Y          float32
+	Width, Height float32
+	Label         string
+	Value         float64
+	// Previous is what the delta is measured against, NaN hides the delta
+	Previous float64
+	// Format writes the value and an absolute delta, nil is axis.SI with one decimal
+	Format axis.Formatter
+	// DeltaPercent shows the change in percent of Previous
+	DeltaPercent bool
+	// LowerIsBetter colors a fall as good, e.g. for latency or costs
+	LowerIsBetter bool
+	// Sparkline draws the history, nil draws none
+	Sparkline *Sparkline
+	// TextWrapper writes the label and the delta, NumberText the value. A nil NumberText is the TextWrapper.
+	TextWrapper *textwrapper.TextWrapper
+	NumberText  *textwrapper.TextWrapper
+	Background  color.Color
+	BorderColor color.Color
+	// GoodColor and BadColor color the delta, nil is green and red
+	GoodColor color.Color
+	BadColor  color.Color
+}
+
+// NewKPITile makes a tile with a sparkline of the values, the last one is the value
+func NewKPITile(x, y, width, height float32, label string, values []float64, tw, numberText *textwrapper.TextWrapper) *KPITile {
+	t := &KPITile{
+		X:           x,
+		Y:           y,
+		Width:       width,
+		Height:      height,
+		Label:       label,
+		Value:       math.NaN(),
+		Previous:    math.NaN(),
+		Sparkline:   NewSparkline(0, 0, 0, 0, nil),
+		TextWrapper: tw,
+		NumberText:  numberText,
+	}
+	for _, v := range values {
+		t.SetValue(v)
+	}
+	return t
+}
+
+func (t *KPITile) SetBounds(x, y, width, height float32) {
+	t.X, t.Y, t.Width, t.Height = x, y, width, height
+}
+
+func (t *KPITile) Bounds() (float32, float32, float32, float32) {
+	return t.X, t.Y, t.Width, t.Height
+}
+
+// SetValue makes the value the previous one and appends the new one to the sparkline
+func (t *KPITile) SetValue(v float64) {
+	t.Previous, t.Value = t.Value, v
+	if t.Sparkline != nil {
+		t.Sparkline.Append(v)
+	}
+}
+
+func (t *KPITile) format(v float64) string {
+	if t.Format != nil {
+		return t.Format(v)
+	}
+	return axis.SI(1)(v)
+}
+
+// delta returns the change as text and its direction, 0 when there is none to show
+func (t *KPITile) delta() (string, int) {
+	if math.IsNaN(t.Previous) || math.IsNaN(t.Value) {
+		return "", 0
+	}
+	change := t.Value - t.Previous
+	direction := 0
+	if change > 0 {
+		direction = 1
+	} else if change < 0 {
+		direction = -1
+	}
+	text := t.format(math.Abs(change))
+	if t.DeltaPercent {
+		if t.Previous == 0 {
+			return "", direction
+		}
+		text = strconv.FormatFloat(math.Abs(change/t.Previous)*100, 'f', 1, 64) + "%"
+	}
+	return text, direction
+}
+
+func (t *KPITile) Update(offsetX, offsetY float32, isAnimating bool) {}
+
+func (t *KPITile) Draw(screen *ebiten.Image) {
+	numberText := t.NumberText
+	if numberText == nil {
+		numberText = t.TextWrapper
+	}
+	t.draw(&imageCanvas{dst: screen, tw: t.TextWrapper}, &imageCanvas{dst: screen, tw: numberText}, t.X, t.Y, t.Width, t.Height)
+}
+
+// draw lays out the tile, big writes the number
+func (t *KPITile) draw(c, big core.Canvas, x, y, width, height float32) {
+	if t.Background != nil {
+		core.FillRect(c, x, y, width, height, t.Background)
+	}
+	if t.BorderColor != nil {
+		core.StrokeRect(c, x, y, width, height, 1, t.BorderColor)
+	}
+
+	left, top := float64(x+tilePadding), float64(y+tilePadding)
+	_, labelHeight := c.Measure(t.Label)
+	if t.Label == "" {
+		labelHeight = 0
+	}
+	value := "–"
+	if !math.IsNaN(t.Value) {
+		value = t.format(t.Value)
+	}
+	valueWidth, valueHeight := big.Measure(value)
+	textHeight := labelHeight + valueHeight
+	textWidth := valueWidth
+
+	c.Text(t.Label, left, top, nil)
+	big.Text(value, left, top+labelHeight, nil)
+
+	if text, direction := t.delta(); direction != 0 || text != "" {
+		_, lineHeight := c.Measure(text)
+		size := float32(lineHeight * 0.6)
+		// Beside the number, at its baseline
+		dx := float32(left+valueWidth) + 8
+		dy := float32(top+labelHeight+valueHeight) - float32(lineHeight)
+		clr := t.deltaColor(direction)
+		drawDeltaArrow(c, dx, dy+float32(lineHeight)/2, size, direction, clr)
+		c.Text(text, float64(dx+size+4), float64(dy), clr)
+		w, _ := c.Measure(text)
+		textWidth += 8 + float64(size) + 4 + w
+	}
+
+	if t.Sparkline == nil {
+		return
+	}
+	inner := float64(width - 2*tilePadding)
+	below := float64(height-2*tilePadding) - textHeight - 6
+	if below >= tileMinSparkline || !c.HasText() {
+		t.Sparkline.draw(c, x+tilePadding, float32(top+textHeight+6), float32(inner), float32(max(below, 0)))
+		return
+	}
+	// Too flat for a sparkline below, it takes the room right of the text
+	sx := left + math.Max(textWidth+12, inner*0.5)
+	t.Sparkline.draw(c, float32(sx), y+tilePadding, float32(left+inner-sx), height-2*tilePadding)
+}
+
+func (t *KPITile) deltaColor(direction int) color.Color {
+	good := utils.ColorOr(t.GoodColor, color.RGBA{52, 168, 83, 255})
+	bad := utils.ColorOr(t.BadColor, color.RGBA{234, 67, 53, 255})
+	if direction == 0 {
+		return color.RGBA{160, 160, 160, 255}
+	}
+	if (direction > 0) != t.LowerIsBetter {
+		return good
+	}
+	return bad
+}
+
+// drawDeltaArrow draws a triangle pointing up or down with its center at x + size/2, cy,
+// a bar when nothing changed
+func drawDeltaArrow(c core.Canvas, x, cy, size float32, direction int, clr color.Color) {
+	if direction == 0 {
+		core.FillRect(c, x, cy-1, size, 2, clr)
+		return
+	}
+	tip, base := cy-size/2, cy+size/2
+	if direction < 0 {
+		tip, base = base, tip
+	}
+	var s core.Shape
+	s.MoveTo(x+size/2, tip)
+	s.LineTo(x+size, base)
+	s.LineTo(x, base)
+	s.Close()
+	c.Fill(&s, clr, true)
+}
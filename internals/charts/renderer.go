@@ -0,0 +1,164 @@
+package charts
+
+import (
+	"example.com/menu/internals/charts/core"
+	"example.com/menu/internals/textwrapper"
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Renderer draws the visible series of a model into the plot rectangle
+type Renderer interface {
+	Render(screen *ebiten.Image, plot *core.Plot, series []*core.Series)
+	// DrawSwatch draws the legend sample of a series into the given box
+	DrawSwatch(screen *ebiten.Image, s *core.Series, x, y, width, height float32)
+}
+
+// headlessRenderer is implemented by the renderers of this package, their core
+// renderer draws the same on any canvas
+type headlessRenderer interface {
+	headless() core.Renderer
+}
+
+// adapted lets the core layout draw with a Renderer. On screen every renderer
+// draws itself, elsewhere only the renderers of this package can draw.
+type adapted struct {
+	r Renderer
+}
+
+func adapt(r Renderer) core.Renderer {
+	return &adapted{r: r}
+}
+
+func (a *adapted) Draw(c core.Canvas, plot *core.Plot, series []*core.Series) {
+	if ic, ok := c.(*imageCanvas); ok {
+		a.r.Render(ic.dst, plot, series)
+	} else if hr, ok := a.r.(headlessRenderer); ok {
+		hr.headless().Draw(c, plot, series)
+	}
+}
+
+func (a *adapted) DrawSwatch(c core.Canvas, s *core.Series, x, y, width, height float32) {
+	if ic, ok := c.(*imageCanvas); ok {
+		a.r.DrawSwatch(ic.dst, s, x, y, width, height)
+	} else if hr, ok := a.r.(headlessRenderer); ok {
+		hr.headless().DrawSwatch(c, s, x, y, width, height)
+	}
+}
+
+func (a *adapted) AdjustRanges(m *core.Model, plot *core.Plot) {
+	if ra, ok := a.r.(core.RangeAdjuster); ok {
+		ra.AdjustRanges(m, plot)
+	}
+}
+
+func (a *adapted) HideAxes() bool {
+	h, ok := a.r.(core.AxesHider)
+	return ok && h.HideAxes()
+}
+
+// ---------------------
+
+// LineRenderer is core.LineRenderer on Ebiten images
+type LineRenderer core.LineRenderer
+
+func (r *LineRenderer) headless() core.Renderer {
+	return (*core.LineRenderer)(r)
+}
+
+func (r *LineRenderer) Render(screen *ebiten.Image, plot *core.Plot, series []*core.Series) {
+	r.headless().Draw(&imageCanvas{dst: screen}, plot, series)
+}
+
+func (r *LineRenderer) DrawSwatch(screen *ebiten.Image, s *core.Series, x, y, width, height float32) {
+	r.headless().DrawSwatch(&imageCanvas{dst: screen}, s, x, y, width, height)
+}
+
+// ---------------------
+
+// BarRenderer is core.BarRenderer on Ebiten images
+type BarRenderer core.BarRenderer
+
+func (r *BarRenderer) headless() core.Renderer {
+	return (*core.BarRenderer)(r)
+}
+
+func (r *BarRenderer) AdjustRanges(m *core.Model, plot *core.Plot) {
+	(*core.BarRenderer)(r).AdjustRanges(m, plot)
+}
+
+func (r *BarRenderer) Render(screen *ebiten.Image, plot *core.Plot, series []*core.Series) {
+	r.headless().Draw(&imageCanvas{dst: screen}, plot, series)
+}
+
+func (r *BarRenderer) DrawSwatch(screen *ebiten.Image, s *core.Series, x, y, width, height float32) {
+	r.headless().DrawSwatch(&imageCanvas{dst: screen}, s, x, y, width, height)
+}
+
+// ---------------------
+
+// AreaRenderer is core.AreaRenderer on Ebiten images
+type AreaRenderer core.AreaRenderer
+
+func (r *AreaRenderer) headless() core.Renderer {
+	return (*core.AreaRenderer)(r)
+}
+
+func (r *AreaRenderer) AdjustRanges(m *core.Model, plot *core.Plot) {
+	(*core.AreaRenderer)(r).AdjustRanges(m, plot)
+}
+
+func (r *AreaRenderer) Render(screen *ebiten.Image, plot *core.Plot, series []*core.Series) {
+	r.headless().Draw(&imageCanvas{dst: screen}, plot, series)
+}
+
+func (r *AreaRenderer) DrawSwatch(screen *ebiten.Image, s *core.Series, x, y, width, height float32) {
+	r.headless().DrawSwatch(&imageCanvas{dst: screen}, s, x, y, width, height)
+}
+
+// ---------------------
+
+// ScatterRenderer is core.ScatterRenderer on Ebiten images
+type ScatterRenderer core.ScatterRenderer
+
+func (r *ScatterRenderer) headless() core.Renderer {
+	return (*core.ScatterRenderer)(r)
+}
+
+func (r *ScatterRenderer) Render(screen *ebiten.Image, plot *core.Plot, series []*core.Series) {
+	r.headless().Draw(&imageCanvas{dst: screen}, plot, series)
+}
+
+func (r *ScatterRenderer) DrawSwatch(screen *ebiten.Image, s *core.Series, x, y, width, height float32) {
+	r.headless().DrawSwatch(&imageCanvas{dst: screen}, s, x, y, width, height)
+}
+
+// ---------------------
+
+// PieRenderer is core.PieRenderer on Ebiten images, the labels are written with its TextWrapper
+type PieRenderer struct {
+	// Hole is the inner radius of a donut as a fraction of the radius, 0 draws a pie
+	Hole float32
+	// Explode pulls slices out of the pie by some pixels, by series name
+	Explode map[string]float32
+	// TextWrapper draws the name and share of each slice next to it, nothing when nil
+	TextWrapper *textwrapper.TextWrapper
+	// MinLabelShare hides the labels of slices smaller than this fraction, 0 means 0.03
+	MinLabelShare float64
+}
+
+func (r *PieRenderer) HideAxes() bool {
+	return true
+}
+
+// headless labels the slices with the text of the canvas when the renderer has a TextWrapper
+func (r *PieRenderer) headless() core.Renderer {
+	return &core.PieRenderer{Hole: r.Hole, Explode: r.Explode, Labels: r.TextWrapper != nil, MinLabelShare: r.MinLabelShare}
+}
+
+func (r *PieRenderer) Render(screen *ebiten.Image, plot *core.Plot, series []*core.Series) {
+	r.headless().Draw(&imageCanvas{dst: screen, tw: r.TextWrapper}, plot, series)
+}
+
+func (r *PieRenderer) DrawSwatch(screen *ebiten.Image, s *core.Series, x, y, width, height float32) {
+	r.headless().DrawSwatch(&imageCanvas{dst: screen}, s, x, y, width, height)
+}
@@ -0,0 +1,158 @@
+package charts
+
+import (
+	"image/color"
+	"math"
+
+	"example.com/menu/internals/charts/core"
+	"example.com/menu/internals/utils"
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// SparklineMarkers picks the values a sparkline marks with a dot
+type SparklineMarkers int
+
+const (
+	MarkMin SparklineMarkers = 1 << iota
+	MarkMax
+	MarkLast
+)
+
+// Sparkline is a line or bar chart without axes, small enough for a line of text
+// or a tile. It fills its rectangle and is drawn by a LineRenderer or a BarRenderer
+// like every other chart.
+type Sparkline struct {
+	X, Y          float32
+	Width, Height float32
+	Values        []float64
+	// MaxValues keeps only the newest values when Append adds more, 0 keeps all
+	MaxValues int
+	// Bars draws a bar per value from 0 instead of a line
+	Bars bool
+	// Color of the line or the bars, nil is the first color of core.DefaultPalette
+	Color color.Color
+	// NegativeColor colors the bars below 0, nil is Color
+	NegativeColor color.Color
+	// LineWidth is 1.5 when 0
+	LineWidth float32
+	// Fill covers the space under the line
+	Fill    bool
+	Markers SparklineMarkers
+	// MinColor, MaxColor and LastColor color the markers, nil is red, green and Color
+	MinColor  color.Color
+	MaxColor  color.Color
+	LastColor color.Color
+	// Range is the y range, the zero value fits the values. A fixed range lets sparklines be compared.
+	Range core.AxisRange
+}
+
+func NewSparkline(x, y, width, height float32, values []float64) *Sparkline {
+	return &Sparkline{
+		X:       x,
+		Y:       y,
+		Width:   width,
+		Height:  height,
+		Values:  values,
+		Markers: MarkLast,
+	}
+}
+
+func (s *Sparkline) SetBounds(x, y, width, height float32) {
+	s.X, s.Y, s.Width, s.Height = x, y, width, height
+}
+
+func (s *Sparkline) Bounds() (float32, float32, float32, float32) {
+	return s.X, s.Y, s.Width, s.Height
+}
+
+// Append adds a value at the end and drops the oldest beyond MaxValues
+func (s *Sparkline) Append(v float64) {
+	s.Values = append(s.Values, v)
+	if s.MaxValues > 0 && len(s.Values) > s.MaxValues {
+		s.Values = append(s.Values[:0], s.Values[len(s.Values)-s.MaxValues:]...)
+	}
+}
+
+func (s *Sparkline) color() color.Color {
+	return utils.ColorOr(s.Color, core.DefaultPalette[0])
+}
+
+func (s *Sparkline) lineWidth() float32 {
+	if s.LineWidth <= 0 {
+		return 1.5
+	}
+	return s.LineWidth
+}
+
+func (s *Sparkline) markerSize() float32 {
+	return max(4, s.lineWidth()*2.5)
+}
+
+// extremes returns the index of the smallest, the largest and the last value, -1 without values
+func (s *Sparkline) extremes() (lo, hi, last int) {
+	lo, hi, last = -1, -1, -1
+	for i, v := range s.Values {
+		if math.IsNaN(v) {
+			continue
+		}
+		if lo < 0 || v < s.Values[lo] {
+			lo = i
+		}
+		if hi < 0 || v > s.Values[hi] {
+			hi = i
+		}
+		last = i
+	}
+	return lo, hi, last
+}
+
+func (s *Sparkline) Update(offsetX, offsetY float32, isAnimating bool) {}
+
+func (s *Sparkline) Draw(screen *ebiten.Image) {
+	s.draw(&imageCanvas{dst: screen}, s.X, s.Y, s.Width, s.Height)
+}
+
+func (s *Sparkline) draw(c core.Canvas, x, y, width, height float32) {
+	if len(s.Values) == 0 || width <= 0 || height <= 0 {
+		return
+	}
+	// The markers and the line width stay inside the rectangle
+	inset := s.lineWidth() / 2
+	if s.Markers != 0 {
+		inset = s.markerSize()/2 + 1
+	}
+	line := core.SeriesFromValues("", s.Values)
+	line.Color, line.LineWidth, line.Fill = s.color(), s.lineWidth(), s.Fill
+	m := &core.Model{Series: []*core.Series{line}, Y: s.Range}
+	plot := &core.Plot{X: float64(x + inset), Y: float64(y + inset), Width: float64(width - 2*inset), Height: float64(height - 2*inset)}
+	plot.XMin, plot.XMax, plot.YMin, plot.YMax = m.Ranges()
+
+	if s.Bars {
+		// Stacked bars of the positive and the negative values put both at full width
+		negative := &core.Series{Color: utils.ColorOr(s.NegativeColor, line.Color)}
+		for i, p := range line.Points {
+			if p.Y < 0 {
+				negative.Points = append(negative.Points, p)
+				line.Points[i].Y = math.NaN()
+			}
+		}
+		m.Series = append(m.Series, negative)
+		r := &core.BarRenderer{Stacked: true}
+		r.AdjustRanges(m, plot)
+		r.Draw(c, plot, m.Series)
+	} else {
+		(&core.LineRenderer{}).Draw(c, plot, m.Series)
+	}
+
+	lo, hi, last := s.extremes()
+	mark := func(flag SparklineMarkers, i int, clr color.Color) {
+		if s.Markers&flag == 0 || i < 0 {
+			return
+		}
+		px, py := plot.ToScreen(core.Point{X: float64(i), Y: s.Values[i]})
+		core.DrawMarker(c, core.MarkerCircle, px, py, s.markerSize(), clr)
+	}
+	mark(MarkMin, lo, utils.ColorOr(s.MinColor, color.RGBA{234, 67, 53, 255}))
+	mark(MarkMax, hi, utils.ColorOr(s.MaxColor, color.RGBA{52, 168, 83, 255}))
+	mark(MarkLast, last, utils.ColorOr(s.LastColor, s.color()))
+}
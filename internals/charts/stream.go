@@ -0,0 +1,364 @@
+package charts
+
+import (
+	"image"
+	"image/color"
+	"math"
+	"sort"
+	"sync"
+
+	"example.com/menu/internals/charts/axis"
+	"example.com/menu/internals/charts/core"
+	"example.com/menu/internals/textwrapper"
+	"example.com/menu/internals/utils"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+// Stream is a fixed-capacity ring buffer of timed values.
+// Append may be called from any goroutine, the oldest value is dropped when it is full.
+type Stream struct {
+	Name      string
+	Color     color.Color
+	LineWidth float32
+
+	mu     sync.Mutex
+	points []core.Point
+	start  int
+}
+
+func NewStream(name string, capacity int) *Stream {
+	return &Stream{Name: name, points: make([]core.Point, 0, max(capacity, 2))}
+}
+
+// Append adds the value v at time t, times are expected in increasing order
+func (s *Stream) Append(t, v float64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.points) < cap(s.points) {
+		s.points = append(s.points, core.Point{X: t, Y: v})
+		return
+	}
+	s.points[s.start] = core.Point{X: t, Y: v}
+	s.start = (s.start + 1) % len(s.points)
+}
+
+func (s *Stream) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.points)
+}
+
+func (s *Stream) Clear() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.points = s.points[:0]
+	s.start = 0
+}
+
+// Last returns the newest point, ok is false while the stream is empty
+func (s *Stream) Last() (p core.Point, ok bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.points) == 0 {
+		return core.Point{}, false
+	}
+	return s.at(len(s.points) - 1), true
+}
+
+// at returns the i-th oldest point, the caller holds the lock
+func (s *Stream) at(i int) core.Point {
+	return s.points[(s.start+i)%len(s.points)]
+}
+
+// Window appends the points between from and to to dst, with one more point
+// on each side so the line runs into the edges of the plot
+func (s *Stream) Window(dst []core.Point, from, to float64) []core.Point {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	n := len(s.points)
+	first := sort.Search(n, func(i int) bool { return s.at(i).X >= from })
+	last := sort.Search(n, func(i int) bool { return s.at(i).X > to })
+	for i := max(first-1, 0); i < min(last+1, n); i++ {
+		dst = append(dst, s.at(i))
+	}
+	return dst
+}
+
+// ---------------------
+
+// StreamChart scrolls the last Window seconds of its streams. The newest time of
+// all streams is the right edge, so the chart runs on the clock of the producers.
+type StreamChart struct {
+	X, Y          float32
+	Width, Height float32
+	Streams       []*Stream
+	// Window is the visible time span in seconds
+	Window float64
+	// AutoScale fits the y range to the visible values, YMin and YMax are the fixed range otherwise
+	AutoScale  bool
+	YMin, YMax float64
+	// Padding is the fraction of the value span added above and below when auto scaling
+	Padding float64
+	// Shrink is how small the visible values may get, as a fraction of the range, before the range shrinks.
+	// The range grows at once, so a noisy signal does not make the axis jump.
+	Shrink      float64
+	YFormat     axis.Formatter
+	NumXTicks   int
+	NumYTicks   int
+	Background  color.Color
+	AxisColor   color.Color
+	GridColor   color.Color
+	Legend      core.Legend
+	TextWrapper *textwrapper.TextWrapper
+
+	paused   bool
+	pauseEnd float64
+	end      float64
+	yMin     float64
+	yMax     float64
+	scaled   bool
+	windows  [][]core.Point
+	buffer   []core.Point
+}
+
+func NewStreamChart(x, y, width, height float32, window float64, tw *textwrapper.TextWrapper) *StreamChart {
+	return &StreamChart{
+		X:           x,
+		Y:           y,
+		Width:       width,
+		Height:      height,
+		Window:      window,
+		AutoScale:   true,
+		YMin:        0,
+		YMax:        1,
+		Padding:     0.1,
+		Shrink:      0.5,
+		NumXTicks:   6,
+		NumYTicks:   5,
+		Legend:      core.Legend{Visible: true, Position: core.LegendTopLeft},
+		TextWrapper: tw,
+	}
+}
+
+// AddStream creates a stream that keeps the last capacity values
+func (c *StreamChart) AddStream(name string, capacity int) *Stream {
+	s := NewStream(name, capacity)
+	s.Color = core.DefaultPalette[len(c.Streams)%len(core.DefaultPalette)]
+	c.Streams = append(c.Streams, s)
+	return s
+}
+
+// Pause freezes the view, the streams keep collecting values up to their capacity
+func (c *StreamChart) Pause() {
+	if !c.paused {
+		c.paused = true
+		c.pauseEnd = c.end
+	}
+}
+
+func (c *StreamChart) Resume() {
+	c.paused = false
+}
+
+func (c *StreamChart) TogglePause() {
+	if c.paused {
+		c.Resume()
+	} else {
+		c.Pause()
+	}
+}
+
+func (c *StreamChart) IsPaused() bool {
+	return c.paused
+}
+
+func (c *StreamChart) SetBounds(x, y, width, height float32) {
+	c.X, c.Y, c.Width, c.Height = x, y, width, height
+}
+
+// YRange returns the drawn value range
+func (c *StreamChart) YRange() (float64, float64) {
+	if !c.AutoScale || !c.scaled {
+		return c.YMin, c.YMax
+	}
+	return c.yMin, c.yMax
+}
+
+// Update copies the visible part of every stream and adjusts the auto scale
+func (c *StreamChart) Update(offsetX, offsetY float32, isAnimating bool) {
+	end := math.Inf(-1)
+	for _, s := range c.Streams {
+		if p, ok := s.Last(); ok {
+			end = math.Max(end, p.X)
+		}
+	}
+	if math.IsInf(end, -1) {
+		end = 0
+	}
+	c.end = end
+	if c.paused {
+		end = c.pauseEnd
+	}
+
+	if len(c.windows) != len(c.Streams) {
+		c.windows = make([][]core.Point, len(c.Streams))
+	}
+	lo, hi := math.Inf(1), math.Inf(-1)
+	for i, s := range c.Streams {
+		c.windows[i] = s.Window(c.windows[i][:0], end-c.Window, end)
+		for _, p := range c.windows[i] {
+			if p.X >= end-c.Window && p.X <= end && !math.IsNaN(p.Y) && !math.IsInf(p.Y, 0) {
+				lo, hi = math.Min(lo, p.Y), math.Max(hi, p.Y)
+			}
+		}
+	}
+	if c.AutoScale && lo <= hi {
+		c.autoScale(lo, hi)
+	}
+}
+
+// autoScale grows the range to the values at once and shrinks it only when they use less than Shrink of it
+func (c *StreamChart) autoScale(lo, hi float64) {
+	span := hi - lo
+	if span == 0 {
+		span = math.Max(math.Abs(hi), 1)
+	}
+	pad := span * c.Padding
+	if !c.scaled || lo < c.yMin || hi > c.yMax || span < c.Shrink*(c.yMax-c.yMin) {
+		c.yMin, c.yMax = lo-pad, hi+pad
+		if lo == hi {
+			c.yMin, c.yMax = lo-span/2, hi+span/2
+		}
+		c.scaled = true
+	}
+}
+
+func (c *StreamChart) plot() *core.Plot {
+	const left, right, top, bottom = 56, 12, 10, 26
+	yMin, yMax := c.YRange()
+	end := c.end
+	if c.paused {
+		end = c.pauseEnd
+	}
+	return &core.Plot{
+		X:      float64(c.X) + left,
+		Y:      float64(c.Y) + top,
+		Width:  math.Max(float64(c.Width)-left-right, 1),
+		Height: math.Max(float64(c.Height)-top-bottom, 1),
+		XMin:   end - c.Window,
+		XMax:   end,
+		YMin:   yMin,
+		YMax:   yMax,
+	}
+}
+
+func (c *StreamChart) Draw(screen *ebiten.Image) {
+	axisColor := utils.ColorOr(c.AxisColor, color.RGBA{200, 200, 200, 255})
+	gridColor := utils.ColorOr(c.GridColor, color.RGBA{255, 255, 255, 28})
+	if c.Background != nil {
+		vector.DrawFilledRect(screen, c.X, c.Y, c.Width, c.Height, c.Background, false)
+	}
+	plot := c.plot()
+	left, top := float32(plot.X), float32(plot.Y)
+	right, bottom := float32(plot.X+plot.Width), float32(plot.Y+plot.Height)
+
+	// Ticks count seconds back from the newest value
+	xTicks := (&axis.Linear{Minor: -1}).Ticks(-c.Window, 0, c.NumXTicks)
+	yTicks := (&axis.Linear{Format: c.YFormat, Minor: -1}).Ticks(plot.YMin, plot.YMax, c.NumYTicks)
+	tw := c.TextWrapper
+	for _, t := range xTicks {
+		x := float32(plot.ScreenX(plot.XMax + t.Value))
+		vector.StrokeLine(screen, x, top, x, bottom, 1, gridColor, false)
+		if tw != nil {
+			label := t.Label + "s"
+			w, _ := tw.MeasureText(label)
+			tw.DrawText(screen, label, float64(x)-w/2, float64(bottom)+6)
+		}
+	}
+	for _, t := range yTicks {
+		y := float32(plot.ScreenY(t.Value))
+		vector.StrokeLine(screen, left, y, right, y, 1, gridColor, false)
+		if tw != nil {
+			w, h := tw.MeasureText(t.Label)
+			tw.DrawText(screen, t.Label, float64(left)-6-w, float64(y)-h/2)
+		}
+	}
+	vector.StrokeLine(screen, left, top, left, bottom, 1, axisColor, false)
+	vector.StrokeLine(screen, left, bottom, right, bottom, 1, axisColor, false)
+
+	clip := screen.SubImage(image.Rect(int(left), int(top), int(math.Ceil(float64(right))), int(math.Ceil(float64(bottom))))).(*ebiten.Image)
+	legend := make([]*core.Series, 0, len(c.Streams))
+	for i, s := range c.Streams {
+		if i < len(c.windows) {
+			c.buffer = decimate(c.buffer[:0], c.windows[i], plot)
+			strokePoints(clip, plot, c.buffer, s.LineWidth, s.Color)
+		}
+		legend = append(legend, &core.Series{Name: s.Name, Color: s.Color, LineWidth: s.LineWidth})
+	}
+	c.Legend.Draw(&imageCanvas{dst: screen, tw: tw}, plot, legend, &core.LineRenderer{})
+
+	if c.paused && tw != nil {
+		w, _ := tw.MeasureText("PAUSED")
+		tw.DrawText(screen, "PAUSED", float64(right)-w-6, float64(top)+4)
+	}
+}
+
+// decimate keeps at most the first, lowest, highest and last point of every
+// pixel column, the line looks the same with a fraction of the segments
+func decimate(dst, points []core.Point, plot *core.Plot) []core.Point {
+	if len(points) <= int(plot.Width)*2 {
+		return append(dst, points...)
+	}
+	for i := 0; i < len(points); {
+		column := math.Floor(plot.ScreenX(points[i].X))
+		lo, hi, j := i, i, i+1
+		for ; j < len(points) && math.Floor(plot.ScreenX(points[j].X)) == column; j++ {
+			if points[j].Y < points[lo].Y {
+				lo = j
+			}
+			if points[j].Y > points[hi].Y {
+				hi = j
+			}
+		}
+		last := j - 1
+		// The extremes in the order they came, so the line does not run backwards
+		keep := []int{i, min(lo, hi), max(lo, hi), last}
+		for k, index := range keep {
+			if k == 0 || index != keep[k-1] {
+				dst = append(dst, points[index])
+			}
+		}
+		i = j
+	}
+	return dst
+}
+
+// strokePoints draws a polyline as a few batched paths instead of a call per segment
+func strokePoints(dst *ebiten.Image, plot *core.Plot, points []core.Point, width float32, clr color.Color) {
+	if width <= 0 {
+		width = 1
+	}
+	// Keep each path well below the vertex limit of one DrawTriangles call
+	const chunk = 2000
+	for start := 0; start < len(points)-1; start += chunk {
+		var path vector.Path
+		end := min(start+chunk+1, len(points))
+		gap := true
+		for i := start; i < end; i++ {
+			// A NaN value leaves a gap in the line
+			if math.IsNaN(points[i].Y) {
+				gap = true
+				continue
+			}
+			x, y := plot.ToScreen(points[i])
+			if gap {
+				path.MoveTo(x, y)
+			} else {
+				path.LineTo(x, y)
+			}
+			gap = false
+		}
+		strokePath(dst, &path, core.Stroke{Width: width, Antialias: true}, clr)
+	}
+}
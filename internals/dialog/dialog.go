@@ -0,0 +1,531 @@
+package dialog
+
+import (
+	"image/color"
+
+	"example.com/menu/internals/layout"
+	"example.com/menu/internals/overlay"
+	"example.com/menu/internals/textwrapper"
+	"example.com/menu/internals/utils"
+	"example.com/menu/internals/widgets"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+const defaultAnimationFrames = 12
+
+// breakpoints sizes the dialogs by the width of the area they open in
+var breakpoints = layout.NewLayoutSystem(nil)
+
+// Result is what a dialog gives back when it closes
+type Result struct {
+	Button   int    // index in Buttons of the pressed button, -1 when closed without one
+	Accepted bool   // the primary button closed the dialog
+	Value    string // text of the Prompt field
+}
+
+// Body is the custom content of a dialog, placed between the message and the buttons
+type Body interface {
+	Update(offsetX, offsetY float32, isAnimating bool)
+	Draw(screen *ebiten.Image)
+	SetBounds(x, y, width, height float32)
+	PreferredSize() (float32, float32)
+}
+
+// Dialog is a modal box with a title, a message, an optional field or body and a row of buttons.
+//
+// Enter presses the Primary button and Escape the Cancel one. The dialog sizes
+// itself by the breakpoint of the area it is drawn in: nearly full width with
+// stacked buttons on small areas, a centered box on large ones.
+type Dialog struct {
+	X, Y          float32
+	Width, Height float32
+
+	Title   string
+	Message string
+	Buttons []string
+	Primary int // pressed by Enter, -1 for none
+	Cancel  int // pressed by Escape, -1 closes without a button
+	Input   *widgets.TextInput
+	Body    Body
+
+	TextWrapper     *textwrapper.TextWrapper
+	FontSize        float64
+	FontColor       color.Color
+	BackgroundColor color.Color
+	ButtonColor     color.Color
+	PrimaryColor    color.Color
+	FocusColor      color.Color
+	// AnimationFrames is the length of the open and close animation, 0 to disable it
+	AnimationFrames int
+
+	OnResult func(Result)
+
+	buttons    []*widgets.ButtonStd
+	focused    int // index of the focused button, -1 when the field has the focus
+	lines      []string
+	stacked    bool
+	areaWidth  float32
+	areaHeight float32
+	canvas     *ebiten.Image
+
+	overlay   *overlay.Overlay
+	progress  int
+	closing   bool
+	result    Result
+	results   chan Result
+	delivered bool
+}
+
+// New returns a dialog with buttons from left to right. The last one is the
+// primary button and the first one cancels.
+func New(title, message string, tw *textwrapper.TextWrapper, buttons ...string) *Dialog {
+	d := &Dialog{
+		Title:           title,
+		Message:         message,
+		Buttons:         buttons,
+		Primary:         len(buttons) - 1,
+		Cancel:          0,
+		TextWrapper:     tw,
+		FontSize:        18,
+		AnimationFrames: defaultAnimationFrames,
+	}
+	if len(buttons) == 0 {
+		d.Cancel = -1
+	}
+	for i, label := range buttons {
+		index := i
+		d.buttons = append(d.buttons, widgets.NewButtonStd(0, 0, 0, 0, label, tw, color.White, nil, d.FontSize, func() {
+			d.press(index)
+		}))
+	}
+	return d
+}
+
+// NewAlert returns a dialog with a single OK button
+func NewAlert(title, message string, tw *textwrapper.TextWrapper) *Dialog {
+	return New(title, message, tw, "OK")
+}
+
+// NewConfirm returns a Cancel / OK dialog
+func NewConfirm(message string, tw *textwrapper.TextWrapper) *Dialog {
+	return New("", message, tw, "Cancel", "OK")
+}
+
+// NewPrompt returns a Cancel / OK dialog with a text field, OK needs a valid value
+func NewPrompt(message, placeholder string, tw *textwrapper.TextWrapper) *Dialog {
+	d := New("", message, tw, "Cancel", "OK")
+	d.Input = widgets.NewTextInput(0, 0, 0, 0, tw, d.FontSize, placeholder)
+	d.Input.OnSubmit = func(string) { d.press(d.Primary) }
+	return d
+}
+
+// ---------------------
+
+// Alert opens an alert in m, onClose runs when it is dismissed
+func Alert(m *overlay.Manager, tw *textwrapper.TextWrapper, title, message string, onClose func()) *Dialog {
+	d := NewAlert(title, message, tw)
+	d.OnResult = func(Result) {
+		if onClose != nil {
+			onClose()
+		}
+	}
+	return d.Open(m)
+}
+
+// Confirm opens a confirmation in m, onResult gets true for OK
+func Confirm(m *overlay.Manager, tw *textwrapper.TextWrapper, message string, onResult func(ok bool)) *Dialog {
+	d := NewConfirm(message, tw)
+	d.OnResult = func(r Result) {
+		if onResult != nil {
+			onResult(r.Accepted)
+		}
+	}
+	return d.Open(m)
+}
+
+// Prompt opens a prompt in m, onResult gets the value and true for OK
+func Prompt(m *overlay.Manager, tw *textwrapper.TextWrapper, message, placeholder string, onResult func(value string, ok bool)) *Dialog {
+	d := NewPrompt(message, placeholder, tw)
+	d.OnResult = func(r Result) {
+		if onResult != nil {
+			onResult(r.Value, r.Accepted)
+		}
+	}
+	return d.Open(m)
+}
+
+// ---------------------
+
+// Open shows the dialog as a modal of m. The result comes through OnResult and Done.
+func (d *Dialog) Open(m *overlay.Manager) *Dialog {
+	if d.overlay != nil && d.overlay.IsOpen() {
+		return d
+	}
+	d.progress = 0
+	d.closing = false
+	d.delivered = false
+	d.result = Result{Button: -1}
+	d.results = make(chan Result, 1)
+	d.areaWidth, d.areaHeight = 0, 0
+
+	d.overlay = overlay.New(overlay.Modal, d)
+	// Escape is handled by the dialog, to play the close animation
+	d.overlay.DismissOnEscape = false
+	d.overlay.Backdrop = color.Transparent
+	d.overlay.OnClose = d.deliver
+	m.Open(d.overlay)
+	return d
+}
+
+// Done returns a channel that receives the result once, when the dialog has closed
+func (d *Dialog) Done() <-chan Result {
+	return d.results
+}
+
+// IsOpen reports whether the dialog is shown, closing included
+func (d *Dialog) IsOpen() bool {
+	return d.overlay != nil && d.overlay.IsOpen()
+}
+
+// Close dismisses the dialog as if its Cancel button was pressed
+func (d *Dialog) Close() {
+	d.finish(d.Cancel)
+}
+
+// press runs a button, the primary one only closes a prompt with a valid value
+func (d *Dialog) press(index int) {
+	if index >= 0 && index == d.Primary && d.Input != nil && !d.Input.Valid() {
+		return
+	}
+	d.finish(index)
+}
+
+func (d *Dialog) finish(index int) {
+	if d.closing || !d.IsOpen() {
+		return
+	}
+	d.result = Result{Button: index, Accepted: index >= 0 && index == d.Primary}
+	if d.Input != nil {
+		d.result.Value = d.Input.Text()
+	}
+	d.closing = true
+	if d.AnimationFrames <= 0 {
+		d.overlay.Close()
+	}
+}
+
+// deliver sends the result once, also when the overlay is closed from outside (page switch)
+func (d *Dialog) deliver() {
+	if d.delivered {
+		return
+	}
+	d.delivered = true
+	d.results <- d.result
+	if d.OnResult != nil {
+		d.OnResult(d.result)
+	}
+}
+
+// openness is the eased animation state, 0 closed and 1 open
+func (d *Dialog) openness() float64 {
+	if d.AnimationFrames <= 0 {
+		return 1
+	}
+	t := float64(d.progress) / float64(d.AnimationFrames)
+	return 1 - (1-t)*(1-t)
+}
+
+// ---------------------
+
+// Focus is called by the overlay manager on open
+func (d *Dialog) Focus() {
+	if d.Input != nil {
+		d.Input.Focus()
+		d.focused = -1
+		return
+	}
+	d.focused = d.Primary
+}
+
+func (d *Dialog) Blur() {
+	if d.Input != nil {
+		d.Input.Blur()
+	}
+}
+
+func (d *Dialog) IsFocused() bool {
+	return d.IsOpen()
+}
+
+func (d *Dialog) Contains(x, y float32) bool {
+	return x >= d.X && x < d.X+d.Width && y >= d.Y && y < d.Y+d.Height
+}
+
+func (d *Dialog) Update(offsetX, offsetY float32, isAnimating bool) {
+	if d.closing {
+		d.progress--
+		if d.progress <= 0 {
+			d.overlay.Close()
+		}
+		return
+	}
+	if d.progress < d.AnimationFrames {
+		d.progress++
+	}
+	// No input before the first layout or while the dialog grows in
+	if isAnimating || d.areaWidth == 0 || d.progress < d.AnimationFrames {
+		return
+	}
+
+	d.handleKeyboard()
+	if d.closing {
+		return
+	}
+
+	if d.Input != nil {
+		d.Input.Update(offsetX, offsetY, false)
+		if d.Input.IsFocused() {
+			d.focused = -1
+		}
+	}
+	if d.Body != nil {
+		d.Body.Update(offsetX, offsetY, false)
+	}
+	for _, button := range d.buttons {
+		button.Update(offsetX, offsetY, false)
+	}
+}
+
+func (d *Dialog) handleKeyboard() {
+	inputFocused := d.Input != nil && d.Input.IsFocused()
+
+	if inpututil.IsKeyJustPressed(ebiten.KeyEscape) {
+		d.finish(d.Cancel)
+		return
+	}
+	// The field submits itself on Enter
+	if !inputFocused && (inpututil.IsKeyJustPressed(ebiten.KeyEnter) || inpututil.IsKeyJustPressed(ebiten.KeyNumpadEnter)) {
+		switch {
+		case d.focused >= 0:
+			d.press(d.focused)
+		case d.Primary >= 0:
+			d.press(d.Primary)
+		}
+		return
+	}
+
+	switch {
+	case inpututil.IsKeyJustPressed(ebiten.KeyTab):
+		if ebiten.IsKeyPressed(ebiten.KeyShift) {
+			d.moveFocus(-1)
+		} else {
+			d.moveFocus(1)
+		}
+	case !inputFocused && inpututil.IsKeyJustPressed(ebiten.KeyArrowLeft):
+		d.moveFocus(-1)
+	case !inputFocused && inpututil.IsKeyJustPressed(ebiten.KeyArrowRight):
+		d.moveFocus(1)
+	}
+}
+
+// moveFocus cycles through the field, when there is one, and the buttons
+func (d *Dialog) moveFocus(dir int) {
+	first := 0
+	if d.Input != nil {
+		first = -1
+	}
+	count := len(d.buttons) - first
+	if count <= 0 {
+		return
+	}
+	next := ((d.focused-first+dir)%count+count)%count + first
+	d.focused = next
+	if d.Input == nil {
+		return
+	}
+	if next == -1 {
+		d.Input.Focus()
+	} else {
+		d.Input.Blur()
+	}
+}
+
+// ---------------------
+
+// arrange sizes and places the dialog in an area of areaWidth x areaHeight
+func (d *Dialog) arrange(areaWidth, areaHeight float32) {
+	d.areaWidth, d.areaHeight = areaWidth, areaHeight
+
+	const margin = 16
+	switch breakpoints.DetermineBreakpoint(int(areaWidth), int(areaHeight)) {
+	case layout.Bp_ExtraSmall, layout.Bp_Small:
+		d.Width = areaWidth - 2*margin
+		d.stacked = true
+	case layout.Bp_Medium:
+		d.Width = areaWidth * 0.7
+		d.stacked = false
+	case layout.Bp_Large:
+		d.Width = areaWidth * 0.5
+		d.stacked = false
+	default:
+		d.Width = min(areaWidth*0.4, 600)
+		d.stacked = false
+	}
+
+	padding := float32(d.FontSize)
+	lineHeight := float32(d.FontSize) * 1.4
+	controlHeight := float32(d.FontSize) * 2.2
+	innerWidth := d.Width - 2*padding
+
+	d.TextWrapper.SetFontSize(d.FontSize)
+	d.lines = nil
+	if d.Message != "" {
+		d.lines = d.TextWrapper.Wrap(d.Message, float64(innerWidth))
+	}
+
+	height := padding
+	if d.Title != "" {
+		height += float32(d.titleSize()) * 1.6
+	}
+	height += lineHeight * float32(len(d.lines))
+	if d.Input != nil {
+		height += padding/2 + controlHeight
+	}
+	var bodyHeight float32
+	if d.Body != nil {
+		_, bodyHeight = d.Body.PreferredSize()
+		height += padding/2 + bodyHeight
+	}
+	buttonsHeight := controlHeight
+	if d.stacked && len(d.buttons) > 0 {
+		buttonsHeight = float32(len(d.buttons))*(controlHeight+padding/2) - padding/2
+	}
+	height += padding + buttonsHeight + padding
+
+	d.Height = min(height, areaHeight-2*margin)
+	d.X = (areaWidth - d.Width) / 2
+	d.Y = (areaHeight - d.Height) / 2
+
+	// Field and body go under the message, the buttons stay at the bottom
+	y := d.Y + padding + lineHeight*float32(len(d.lines))
+	if d.Title != "" {
+		y += float32(d.titleSize()) * 1.6
+	}
+	if d.Input != nil {
+		y += padding / 2
+		d.Input.X, d.Input.Y, d.Input.Width, d.Input.Height = d.X+padding, y, innerWidth, controlHeight
+		y += controlHeight
+	}
+	if d.Body != nil {
+		y += padding / 2
+		d.Body.SetBounds(d.X+padding, y, innerWidth, bodyHeight)
+	}
+	d.arrangeButtons(padding, controlHeight)
+}
+
+// arrangeButtons stacks the buttons, primary on top, or puts them in a right aligned row
+func (d *Dialog) arrangeButtons(padding, height float32) {
+	bottom := d.Y + d.Height - padding
+	if d.stacked {
+		y := bottom - height
+		for i := range d.buttons {
+			// The primary button is the last of the row, the first of the stack
+			button := d.buttons[i]
+			button.X, button.Y, button.Width, button.Height = d.X+padding, y, d.Width-2*padding, height
+			y -= height + padding/2
+		}
+		return
+	}
+
+	x := d.X + d.Width - padding
+	for i := len(d.buttons) - 1; i >= 0; i-- {
+		button := d.buttons[i]
+		d.TextWrapper.SetFontSize(d.FontSize)
+		textWidth, _ := d.TextWrapper.MeasureText(button.Text)
+		width := max(float32(textWidth)+2*padding, 90)
+		x -= width
+		button.X, button.Y, button.Width, button.Height = x, bottom-height, width, height
+		x -= padding / 2
+	}
+}
+
+func (d *Dialog) titleSize() float64 {
+	return d.FontSize * 1.3
+}
+
+// ---------------------
+
+func (d *Dialog) Draw(screen *ebiten.Image) {
+	bounds := screen.Bounds()
+	areaWidth, areaHeight := float32(bounds.Dx()), float32(bounds.Dy())
+	if areaWidth != d.areaWidth || areaHeight != d.areaHeight {
+		d.arrange(areaWidth, areaHeight)
+	}
+
+	t := d.openness()
+	d.overlay.Backdrop = color.RGBA{0, 0, 0, uint8(140 * t)}
+	if t >= 1 {
+		d.drawBox(screen)
+		return
+	}
+
+	// Grows and fades in from a slightly smaller box
+	if d.canvas == nil || d.canvas.Bounds().Dx() != bounds.Dx() || d.canvas.Bounds().Dy() != bounds.Dy() {
+		d.canvas = ebiten.NewImage(bounds.Dx(), bounds.Dy())
+	}
+	d.canvas.Clear()
+	d.drawBox(d.canvas)
+
+	scale := 0.9 + 0.1*t
+	centerX, centerY := float64(d.X+d.Width/2), float64(d.Y+d.Height/2)
+	op := &ebiten.DrawImageOptions{}
+	op.GeoM.Translate(-centerX, -centerY)
+	op.GeoM.Scale(scale, scale)
+	op.GeoM.Translate(centerX, centerY+(1-t)*20)
+	op.ColorScale.ScaleAlpha(float32(t))
+	screen.DrawImage(d.canvas, op)
+}
+
+func (d *Dialog) drawBox(screen *ebiten.Image) {
+	fontColor := utils.ColorOr(d.FontColor, color.Black)
+	buttonColor := utils.ColorOr(d.ButtonColor, color.RGBA{110, 110, 110, 255})
+	primaryColor := utils.ColorOr(d.PrimaryColor, color.RGBA{0, 120, 215, 255})
+	focusColor := utils.ColorOr(d.FocusColor, color.RGBA{0, 128, 255, 255})
+
+	vector.DrawFilledRect(screen, d.X, d.Y, d.Width, d.Height, utils.ColorOr(d.BackgroundColor, color.White), false)
+	vector.StrokeRect(screen, d.X, d.Y, d.Width, d.Height, 1, color.RGBA{120, 120, 120, 255}, false)
+
+	padding := float32(d.FontSize)
+	y := d.Y + padding
+	d.TextWrapper.SetColor(fontColor)
+	if d.Title != "" {
+		d.TextWrapper.SetFontSize(d.titleSize())
+		d.TextWrapper.DrawText(screen, d.Title, float64(d.X+padding), float64(y))
+		y += float32(d.titleSize()) * 1.6
+	}
+	d.TextWrapper.SetFontSize(d.FontSize)
+	for _, line := range d.lines {
+		d.TextWrapper.DrawText(screen, line, float64(d.X+padding), float64(y))
+		y += float32(d.FontSize) * 1.4
+	}
+
+	if d.Input != nil {
+		d.Input.Draw(screen)
+	}
+	if d.Body != nil {
+		d.Body.Draw(screen)
+	}
+	for i, button := range d.buttons {
+		button.FontSize = d.FontSize
+		button.BackgroundColor = buttonColor
+		if i == d.Primary {
+			button.BackgroundColor = primaryColor
+		}
+		button.Draw(screen)
+		if i == d.focused {
+			vector.StrokeRect(screen, button.X-3, button.Y-3, button.Width+6, button.Height+6, 2, focusColor, false)
+		}
+	}
+}
@@ -0,0 +1,128 @@
+package form
+
+import (
+	"fmt"
+
+	"example.com/menu/internals/widgets"
+)
+
+// Adapters for the widgets that do not implement Control themselves
+
+type textInputControl struct {
+	input *widgets.TextInput
+}
+
+// TextInputControl binds a TextInput, numbers are shown and parsed as text
+func TextInputControl(input *widgets.TextInput) Control {
+	return &textInputControl{input: input}
+}
+
+func (c *textInputControl) Value() any {
+	return c.input.Text()
+}
+
+func (c *textInputControl) SetValue(value any) {
+	c.input.SetText(fmt.Sprint(value))
+}
+
+type toggleControl struct {
+	toggle *widgets.ToggleButton04
+}
+
+// ToggleControl binds a ToggleButton04 to a bool field
+func ToggleControl(toggle *widgets.ToggleButton04) Control {
+	return &toggleControl{toggle: toggle}
+}
+
+func (c *toggleControl) Value() any {
+	return c.toggle.IsToggled
+}
+
+func (c *toggleControl) SetValue(value any) {
+	on, _ := value.(bool)
+	c.toggle.SetToggled(on)
+}
+
+type sliderControl struct {
+	slider *widgets.Slider
+}
+
+// SliderControl binds a Slider to a number field, Min and Max come from the slider
+func SliderControl(slider *widgets.Slider) Control {
+	return &sliderControl{slider: slider}
+}
+
+func (c *sliderControl) Value() any {
+	return c.slider.Value()
+}
+
+func (c *sliderControl) SetValue(value any) {
+	n, err := toFloat64(value)
+	if err != nil {
+		return
+	}
+	c.slider.SetValue(n)
+}
+
+type checkboxControl struct {
+	checkbox *widgets.Checkbox
+}
+
+// CheckboxControl binds a Checkbox to a bool field, Indeterminate reads as false
+func CheckboxControl(checkbox *widgets.Checkbox) Control {
+	return &checkboxControl{checkbox: checkbox}
+}
+
+func (c *checkboxControl) Value() any {
+	return c.checkbox.Checked()
+}
+
+func (c *checkboxControl) SetValue(value any) {
+	on, _ := value.(bool)
+	c.checkbox.SetChecked(on)
+}
+
+type selectionControl struct {
+	selection *widgets.Selection
+	values    []any
+}
+
+// SelectionControl binds the Selection of a RadioGroup or SegmentedControl to a field.
+// values[i] is the field value of option i, nil stands for no selection.
+func SelectionControl(selection *widgets.Selection, values ...any) Control {
+	return &selectionControl{selection: selection, values: values}
+}
+
+func (c *selectionControl) Value() any {
+	i := c.selection.SelectedIndex()
+	if i < 0 || i >= len(c.values) {
+		return nil
+	}
+	return c.values[i]
+}
+
+func (c *selectionControl) SetValue(value any) {
+	for i, v := range c.values {
+		if v == value {
+			c.selection.Select(i)
+			return
+		}
+	}
+}
+
+type dropdownControl struct {
+	dropdown *widgets.Dropdown
+}
+
+// DropdownControl binds a Dropdown to a string field
+func DropdownControl(dropdown *widgets.Dropdown) Control {
+	return &dropdownControl{dropdown: dropdown}
+}
+
+func (c *dropdownControl) Value() any {
+	return c.dropdown.Value()
+}
+
+func (c *dropdownControl) SetValue(value any) {
+	c.dropdown.SetValue(fmt.Sprint(value))
+}
@@ -0,0 +1,118 @@
+package form
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// convert turns a widget value into a value of type t.
+// Numbers convert between kinds, strings are parsed.
+func convert(value any, t reflect.Type) (any, error) {
+	if t == nil {
+		return value, nil
+	}
+	if value == nil {
+		return reflect.Zero(t).Interface(), nil
+	}
+	v := reflect.ValueOf(value)
+	if v.Type() == t {
+		return value, nil
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return reflect.ValueOf(fmt.Sprint(value)).Convert(t).Interface(), nil
+
+	case reflect.Bool:
+		if s, ok := value.(string); ok {
+			b, err := strconv.ParseBool(strings.TrimSpace(s))
+			if err != nil {
+				return nil, fmt.Errorf("%q is not true or false", s)
+			}
+			return reflect.ValueOf(b).Convert(t).Interface(), nil
+		}
+		if v.Kind() == reflect.Bool {
+			return v.Convert(t).Interface(), nil
+		}
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := toInt64(value)
+		if err != nil {
+			return nil, err
+		}
+		out := reflect.New(t).Elem()
+		if out.OverflowInt(n) {
+			return nil, fmt.Errorf("%d is out of range", n)
+		}
+		out.SetInt(n)
+		return out.Interface(), nil
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := toInt64(value)
+		if err != nil {
+			return nil, err
+		}
+		out := reflect.New(t).Elem()
+		if n < 0 || out.OverflowUint(uint64(n)) {
+			return nil, fmt.Errorf("%d is out of range", n)
+		}
+		out.SetUint(uint64(n))
+		return out.Interface(), nil
+
+	case reflect.Float32, reflect.Float64:
+		n, err := toFloat64(value)
+		if err != nil {
+			return nil, err
+		}
+		out := reflect.New(t).Elem()
+		out.SetFloat(n)
+		return out.Interface(), nil
+	}
+
+	if v.Type().ConvertibleTo(t) {
+		return v.Convert(t).Interface(), nil
+	}
+	return nil, fmt.Errorf("cannot use %T as %s", value, t)
+}
+
+func toInt64(value any) (int64, error) {
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int(), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return int64(v.Uint()), nil
+	case reflect.Float32, reflect.Float64:
+		// Sliders report floats, round them to the closest whole number
+		return int64(math.Round(v.Float())), nil
+	case reflect.String:
+		n, err := strconv.ParseInt(strings.TrimSpace(v.String()), 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("%q is not a whole number", v.String())
+		}
+		return n, nil
+	}
+	return 0, fmt.Errorf("cannot use %T as a number", value)
+}
+
+func toFloat64(value any) (float64, error) {
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(v.Uint()), nil
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), nil
+	case reflect.String:
+		n, err := strconv.ParseFloat(strings.TrimSpace(v.String()), 64)
+		if err != nil {
+			return 0, fmt.Errorf("%q is not a number", v.String())
+		}
+		return n, nil
+	}
+	return 0, fmt.Errorf("cannot use %T as a number", value)
+}
@@ -0,0 +1,171 @@
+package form
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// Field is one bound setting of a Form
+type Field struct {
+	Name string
+
+	form         *Form
+	typ          reflect.Type
+	get          func() any
+	set          func(any)
+	value        any // working value, always of the field type
+	applied      any // value last read from or written to the target
+	defaultValue any
+	validators   []func(value any) error
+	err          error
+	parseErr     error // the last widget or Set value did not convert to the field type
+
+	control     Control
+	lastControl any // last value read from the control, to see when the user changed it
+}
+
+// Value returns the working value
+func (fl *Field) Value() any {
+	return fl.value
+}
+
+// AppliedValue returns the value the target holds
+func (fl *Field) AppliedValue() any {
+	return fl.applied
+}
+
+func (fl *Field) DefaultValue() any {
+	return fl.defaultValue
+}
+
+func (fl *Field) IsDirty() bool {
+	return !reflect.DeepEqual(fl.value, fl.applied)
+}
+
+// Error returns the validation error of the field, nil when it is valid
+func (fl *Field) Error() error {
+	return fl.err
+}
+
+// Validate adds a validator that runs on every change and before Apply.
+// It returns the field so calls can be chained.
+func (fl *Field) Validate(validator func(value any) error) *Field {
+	fl.validators = append(fl.validators, validator)
+	return fl
+}
+
+// Set converts value to the field type and makes it the working value.
+// Strings are parsed for number and bool fields. A value that does not
+// convert is kept out of the working copy and reported as the field error.
+func (fl *Field) Set(value any) error {
+	converted, err := convert(value, fl.typ)
+	if err != nil {
+		fl.parseErr = err
+		fl.err = err
+		return &FieldError{Field: fl.Name, Err: err}
+	}
+	fl.setValue(converted)
+	fl.validate()
+	return fl.err
+}
+
+// setValue changes the working value and shows it in the bound control
+func (fl *Field) setValue(value any) {
+	fl.parseErr = nil
+	changed := !reflect.DeepEqual(fl.value, value)
+	fl.value = value
+	if fl.control != nil {
+		fl.control.SetValue(value)
+		fl.lastControl = fl.control.Value()
+	}
+	if changed && fl.form.OnChange != nil {
+		fl.form.OnChange(fl)
+	}
+}
+
+// BindControl binds a widget to the field, the widget shows the working value right away
+func (fl *Field) BindControl(control Control) *Field {
+	fl.control = control
+	control.SetValue(fl.value)
+	fl.lastControl = control.Value()
+	return fl
+}
+
+// pollControl copies a value the user entered in the widget into the working copy
+func (fl *Field) pollControl() {
+	if fl.control == nil {
+		return
+	}
+	current := fl.control.Value()
+	if reflect.DeepEqual(current, fl.lastControl) {
+		return
+	}
+	fl.lastControl = current
+
+	converted, err := convert(current, fl.typ)
+	if err != nil {
+		fl.parseErr = err
+		fl.err = err
+		return
+	}
+	fl.parseErr = nil
+	changed := !reflect.DeepEqual(fl.value, converted)
+	fl.value = converted
+	fl.validate()
+	if changed && fl.form.OnChange != nil {
+		fl.form.OnChange(fl)
+	}
+}
+
+func (fl *Field) validate() bool {
+	fl.err = fl.parseErr
+	if fl.err != nil {
+		return false
+	}
+	for _, validator := range fl.validators {
+		if err := validator(fl.value); err != nil {
+			fl.err = err
+			return false
+		}
+	}
+	return true
+}
+
+// ---------------------
+
+// Required rejects the zero value of the field type, e.g. an empty string
+func Required(message string) func(value any) error {
+	return func(value any) error {
+		if value == nil || reflect.ValueOf(value).IsZero() {
+			return errors.New(message)
+		}
+		return nil
+	}
+}
+
+// Range rejects numbers outside min and max, both included
+func Range(min, max float64) func(value any) error {
+	return func(value any) error {
+		n, err := toFloat64(value)
+		if err != nil {
+			return err
+		}
+		if n < min || n > max {
+			return fmt.Errorf("must be between %v and %v", min, max)
+		}
+		return nil
+	}
+}
+
+// OneOf rejects values that are not in the list
+func OneOf(allowed ...any) func(value any) error {
+	return func(value any) error {
+		for _, a := range allowed {
+			if reflect.DeepEqual(a, value) {
+				return nil
+			}
+		}
+		return fmt.Errorf("%v is not allowed", value)
+	}
+}
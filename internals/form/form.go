@@ -0,0 +1,372 @@
+package form
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Control is a widget a field can be bound to.
+// The form pushes values with SetValue and polls Value every Update.
+type Control interface {
+	Value() any
+	SetValue(value any)
+}
+
+// FieldError is a validation error attached to a field.
+// Cross-field validators return it to point at the field to fix.
+type FieldError struct {
+	Field string
+	Err   error
+}
+
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("%s: %v", e.Field, e.Err)
+}
+
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// ErrInvalid is returned by Apply and Submit when a validator failed
+var ErrInvalid = errors.New("form has invalid fields")
+
+// Form holds a working copy of settings, edited by widgets and written back on Apply.
+//
+// Fields come from the exported fields of a struct (see New) or from typed
+// accessors (see Bind). Every field keeps three values: the working value the
+// widgets edit, the applied value last written to the target, and a default.
+type Form struct {
+	fields     []*Field
+	byName     map[string]*Field
+	validators []func(values Values) error
+	formErr    error
+
+	// OnApply runs after the working values were written to the target
+	OnApply func()
+	// OnSubmit runs after Apply on Submit, e.g. to save the settings to disk
+	OnSubmit func() error
+	// OnChange runs when a field value changes, from a widget or from Set
+	OnChange func(field *Field)
+}
+
+// New builds a form over the exported fields of the struct target points to.
+// defaults is a struct of the same type, or nil to use the current values as defaults.
+//
+// A field is named after the Go field, or the `form:"name"` tag. `form:"-"` skips it.
+// Supported kinds are bool, string, and the int, uint and float kinds.
+func New(target any, defaults any) (*Form, error) {
+	targetValue := reflect.ValueOf(target)
+	if targetValue.Kind() != reflect.Pointer || targetValue.Elem().Kind() != reflect.Struct {
+		return nil, fmt.Errorf("form target must be a pointer to a struct, got %T", target)
+	}
+	structValue := targetValue.Elem()
+	structType := structValue.Type()
+
+	var defaultsValue reflect.Value
+	if defaults != nil {
+		defaultsValue = reflect.Indirect(reflect.ValueOf(defaults))
+		if defaultsValue.Type() != structType {
+			return nil, fmt.Errorf("form defaults must be a %s, got %T", structType, defaults)
+		}
+	}
+
+	f := NewEmpty()
+	for i := 0; i < structType.NumField(); i++ {
+		sf := structType.Field(i)
+		if !sf.IsExported() || !isSupportedKind(sf.Type.Kind()) {
+			continue
+		}
+		name := sf.Name
+		if tag, ok := sf.Tag.Lookup("form"); ok {
+			if tag == "-" {
+				continue
+			}
+			name = strings.Split(tag, ",")[0]
+		}
+
+		fieldValue := structValue.Field(i)
+		defaultValue := fieldValue.Interface()
+		if defaultsValue.IsValid() {
+			defaultValue = defaultsValue.Field(i).Interface()
+		}
+		f.addField(&Field{
+			Name:         name,
+			typ:          sf.Type,
+			get:          fieldValue.Interface,
+			set:          func(v any) { fieldValue.Set(reflect.ValueOf(v)) },
+			defaultValue: defaultValue,
+		})
+	}
+	return f, nil
+}
+
+// NewEmpty returns a form without fields, add them with Bind
+func NewEmpty() *Form {
+	return &Form{byName: map[string]*Field{}}
+}
+
+// Bind adds a field read and written through typed accessors,
+// for settings that do not live in a struct
+func Bind[T any](f *Form, name string, get func() T, set func(T), defaultValue T) *Field {
+	return f.addField(&Field{
+		Name:         name,
+		typ:          reflect.TypeOf(defaultValue),
+		get:          func() any { return get() },
+		set:          func(v any) { set(v.(T)) },
+		defaultValue: defaultValue,
+	})
+}
+
+func (f *Form) addField(field *Field) *Field {
+	field.form = f
+	field.applied = field.get()
+	field.value = field.applied
+	f.fields = append(f.fields, field)
+	f.byName[field.Name] = field
+	return field
+}
+
+func isSupportedKind(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Bool, reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+// Field returns the field with that name, nil when it does not exist
+func (f *Form) Field(name string) *Field {
+	return f.byName[name]
+}
+
+// Fields returns the fields in declaration order
+func (f *Form) Fields() []*Field {
+	return f.fields
+}
+
+// Get returns the working value of a field
+func (f *Form) Get(name string) any {
+	if field := f.byName[name]; field != nil {
+		return field.value
+	}
+	return nil
+}
+
+// Set changes the working value of a field, see Field.Set
+func (f *Form) Set(name string, value any) error {
+	field := f.byName[name]
+	if field == nil {
+		return fmt.Errorf("form has no field %q", name)
+	}
+	return field.Set(value)
+}
+
+// BindControl binds a widget to a field, the widget shows the working value right away
+func (f *Form) BindControl(name string, control Control) error {
+	field := f.byName[name]
+	if field == nil {
+		return fmt.Errorf("form has no field %q", name)
+	}
+	field.BindControl(control)
+	return nil
+}
+
+// AddValidator adds a cross-field validator. It sees every working value.
+// Return a *FieldError to attach the error to one field, any other error is shown for the form.
+func (f *Form) AddValidator(validator func(values Values) error) {
+	f.validators = append(f.validators, validator)
+}
+
+// Update reads the bound widgets, call it once per frame after the widgets updated
+func (f *Form) Update() {
+	for _, field := range f.fields {
+		field.pollControl()
+	}
+}
+
+// Values returns a snapshot of the working values
+func (f *Form) Values() Values {
+	values := make(Values, len(f.fields))
+	for _, field := range f.fields {
+		values[field.Name] = field.value
+	}
+	return values
+}
+
+// IsDirty reports whether any working value differs from the applied one
+func (f *Form) IsDirty() bool {
+	for _, field := range f.fields {
+		if field.IsDirty() {
+			return true
+		}
+	}
+	return false
+}
+
+// DirtyFields returns the names of the fields with unapplied changes
+func (f *Form) DirtyFields() []string {
+	var names []string
+	for _, field := range f.fields {
+		if field.IsDirty() {
+			names = append(names, field.Name)
+		}
+	}
+	return names
+}
+
+// Validate runs the field and cross-field validators and reports if everything passed
+func (f *Form) Validate() bool {
+	valid := true
+	for _, field := range f.fields {
+		if !field.validate() {
+			valid = false
+		}
+	}
+
+	f.formErr = nil
+	values := f.Values()
+	for _, validator := range f.validators {
+		err := validator(values)
+		if err == nil {
+			continue
+		}
+		valid = false
+		var fieldErr *FieldError
+		if errors.As(err, &fieldErr) {
+			if field := f.byName[fieldErr.Field]; field != nil {
+				if field.err == nil {
+					field.err = fieldErr.Err
+				}
+				continue
+			}
+		}
+		if f.formErr == nil {
+			f.formErr = err
+		}
+	}
+	return valid
+}
+
+// Error returns the form level error of the last validation
+func (f *Form) Error() error {
+	return f.formErr
+}
+
+// Errors returns the field errors of the last validation, by field name
+func (f *Form) Errors() map[string]error {
+	errs := map[string]error{}
+	for _, field := range f.fields {
+		if field.err != nil {
+			errs[field.Name] = field.err
+		}
+	}
+	return errs
+}
+
+// FirstError returns the first error in field order, or the form error, for a status line
+func (f *Form) FirstError() error {
+	for _, field := range f.fields {
+		if field.err != nil {
+			return &FieldError{Field: field.Name, Err: field.err}
+		}
+	}
+	return f.formErr
+}
+
+// Apply validates the working values and writes them to the target
+func (f *Form) Apply() error {
+	if !f.Validate() {
+		return ErrInvalid
+	}
+	for _, field := range f.fields {
+		if field.IsDirty() {
+			field.set(field.value)
+		}
+		field.applied = field.get()
+		field.value = field.applied
+	}
+	if f.OnApply != nil {
+		f.OnApply()
+	}
+	return nil
+}
+
+// Submit applies the form and then runs the OnSubmit hook
+func (f *Form) Submit() error {
+	if err := f.Apply(); err != nil {
+		return err
+	}
+	if f.OnSubmit != nil {
+		return f.OnSubmit()
+	}
+	return nil
+}
+
+// Revert drops the unapplied changes
+func (f *Form) Revert() {
+	for _, field := range f.fields {
+		field.setValue(field.applied)
+		field.err = nil
+	}
+	f.formErr = nil
+}
+
+// Reload reads the target again, for when it was changed outside of the form
+func (f *Form) Reload() {
+	for _, field := range f.fields {
+		field.applied = field.get()
+	}
+	f.Revert()
+}
+
+// ResetToDefaults puts the default values in the working copy.
+// Like any other edit they only reach the target on Apply.
+func (f *Form) ResetToDefaults() {
+	for _, field := range f.fields {
+		field.setValue(field.defaultValue)
+	}
+	f.Validate()
+}
+
+// ---------------------
+
+// Values is a snapshot of the working values, by field name
+type Values map[string]any
+
+func (v Values) Int(name string) int {
+	n, _ := toInt64(v[name])
+	return int(n)
+}
+
+func (v Values) Float(name string) float64 {
+	n, _ := toFloat64(v[name])
+	return n
+}
+
+func (v Values) Bool(name string) bool {
+	b, _ := v[name].(bool)
+	return b
+}
+
+func (v Values) String(name string) string {
+	if v[name] == nil {
+		return ""
+	}
+	return fmt.Sprint(v[name])
+}
+
+// Names returns the field names, sorted
+func (v Values) Names() []string {
+	names := make([]string, 0, len(v))
+	for name := range v {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
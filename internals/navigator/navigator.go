@@ -4,6 +4,7 @@ import (
 	"image"
 	"image/color"
 
+	"example.com/menu/internals/overlay"
 	"github.com/hajimehoshi/ebiten/v2"
 )
 
@@ -18,6 +19,8 @@ type Navigator struct {
 	Animating  bool
 	Transition float64
 	Direction  int
+	// Overlays is the window overlay stack, in screen coordinates above every page
+	Overlays *overlay.Manager
 }
 
 func NewNavigator() *Navigator {
@@ -25,10 +28,22 @@ func NewNavigator() *Navigator {
 		Stack:      []Page{},
 		Animating:  false,
 		Transition: 1.0,
+		Overlays:   overlay.NewManager(),
 	}
 }
 
+// leavePage closes the overlays that belong to the page going away
+func (n *Navigator) leavePage(page Page) {
+	if host, ok := page.(overlay.Host); ok {
+		host.Overlays().CloseOnPageSwitch()
+	}
+	n.Overlays.CloseOnPageSwitch()
+}
+
 func (n *Navigator) Push(nav Page) {
+	if current := n.CurrentPage(); current != nil {
+		n.leavePage(current)
+	}
 	if len(n.Stack) > 0 {
 		n.Animating = true
 		n.Transition = 0.0
@@ -39,6 +54,7 @@ func (n *Navigator) Push(nav Page) {
 
 func (n *Navigator) Pop() {
 	if len(n.Stack) > 1 && !n.Animating {
+		n.leavePage(n.CurrentPage())
 		n.Animating = true
 		n.Transition = 0.0
 		n.Direction = -1
@@ -62,8 +78,11 @@ func (n *Navigator) Update(navigatorOffsetX, navigatorOffsetY float32) (bool, er
 		}
 	}
 
+	// Window overlays are in screen coordinates, they block the page when they use the input
+	blocked := n.Overlays.Update(0, 0, n.Animating)
+
 	currentPage := n.Stack[len(n.Stack)-1]
-	err := currentPage.Update(navigatorOffsetX, navigatorOffsetY, n.Animating)
+	err := currentPage.Update(navigatorOffsetX, navigatorOffsetY, n.Animating || blocked)
 	return n.Animating, err
 }
 
@@ -105,6 +124,8 @@ func (n *Navigator) Draw(screen *ebiten.Image, navigatorAreaRect image.Rectangle
 	op := &ebiten.DrawImageOptions{}
 	op.GeoM.Translate(float64(navigatorAreaRect.Min.X), float64(navigatorAreaRect.Min.Y))
 	screen.DrawImage(navigatorArea, op)
+
+	n.Overlays.Draw(screen)
 }
 
 func (n *Navigator) CurrentPage() Page {
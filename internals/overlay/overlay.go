@@ -0,0 +1,343 @@
+package overlay
+
+import (
+	"image/color"
+	"sort"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+type Kind int
+
+const (
+	Modal   Kind = iota // draws a backdrop and blocks the input of everything beneath
+	Popup               // non modal, e.g. a menu; closes on a click outside by default
+	Tooltip             // never takes input
+	Toast               // above everything, only takes clicks inside it
+)
+
+// Default layers: tooltips and toasts stay above modals and popups whatever the open order
+const (
+	LayerDefault = 0
+	LayerTooltip = 100
+	LayerToast   = 200
+)
+
+// Content is what an overlay shows. It has the Update and Draw of page.UIElement,
+// and Contains to tell clicks inside from clicks outside.
+type Content interface {
+	Update(offsetX, offsetY float32, isAnimating bool)
+	Draw(screen *ebiten.Image)
+	Contains(x, y float32) bool
+}
+
+// Focusable is a widget that can have the keyboard focus, e.g. widgets.TextInput
+type Focusable interface {
+	Focus()
+	Blur()
+	IsFocused() bool
+}
+
+// Overlay is one entry of the Manager stack
+type Overlay struct {
+	Kind    Kind
+	Content Content
+	// Layer orders overlays, higher is drawn above. Inside a layer the last opened is on top.
+	Layer int
+
+	DismissOnOutsideClick bool
+	DismissOnEscape       bool
+	// Persistent overlays stay open when the navigator switches pages, e.g. toasts
+	Persistent bool
+	// Backdrop is drawn under a modal over the whole screen, nil for the default dim
+	Backdrop color.Color
+
+	// OnClose runs once when the overlay closes, for any reason
+	OnClose func()
+
+	manager      *Manager
+	order        int
+	restoreFocus Focusable
+}
+
+// New returns an overlay with the defaults of its kind
+func New(kind Kind, content Content) *Overlay {
+	o := &Overlay{Kind: kind, Content: content}
+	switch kind {
+	case Modal:
+		o.DismissOnEscape = true
+	case Popup:
+		o.DismissOnOutsideClick = true
+		o.DismissOnEscape = true
+	case Tooltip:
+		o.Layer = LayerTooltip
+	case Toast:
+		o.Layer = LayerToast
+	}
+	return o
+}
+
+// IsOpen reports whether the overlay is in a manager stack
+func (o *Overlay) IsOpen() bool {
+	return o.manager != nil
+}
+
+// Close removes the overlay from its manager
+func (o *Overlay) Close() {
+	if o.manager != nil {
+		o.manager.Close(o)
+	}
+}
+
+// takesFocus reports whether the overlay moves the keyboard focus away from the page
+func (o *Overlay) takesFocus() bool {
+	return o.Kind == Modal || o.Kind == Popup
+}
+
+// takesEscape reports whether the Escape key stops at the overlay. Tooltips, toasts
+// and overlays Escape does not close pass it down, a modal always keeps it.
+func (o *Overlay) takesEscape() bool {
+	if o.Kind == Tooltip || o.Kind == Toast {
+		return false
+	}
+	return o.Kind == Modal || o.DismissOnEscape
+}
+
+// ---------------------
+
+// Manager is the stack of overlays of a window or a page.
+//
+// Call Update before updating what is beneath: when it reports blocked, the
+// page must not react to input this frame. Call Draw after drawing the page.
+type Manager struct {
+	overlays []*Overlay
+	nextID   int
+
+	// FocusTarget returns the focused widget of the page, it gets the focus back
+	// when the modal or popup opened over it closes
+	FocusTarget func() Focusable
+}
+
+func NewManager() *Manager {
+	return &Manager{}
+}
+
+// Open pushes an overlay on top of its layer
+func (m *Manager) Open(o *Overlay) {
+	if o.manager == m {
+		m.BringToFront(o)
+		return
+	}
+	if o.manager != nil {
+		o.manager.remove(o)
+	}
+	o.manager = m
+	m.nextID++
+	o.order = m.nextID
+	o.restoreFocus = nil
+
+	if o.takesFocus() && m.FocusTarget != nil {
+		if focused := m.FocusTarget(); focused != nil && focused.IsFocused() {
+			focused.Blur()
+			o.restoreFocus = focused
+		}
+	}
+	if focusable, ok := o.Content.(Focusable); ok && o.takesFocus() {
+		focusable.Focus()
+	}
+
+	m.overlays = append(m.overlays, o)
+	m.sort()
+}
+
+// Close removes an overlay and gives the focus back to what had it before
+func (m *Manager) Close(o *Overlay) {
+	if o.manager != m || !m.remove(o) {
+		return
+	}
+	if focusable, ok := o.Content.(Focusable); ok {
+		focusable.Blur()
+	}
+	if o.restoreFocus != nil {
+		o.restoreFocus.Focus()
+		o.restoreFocus = nil
+	}
+	if o.OnClose != nil {
+		o.OnClose()
+	}
+}
+
+func (m *Manager) remove(o *Overlay) bool {
+	for i, open := range m.overlays {
+		if open == o {
+			m.overlays = append(m.overlays[:i], m.overlays[i+1:]...)
+			o.manager = nil
+			return true
+		}
+	}
+	return false
+}
+
+// CloseAll closes every overlay, top first
+func (m *Manager) CloseAll() {
+	for len(m.overlays) > 0 {
+		m.Close(m.overlays[len(m.overlays)-1])
+	}
+}
+
+// CloseOnPageSwitch closes every overlay that is not Persistent, the navigators call it
+func (m *Manager) CloseOnPageSwitch() {
+	for i := len(m.overlays) - 1; i >= 0; i-- {
+		if i < len(m.overlays) && !m.overlays[i].Persistent {
+			m.Close(m.overlays[i])
+		}
+	}
+}
+
+// BringToFront moves an overlay to the top of its layer
+func (m *Manager) BringToFront(o *Overlay) {
+	if o.manager != m {
+		return
+	}
+	m.nextID++
+	o.order = m.nextID
+	m.sort()
+}
+
+func (m *Manager) sort() {
+	sort.SliceStable(m.overlays, func(i, j int) bool {
+		a, b := m.overlays[i], m.overlays[j]
+		if a.Layer != b.Layer {
+			return a.Layer < b.Layer
+		}
+		return a.order < b.order
+	})
+}
+
+// Overlays returns the open overlays, bottom first
+func (m *Manager) Overlays() []*Overlay {
+	return m.overlays
+}
+
+// Top returns the overlay drawn above the others, nil when none is open
+func (m *Manager) Top() *Overlay {
+	if len(m.overlays) == 0 {
+		return nil
+	}
+	return m.overlays[len(m.overlays)-1]
+}
+
+// HasModal reports whether a modal is open, the page beneath is then blocked
+func (m *Manager) HasModal() bool {
+	for _, o := range m.overlays {
+		if o.Kind == Modal {
+			return true
+		}
+	}
+	return false
+}
+
+// HasPopup reports whether a popup is open, e.g. to hide tooltips it would cover
+func (m *Manager) HasPopup() bool {
+	for _, o := range m.overlays {
+		if o.Kind == Popup {
+			return true
+		}
+	}
+	return false
+}
+
+// ---------------------
+
+// Update routes the input to the overlays and updates them. It reports whether
+// the input beneath is blocked this frame: a modal is open, or an overlay used
+// the click or the Escape key. offsetX and offsetY are the page offsets, as for page.UIElement.
+func (m *Manager) Update(offsetX, offsetY float32, isAnimating bool) (blocked bool) {
+	if len(m.overlays) == 0 {
+		return false
+	}
+
+	x, y := ebiten.CursorPosition()
+	localX := float32(x) - offsetX
+	localY := float32(y) - offsetY
+
+	if !isAnimating && inpututil.IsKeyJustPressed(ebiten.KeyEscape) {
+		for i := len(m.overlays) - 1; i >= 0; i-- {
+			o := m.overlays[i]
+			if !o.takesEscape() {
+				continue
+			}
+			if o.DismissOnEscape {
+				m.Close(o)
+				blocked = true
+			}
+			// Escape goes to the top overlay that takes it only
+			break
+		}
+	}
+
+	// inputTop is the lowest overlay that still gets input, nothing under a modal does
+	inputTop := 0
+	if !isAnimating && inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+		for i := len(m.overlays) - 1; i >= 0; i-- {
+			o := m.overlays[i]
+			if o.Kind == Tooltip {
+				continue
+			}
+			if o.Content.Contains(localX, localY) {
+				blocked = true
+				break
+			}
+			if o.DismissOnOutsideClick {
+				m.Close(o)
+				blocked = true
+				if o.Kind == Modal {
+					break
+				}
+				continue
+			}
+			if o.Kind == Modal {
+				blocked = true
+				break
+			}
+		}
+	}
+	for i, o := range m.overlays {
+		if o.Kind == Modal {
+			inputTop = i
+		}
+	}
+
+	// A copy: contents may open or close overlays while they update
+	overlays := append([]*Overlay(nil), m.overlays...)
+	for i, o := range overlays {
+		frozen := isAnimating || i < inputTop
+		o.Content.Update(offsetX, offsetY, frozen)
+	}
+
+	return blocked || m.HasModal()
+}
+
+// Draw draws the overlays bottom first, with the backdrop under each modal
+func (m *Manager) Draw(screen *ebiten.Image) {
+	bounds := screen.Bounds()
+	for _, o := range m.overlays {
+		if o.Kind == Modal {
+			backdrop := o.Backdrop
+			if backdrop == nil {
+				backdrop = color.RGBA{0, 0, 0, 140}
+			}
+			vector.DrawFilledRect(screen, float32(bounds.Min.X), float32(bounds.Min.Y), float32(bounds.Dx()), float32(bounds.Dy()), backdrop, false)
+		}
+		o.Content.Draw(screen)
+	}
+}
+
+// ---------------------
+
+// Host is a page with its own overlays, the navigators close them on a page switch
+type Host interface {
+	Overlays() *Manager
+}
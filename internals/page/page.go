@@ -3,7 +3,10 @@ package page
 import (
 	"image/color"
 
+	"example.com/menu/internals/dialog"
+	"example.com/menu/internals/overlay"
 	"example.com/menu/internals/textwrapper"
+	"example.com/menu/internals/tooltip"
 	"example.com/menu/internals/widgets"
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
@@ -42,6 +45,8 @@ type BasePage struct {
 	DrawCustom           func(screen *ebiten.Image)
 	DrawBackgroundCustom func(screen *ebiten.Image)
 	DrawUIElementsCustom func(screen *ebiten.Image)
+	overlays             *overlay.Manager
+	tooltips             *tooltip.Manager
 }
 
 func NewBasePage(
@@ -65,8 +70,70 @@ func NewBasePage(
 }
 
 func (p *BasePage) Update(navigatorOffsetX, navigatorOffsetY float32, isAnimating bool) error {
+	offsetX, offsetY := navigatorOffsetX+p.X, navigatorOffsetY+p.Y
+
+	// Overlays go first, a modal or a used click freezes the elements beneath
+	if p.overlays != nil && p.overlays.Update(offsetX, offsetY, isAnimating) {
+		isAnimating = true
+	}
+
+	if p.tooltips != nil {
+		p.tooltips.Update(offsetX, offsetY, isAnimating)
+	}
 	for _, element := range p.UiElements {
-		element.Update(navigatorOffsetX+p.X, navigatorOffsetY+p.Y, isAnimating)
+		element.Update(offsetX, offsetY, isAnimating)
+	}
+	return nil
+}
+
+// Overlays returns the overlay stack of the page, drawn in PageArea above the elements.
+// The focused element gets the focus back when a modal or popup closes.
+func (p *BasePage) Overlays() *overlay.Manager {
+	if p.overlays == nil {
+		p.overlays = overlay.NewManager()
+		p.overlays.FocusTarget = p.focusedElement
+	}
+	return p.overlays
+}
+
+// Tooltips returns the tooltips of the page, shown in its overlay stack
+func (p *BasePage) Tooltips() *tooltip.Manager {
+	if p.tooltips == nil {
+		p.tooltips = tooltip.NewManager(p.Overlays(), p.TextWrapper)
+	}
+	return p.tooltips
+}
+
+// SetTooltip gives an element of the page a tooltip, e.g. p.SetTooltip(button, "Saves the game")
+func (p *BasePage) SetTooltip(element tooltip.Bounded, text string) *tooltip.Target {
+	return p.Tooltips().Attach(element, text)
+}
+
+// Alert opens a message box over the page, onClose may be nil
+func (p *BasePage) Alert(title, message string, onClose func()) *dialog.Dialog {
+	return dialog.Alert(p.Overlays(), p.TextWrapper, title, message, onClose)
+}
+
+// Confirm asks a yes / no question over the page, e.g. Confirm("Quit to menu?", ...)
+func (p *BasePage) Confirm(message string, onResult func(ok bool)) *dialog.Dialog {
+	return dialog.Confirm(p.Overlays(), p.TextWrapper, message, onResult)
+}
+
+// Prompt asks for a line of text over the page
+func (p *BasePage) Prompt(message, placeholder string, onResult func(value string, ok bool)) *dialog.Dialog {
+	return dialog.Prompt(p.Overlays(), p.TextWrapper, message, placeholder, onResult)
+}
+
+// ShowDialog opens a custom dialog over the page, its result comes through Done or OnResult
+func (p *BasePage) ShowDialog(d *dialog.Dialog) *dialog.Dialog {
+	return d.Open(p.Overlays())
+}
+
+func (p *BasePage) focusedElement() overlay.Focusable {
+	for _, element := range p.UiElements {
+		if focusable, ok := element.(overlay.Focusable); ok && focusable.IsFocused() {
+			return focusable
+		}
 	}
 	return nil
 }
@@ -79,6 +146,9 @@ func (p *BasePage) Draw(navigatorArea *ebiten.Image, offsetX, offsetY float64) {
 	if p.DrawCustom != nil {
 		p.DrawCustom(p.PageArea)
 	}
+	if p.overlays != nil {
+		p.overlays.Draw(p.PageArea)
+	}
 
 	op := &ebiten.DrawImageOptions{}
 	op.GeoM.Translate(float64(p.X)+offsetX, float64(p.Y)+offsetY)
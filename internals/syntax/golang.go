@@ -0,0 +1,113 @@
+package syntax
+
+import "strings"
+
+const (
+	goStateNone = iota
+	goStateBlockComment
+	goStateRawString
+)
+
+var goKeywords = map[string]bool{
+	"break": true, "case": true, "chan": true, "const": true, "continue": true,
+	"default": true, "defer": true, "else": true, "fallthrough": true, "for": true,
+	"func": true, "go": true, "goto": true, "if": true, "import": true,
+	"interface": true, "map": true, "package": true, "range": true, "return": true,
+	"select": true, "struct": true, "switch": true, "type": true, "var": true,
+}
+
+var goTypes = map[string]bool{
+	"any": true, "bool": true, "byte": true, "complex64": true, "complex128": true,
+	"error": true, "float32": true, "float64": true, "int": true, "int8": true,
+	"int16": true, "int32": true, "int64": true, "rune": true, "string": true,
+	"uint": true, "uint8": true, "uint16": true, "uint32": true, "uint64": true,
+	"uintptr": true,
+}
+
+var goLiterals = map[string]bool{
+	"true": true, "false": true, "nil": true, "iota": true,
+}
+
+type GoLexer struct{}
+
+func NewGoLexer() *GoLexer {
+	return &GoLexer{}
+}
+
+func (l *GoLexer) TokenizeLine(line string, state int) ([]Token, int) {
+	var tokens []Token
+	i := 0
+
+	// Continue what the previous line left open
+	switch state {
+	case goStateBlockComment:
+		end := strings.Index(line, "*/")
+		if end < 0 {
+			return []Token{{Start: 0, End: len(line), Kind: TokenComment}}, goStateBlockComment
+		}
+		i = end + 2
+		tokens = append(tokens, Token{Start: 0, End: i, Kind: TokenComment})
+	case goStateRawString:
+		end := strings.IndexByte(line, '`')
+		if end < 0 {
+			return []Token{{Start: 0, End: len(line), Kind: TokenString}}, goStateRawString
+		}
+		i = end + 1
+		tokens = append(tokens, Token{Start: 0, End: i, Kind: TokenString})
+	}
+
+	for i < len(line) {
+		c := line[i]
+		switch {
+		case isSpace(c):
+			i++
+		case strings.HasPrefix(line[i:], "//"):
+			tokens = append(tokens, Token{Start: i, End: len(line), Kind: TokenComment})
+			return tokens, goStateNone
+		case strings.HasPrefix(line[i:], "/*"):
+			end := strings.Index(line[i+2:], "*/")
+			if end < 0 {
+				tokens = append(tokens, Token{Start: i, End: len(line), Kind: TokenComment})
+				return tokens, goStateBlockComment
+			}
+			end += i + 4
+			tokens = append(tokens, Token{Start: i, End: end, Kind: TokenComment})
+			i = end
+		case c == '`':
+			end := strings.IndexByte(line[i+1:], '`')
+			if end < 0 {
+				tokens = append(tokens, Token{Start: i, End: len(line), Kind: TokenString})
+				return tokens, goStateRawString
+			}
+			end += i + 2
+			tokens = append(tokens, Token{Start: i, End: end, Kind: TokenString})
+			i = end
+		case c == '"' || c == '\'':
+			end := scanQuoted(line, i)
+			tokens = append(tokens, Token{Start: i, End: end, Kind: TokenString})
+			i = end
+		case isDigit(c) || (c == '.' && i+1 < len(line) && isDigit(line[i+1])):
+			end := scanNumber(line, i)
+			tokens = append(tokens, Token{Start: i, End: end, Kind: TokenNumber})
+			i = end
+		case isIdentStart(c):
+			end := scanIdent(line, i)
+			word := line[i:end]
+			switch {
+			case goKeywords[word]:
+				tokens = append(tokens, Token{Start: i, End: end, Kind: TokenKeyword})
+			case goTypes[word]:
+				tokens = append(tokens, Token{Start: i, End: end, Kind: TokenType})
+			case goLiterals[word]:
+				tokens = append(tokens, Token{Start: i, End: end, Kind: TokenLiteral})
+			}
+			i = end
+		case strings.IndexByte("+-*/%&|^<>=!:.,;(){}[]~", c) >= 0:
+			tokens = append(tokens, Token{Start: i, End: i + 1, Kind: TokenPunctuation})
+			i++
+		default:
+			i++
+		}
+	}
+	return tokens, goStateNone
+}
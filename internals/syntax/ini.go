@@ -0,0 +1,86 @@
+package syntax
+
+import "strings"
+
+type INILexer struct{}
+
+func NewINILexer() *INILexer {
+	return &INILexer{}
+}
+
+func (l *INILexer) TokenizeLine(line string, state int) ([]Token, int) {
+	var tokens []Token
+
+	start := len(line) - len(strings.TrimLeft(line, " \t"))
+	if start == len(line) {
+		return nil, 0
+	}
+
+	switch line[start] {
+	case ';', '#':
+		return []Token{{Start: start, End: len(line), Kind: TokenComment}}, 0
+	case '[':
+		end := strings.IndexByte(line[start:], ']')
+		if end < 0 {
+			end = len(line)
+		} else {
+			end += start + 1
+		}
+		return []Token{{Start: start, End: end, Kind: TokenSection}}, 0
+	}
+
+	sep := strings.IndexAny(line, "=:")
+	if sep < 0 {
+		return nil, 0
+	}
+
+	keyEnd := start + len(strings.TrimRight(line[start:sep], " \t"))
+	if keyEnd > start {
+		tokens = append(tokens, Token{Start: start, End: keyEnd, Kind: TokenKey})
+	}
+	tokens = append(tokens, Token{Start: sep, End: sep + 1, Kind: TokenPunctuation})
+
+	valueStart := sep + 1
+	for valueStart < len(line) && isSpace(line[valueStart]) {
+		valueStart++
+	}
+	if valueStart >= len(line) {
+		return tokens, 0
+	}
+
+	// Trailing comments after the value
+	valueEnd := len(line)
+	if idx := strings.IndexAny(line[valueStart:], ";#"); idx >= 0 && line[valueStart] != '"' {
+		valueEnd = valueStart + idx
+		tokens = append(tokens, Token{Start: valueEnd, End: len(line), Kind: TokenComment})
+	}
+	value := strings.TrimRight(line[valueStart:valueEnd], " \t")
+	if value == "" {
+		return tokens, 0
+	}
+
+	var kind TokenKind
+	switch {
+	case value[0] == '"' || value[0] == '\'':
+		kind = TokenString
+	case scanNumber(value, 0) == len(value):
+		kind = TokenNumber
+	default:
+		switch strings.ToLower(value) {
+		case "true", "false", "yes", "no", "on", "off":
+			kind = TokenLiteral
+		default:
+			kind = TokenText
+		}
+	}
+	valueToken := Token{Start: valueStart, End: valueStart + len(value), Kind: kind}
+
+	// Keep the tokens ordered by position
+	if len(tokens) > 0 && tokens[len(tokens)-1].Kind == TokenComment && tokens[len(tokens)-1].Start > valueStart {
+		comment := tokens[len(tokens)-1]
+		tokens = append(tokens[:len(tokens)-1], valueToken, comment)
+	} else {
+		tokens = append(tokens, valueToken)
+	}
+	return tokens, 0
+}
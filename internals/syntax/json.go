@@ -0,0 +1,50 @@
+package syntax
+
+import "strings"
+
+type JSONLexer struct{}
+
+func NewJSONLexer() *JSONLexer {
+	return &JSONLexer{}
+}
+
+func (l *JSONLexer) TokenizeLine(line string, state int) ([]Token, int) {
+	var tokens []Token
+	i := 0
+	for i < len(line) {
+		c := line[i]
+		switch {
+		case isSpace(c):
+			i++
+		case c == '"':
+			end := scanQuoted(line, i)
+			kind := TokenString
+			// A string followed by a colon is an object key
+			if strings.HasPrefix(strings.TrimLeft(line[end:], " \t"), ":") {
+				kind = TokenKey
+			}
+			tokens = append(tokens, Token{Start: i, End: end, Kind: kind})
+			i = end
+		case isDigit(c) || c == '-':
+			end := scanNumber(line, i)
+			if end == i {
+				end = i + 1
+			}
+			tokens = append(tokens, Token{Start: i, End: end, Kind: TokenNumber})
+			i = end
+		case strings.IndexByte("{}[],:", c) >= 0:
+			tokens = append(tokens, Token{Start: i, End: i + 1, Kind: TokenPunctuation})
+			i++
+		case isIdentStart(c):
+			end := scanIdent(line, i)
+			switch line[i:end] {
+			case "true", "false", "null":
+				tokens = append(tokens, Token{Start: i, End: end, Kind: TokenLiteral})
+			}
+			i = end
+		default:
+			i++
+		}
+	}
+	return tokens, 0
+}
@@ -0,0 +1,139 @@
+package syntax
+
+import (
+	"image/color"
+	"unicode"
+)
+
+type TokenKind int
+
+const (
+	TokenText TokenKind = iota
+	TokenKeyword
+	TokenType
+	TokenLiteral
+	TokenString
+	TokenNumber
+	TokenComment
+	TokenPunctuation
+	TokenKey
+	TokenSection
+)
+
+// Token is a colored span of a single line.
+// Start and End are byte offsets inside the line.
+type Token struct {
+	Start, End int
+	Kind       TokenKind
+}
+
+// Tokenizer splits one line of text into tokens.
+// state carries what is still open at the end of the previous line,
+// like a block comment, and is 0 for the first line of the text.
+// The returned state is passed to the next line.
+type Tokenizer interface {
+	TokenizeLine(line string, state int) ([]Token, int)
+}
+
+// TokenizeLines runs the tokenizer over all the lines of a text
+func TokenizeLines(tokenizer Tokenizer, lines []string) [][]Token {
+	tokens := make([][]Token, len(lines))
+	state := 0
+	for i, line := range lines {
+		tokens[i], state = tokenizer.TokenizeLine(line, state)
+	}
+	return tokens
+}
+
+// Theme maps token kinds to colors.
+// Kinds missing from the theme use the default text color.
+type Theme map[TokenKind]color.Color
+
+// DefaultTheme is tuned for the light grey background of the TextArea
+func DefaultTheme() Theme {
+	return Theme{
+		TokenKeyword:     color.RGBA{0x00, 0x00, 0xA0, 0xFF},
+		TokenType:        color.RGBA{0x00, 0x70, 0x70, 0xFF},
+		TokenLiteral:     color.RGBA{0x80, 0x00, 0x80, 0xFF},
+		TokenString:      color.RGBA{0xA0, 0x30, 0x00, 0xFF},
+		TokenNumber:      color.RGBA{0x00, 0x60, 0x00, 0xFF},
+		TokenComment:     color.RGBA{0x60, 0x60, 0x60, 0xFF},
+		TokenPunctuation: color.RGBA{0x30, 0x30, 0x30, 0xFF},
+		TokenKey:         color.RGBA{0x00, 0x40, 0x90, 0xFF},
+		TokenSection:     color.RGBA{0x90, 0x00, 0x30, 0xFF},
+	}
+}
+
+// ---------------------
+
+func isIdentStart(b byte) bool {
+	return b == '_' || unicode.IsLetter(rune(b))
+}
+
+func isIdentPart(b byte) bool {
+	return isIdentStart(b) || isDigit(b)
+}
+
+func isDigit(b byte) bool {
+	return b >= '0' && b <= '9'
+}
+
+func isSpace(b byte) bool {
+	return b == ' ' || b == '\t' || b == '\r'
+}
+
+// scanNumber returns the end of a number literal starting at pos.
+// It accepts signs, hex prefixes, fractions, exponents and underscores.
+func scanNumber(line string, pos int) int {
+	i := pos
+	if i < len(line) && (line[i] == '-' || line[i] == '+') {
+		i++
+	}
+	if i+1 < len(line) && line[i] == '0' && (line[i+1] == 'x' || line[i+1] == 'X') {
+		i += 2
+		for i < len(line) && (isDigit(line[i]) || line[i] == '_' ||
+			(line[i] >= 'a' && line[i] <= 'f') || (line[i] >= 'A' && line[i] <= 'F')) {
+			i++
+		}
+		return i
+	}
+	for i < len(line) && (isDigit(line[i]) || line[i] == '_' || line[i] == '.') {
+		i++
+	}
+	if i < len(line) && (line[i] == 'e' || line[i] == 'E') {
+		i++
+		if i < len(line) && (line[i] == '-' || line[i] == '+') {
+			i++
+		}
+		for i < len(line) && isDigit(line[i]) {
+			i++
+		}
+	}
+	return i
+}
+
+// scanQuoted returns the end of a string starting with the quote at pos.
+// Backslash escapes are skipped. An unterminated string runs to the end of the line.
+func scanQuoted(line string, pos int) int {
+	quote := line[pos]
+	i := pos + 1
+	for i < len(line) {
+		switch line[i] {
+		case '\\':
+			i += 2
+			continue
+		case quote:
+			return i + 1
+		}
+		i++
+	}
+	return len(line)
+}
+
+func scanIdent(line string, pos int) int {
+	i := pos
+	for i < len(line) && isIdentPart(line[i]) {
+		i++
+	}
+	return i
+}
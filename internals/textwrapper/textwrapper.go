@@ -6,6 +6,7 @@ import (
 	"image"
 	"image/color"
 	"os"
+	"unicode"
 
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/text/v2"
@@ -87,18 +88,38 @@ func (tw *TextWrapper) DrawTextWithWordWrap(screen *ebiten.Image, str string, x,
 }
 
 func (tw *TextWrapper) wrapText(str string) []string {
-	words := splitIntoWords(str)
+	return WrapText(tw, str, float64(tw.MaxWidth))
+}
+
+// Wrap splits str into lines no wider than maxWidth at the current font size, see WrapText
+func (tw *TextWrapper) Wrap(str string, maxWidth float64) []string {
+	return WrapText(tw, str, maxWidth)
+}
+
+// Measurer measures a line of text, TextWrapper and the other text wrappers of the repo do
+type Measurer interface {
+	MeasureText(s string) (float64, float64)
+}
+
+// WrapText splits str into lines no wider than maxWidth as measured by m.
+// Newlines in str always start a new line, a word wider than maxWidth gets a line of its own.
+func WrapText(m Measurer, str string, maxWidth float64) []string {
 	var lines []string
-	var currentLine string
+	currentLine := ""
 
-	for _, word := range words {
+	for _, word := range splitIntoWords(str) {
+		if word == "\n" {
+			lines = append(lines, currentLine)
+			currentLine = ""
+			continue
+		}
 		testLine := currentLine
 		if currentLine != "" {
 			testLine += " "
 		}
 		testLine += word
 
-		if tw.MeasureTextWidth(testLine) > tw.MaxWidth && currentLine != "" {
+		if width, _ := m.MeasureText(testLine); width > maxWidth && currentLine != "" {
 			lines = append(lines, currentLine)
 			currentLine = word
 		} else {
@@ -106,18 +127,14 @@ func (tw *TextWrapper) wrapText(str string) []string {
 		}
 	}
 
-	if currentLine != "" {
-		lines = append(lines, currentLine)
-	}
-
-	return lines
+	return append(lines, currentLine)
 }
 
 func splitIntoWords(str string) []string {
 	var words []string
 	currentWord := ""
 	for _, r := range str {
-		if r == ' ' || r == '\n' {
+		if unicode.IsSpace(r) {
 			if currentWord != "" {
 				words = append(words, currentWord)
 				currentWord = ""
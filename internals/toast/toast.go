@@ -0,0 +1,335 @@
+package toast
+
+import (
+	"image/color"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"example.com/menu/internals/overlay"
+	"example.com/menu/internals/textwrapper"
+	"example.com/menu/internals/utils"
+	"example.com/menu/internals/widgets"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+type Severity int
+
+const (
+	Info Severity = iota
+	Success
+	Warning
+	Error
+)
+
+// DefaultDuration is how long a notice stays when its Duration is 0
+const DefaultDuration = 4 * time.Second
+
+// TextRenderer draws the notices, both textwrapper packages satisfy it
+type TextRenderer = widgets.TextRenderer
+
+// Notice is one notification. Do not change it after Show.
+type Notice struct {
+	Message  string
+	Severity Severity
+	// Duration before it dismisses itself, 0 for DefaultDuration and < 0 to stay until clicked
+	Duration time.Duration
+	// Action is the label of an optional button, OnAction runs on the game goroutine
+	Action   string
+	OnAction func()
+
+	dismissed atomic.Bool
+}
+
+// Dismiss hides the notice, it can be called from any goroutine
+func (n *Notice) Dismiss() {
+	n.dismissed.Store(true)
+}
+
+// ---------------------
+
+// item is a shown notice with its layout and animation state
+type item struct {
+	notice    *Notice
+	remaining time.Duration
+	lines     []string
+	height    float32
+	y         float32 // animated towards targetY
+	targetY   float32
+	slide     float32 // 1 outside of the area, 0 in place
+	alpha     float32
+	leaving   bool
+	placed    bool
+	action    [4]float32 // x, y, width, height of the action button
+}
+
+// Queue shows notices stacked in the bottom right corner, above every page.
+//
+// Show and the severity helpers can be called from any goroutine: notices wait
+// in a pending list until the next Update. The queue is one Persistent overlay
+// of a window overlay stack, so it stays across page switches; clicks outside of
+// the notices go through to the page.
+type Queue struct {
+	MaxVisible int // more notices wait for a free place
+	Width      float32
+	Margin     float32
+	Spacing    float32
+	FontSize   float64
+
+	Text        TextRenderer
+	FontColor   color.Color
+	Background  color.Color
+	ActionColor color.Color
+
+	mu      sync.Mutex
+	pending []*Notice
+	clear   bool
+
+	items      []*item
+	overlay    *overlay.Overlay
+	areaWidth  float32
+	areaHeight float32
+	lastUpdate time.Time
+}
+
+// NewQueue opens the queue in overlays, usually the window stack of the navigator
+func NewQueue(overlays *overlay.Manager, text TextRenderer) *Queue {
+	q := &Queue{
+		MaxVisible: 4,
+		Width:      300,
+		Margin:     16,
+		Spacing:    8,
+		FontSize:   16,
+		Text:       text,
+	}
+	q.overlay = overlay.New(overlay.Toast, q)
+	q.overlay.Persistent = true
+	overlays.Open(q.overlay)
+	return q
+}
+
+// Show queues a notice, from any goroutine
+func (q *Queue) Show(n *Notice) *Notice {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.pending = append(q.pending, n)
+	return n
+}
+
+func (q *Queue) Info(message string) *Notice {
+	return q.Show(&Notice{Message: message, Severity: Info})
+}
+
+func (q *Queue) Success(message string) *Notice {
+	return q.Show(&Notice{Message: message, Severity: Success})
+}
+
+func (q *Queue) Warning(message string) *Notice {
+	return q.Show(&Notice{Message: message, Severity: Warning})
+}
+
+func (q *Queue) Error(message string) *Notice {
+	return q.Show(&Notice{Message: message, Severity: Error})
+}
+
+// Clear dismisses the shown notices and drops the pending ones, from any goroutine
+func (q *Queue) Clear() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.pending = nil
+	q.clear = true
+}
+
+// ---------------------
+
+// Contains tells the overlay manager which clicks are for the notices
+func (q *Queue) Contains(x, y float32) bool {
+	return q.itemAt(x, y) != nil
+}
+
+func (q *Queue) itemAt(x, y float32) *item {
+	for _, it := range q.items {
+		left := q.left() + it.slide*(q.Width+q.Margin)
+		if it.placed && x >= left && x < left+q.Width && y >= it.y && y < it.y+it.height {
+			return it
+		}
+	}
+	return nil
+}
+
+func (q *Queue) left() float32 {
+	return q.areaWidth - q.Margin - q.Width
+}
+
+func (q *Queue) Update(offsetX, offsetY float32, isAnimating bool) {
+	now := time.Now()
+	elapsed := now.Sub(q.lastUpdate)
+	if q.lastUpdate.IsZero() {
+		elapsed = 0
+	}
+	q.lastUpdate = now
+
+	q.takePending()
+
+	cursorX, cursorY := ebiten.CursorPosition()
+	x, y := float32(cursorX)-offsetX, float32(cursorY)-offsetY
+	hovered := q.itemAt(x, y)
+
+	if !isAnimating && hovered != nil && !hovered.leaving && inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+		hovered.leaving = true
+		if hovered.notice.Action != "" && inRect(x, y, hovered.action) && hovered.notice.OnAction != nil {
+			hovered.notice.OnAction()
+		}
+	}
+
+	kept := q.items[:0]
+	for _, it := range q.items {
+		if it.notice.dismissed.Load() {
+			it.leaving = true
+		}
+		// The timer waits while the cursor is over the notice
+		if !it.leaving && it.notice.Duration >= 0 && it != hovered {
+			it.remaining -= elapsed
+			if it.remaining <= 0 {
+				it.leaving = true
+			}
+		}
+
+		if it.leaving {
+			it.alpha -= 0.1
+			if it.alpha <= 0 {
+				continue
+			}
+		} else {
+			it.alpha = min(it.alpha+0.1, 1)
+		}
+		it.slide *= 0.75
+		it.y += (it.targetY - it.y) * 0.25
+		kept = append(kept, it)
+	}
+	q.items = kept
+}
+
+// takePending moves the waiting notices in while there is room
+func (q *Queue) takePending() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.clear {
+		q.clear = false
+		for _, it := range q.items {
+			it.leaving = true
+		}
+	}
+	for len(q.pending) > 0 && (q.MaxVisible <= 0 || q.visibleCount() < q.MaxVisible) {
+		n := q.pending[0]
+		q.pending = q.pending[1:]
+		if n.dismissed.Load() {
+			continue
+		}
+		duration := n.Duration
+		if duration == 0 {
+			duration = DefaultDuration
+		}
+		q.items = append(q.items, &item{notice: n, remaining: duration, slide: 1})
+	}
+}
+
+func (q *Queue) visibleCount() int {
+	count := 0
+	for _, it := range q.items {
+		if !it.leaving {
+			count++
+		}
+	}
+	return count
+}
+
+// ---------------------
+
+// layout wraps the messages and stacks the notices from the bottom, the newest last
+func (q *Queue) layout(areaWidth, areaHeight float32) {
+	q.areaWidth, q.areaHeight = areaWidth, areaHeight
+	padding := float32(q.FontSize) * 0.75
+	_, h := q.Text.MeasureText("Ag")
+	lineHeight := float32(h)
+
+	bottom := areaHeight - q.Margin
+	for i := len(q.items) - 1; i >= 0; i-- {
+		it := q.items[i]
+		if it.lines == nil {
+			textWidth := q.Width - 2*padding - 6
+			if it.notice.Action != "" {
+				actionWidth, _ := q.Text.MeasureText(it.notice.Action)
+				textWidth -= float32(actionWidth) + 2*padding
+			}
+			it.lines = textwrapper.WrapText(q.Text, it.notice.Message, float64(textWidth))
+			it.height = lineHeight*float32(len(it.lines)) + 2*padding
+		}
+		it.targetY = bottom - it.height
+		if !it.placed {
+			it.y, it.placed = it.targetY, true
+		}
+		bottom = it.targetY - q.Spacing
+	}
+}
+
+func (q *Queue) Draw(screen *ebiten.Image) {
+	if len(q.items) == 0 {
+		return
+	}
+	q.Text.SetFontSize(q.FontSize)
+	bounds := screen.Bounds()
+	q.layout(float32(bounds.Dx()), float32(bounds.Dy()))
+
+	padding := float32(q.FontSize) * 0.75
+	_, h := q.Text.MeasureText("Ag")
+	lineHeight := float32(h)
+
+	for _, it := range q.items {
+		x := q.left() + it.slide*(q.Width+q.Margin)
+		accent := severityColor(it.notice.Severity)
+
+		vector.DrawFilledRect(screen, x, it.y, q.Width, it.height, fade(utils.ColorOr(q.Background, color.RGBA{45, 45, 48, 240}), it.alpha), false)
+		vector.DrawFilledRect(screen, x, it.y, 6, it.height, fade(accent, it.alpha), false)
+
+		q.Text.SetColor(fade(utils.ColorOr(q.FontColor, color.White), it.alpha))
+		for i, line := range it.lines {
+			q.Text.DrawText(screen, line, float64(x+6+padding), float64(it.y+padding+float32(i)*lineHeight))
+		}
+
+		if it.notice.Action != "" {
+			actionWidth, _ := q.Text.MeasureText(it.notice.Action)
+			w := float32(actionWidth) + padding
+			it.action = [4]float32{x + q.Width - padding/2 - w, it.y + (it.height-lineHeight-padding/2)/2, w, lineHeight + padding/2}
+			vector.StrokeRect(screen, it.action[0], it.action[1], it.action[2], it.action[3], 1, fade(utils.ColorOr(q.ActionColor, accent), it.alpha), false)
+			q.Text.SetColor(fade(utils.ColorOr(q.ActionColor, accent), it.alpha))
+			q.Text.DrawText(screen, it.notice.Action, float64(it.action[0]+padding/2), float64(it.action[1]+padding/4))
+		}
+	}
+}
+
+func severityColor(s Severity) color.Color {
+	switch s {
+	case Success:
+		return color.RGBA{70, 180, 90, 255}
+	case Warning:
+		return color.RGBA{230, 170, 40, 255}
+	case Error:
+		return color.RGBA{220, 60, 60, 255}
+	default:
+		return color.RGBA{0, 140, 230, 255}
+	}
+}
+
+// fade scales the alpha of c, colors are premultiplied
+func fade(c color.Color, alpha float32) color.Color {
+	r, g, b, a := c.RGBA()
+	scale := func(v uint32) uint8 { return uint8(float32(v>>8) * alpha) }
+	return color.RGBA{scale(r), scale(g), scale(b), scale(a)}
+}
+
+func inRect(x, y float32, r [4]float32) bool {
+	return x >= r[0] && x < r[0]+r[2] && y >= r[1] && y < r[1]+r[3]
+}
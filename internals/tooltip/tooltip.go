@@ -0,0 +1,285 @@
+package tooltip
+
+import (
+	"image/color"
+	"time"
+
+	"example.com/menu/internals/overlay"
+	"example.com/menu/internals/textwrapper"
+	"example.com/menu/internals/utils"
+	"example.com/menu/internals/widgets"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+// TextRenderer draws the tooltip text, both textwrapper packages satisfy it
+type TextRenderer = widgets.TextRenderer
+
+// Bounded is a widget that can tell where it is, see Manager.Attach
+type Bounded interface {
+	Bounds() (x, y, width, height float32)
+}
+
+// Content is a custom tooltip, shown instead of a text
+type Content interface {
+	Draw(screen *ebiten.Image)
+	Size() (width, height float32)
+	SetPosition(x, y float32)
+}
+
+// Target is a widget with a tooltip
+type Target struct {
+	Bounds func() (x, y, width, height float32)
+	// Hovered replaces the hit test on Bounds when set, e.g. Button01.IsHovered
+	Hovered func() bool
+	// Focused shows the tooltip under the widget while it has the keyboard focus
+	Focused func() bool
+
+	Text    string
+	Content Content
+	// Delay overrides the manager delay when not 0
+	Delay    time.Duration
+	Disabled bool
+}
+
+func (t *Target) isHovered(x, y float32) bool {
+	if t.Hovered != nil {
+		return t.Hovered()
+	}
+	if t.Bounds == nil {
+		return false
+	}
+	bx, by, bw, bh := t.Bounds()
+	return x >= bx && x < bx+bw && y >= by && y < by+bh
+}
+
+// ---------------------
+
+// Manager shows the tooltip of the hovered or focused target, as a Tooltip
+// overlay of a page or window overlay stack. One tooltip is shown at a time.
+//
+// A tooltip shows after Delay over the same target and hides when the cursor
+// leaves it or clicks; after a click it stays hidden until the cursor leaves.
+type Manager struct {
+	Delay    time.Duration
+	MaxWidth float32 // text wraps at this width
+	FontSize float64
+	// Gap is the distance between the tooltip and the cursor or the focused widget
+	Gap float32
+
+	Text            TextRenderer
+	FontColor       color.Color
+	BackgroundColor color.Color
+	BorderColor     color.Color
+
+	overlays   *overlay.Manager
+	targets    []*Target
+	active     *Target
+	byFocus    bool
+	since      time.Time
+	suppressed *Target
+	tip        *overlay.Overlay
+	box        *box
+}
+
+func NewManager(overlays *overlay.Manager, text TextRenderer) *Manager {
+	m := &Manager{
+		Delay:    500 * time.Millisecond,
+		MaxWidth: 280,
+		FontSize: 16,
+		Gap:      16,
+		Text:     text,
+		overlays: overlays,
+	}
+	m.box = &box{manager: m}
+	m.tip = overlay.New(overlay.Tooltip, m.box)
+	return m
+}
+
+// Add registers a target, the last added wins when targets overlap
+func (m *Manager) Add(t *Target) *Target {
+	m.targets = append(m.targets, t)
+	return t
+}
+
+// Attach gives a widget a text tooltip. It also shows on keyboard focus when the
+// widget has IsFocused, and uses IsHovered instead of Bounds when the widget has it.
+func (m *Manager) Attach(widget Bounded, text string) *Target {
+	t := &Target{Bounds: widget.Bounds, Text: text}
+	if focusable, ok := widget.(interface{ IsFocused() bool }); ok {
+		t.Focused = focusable.IsFocused
+	}
+	if hoverable, ok := widget.(interface{ IsHovered() bool }); ok {
+		t.Hovered = hoverable.IsHovered
+	}
+	return m.Add(t)
+}
+
+func (m *Manager) Remove(t *Target) {
+	for i, target := range m.targets {
+		if target == t {
+			m.targets = append(m.targets[:i], m.targets[i+1:]...)
+			break
+		}
+	}
+	if m.active == t {
+		m.Hide()
+	}
+}
+
+// Hide closes the tooltip, it shows again after a new hover or focus delay
+func (m *Manager) Hide() {
+	m.tip.Close()
+	m.active = nil
+	m.byFocus = false
+}
+
+// Update follows the cursor and the focus. offsetX and offsetY are the page offsets;
+// isAnimating hides the tooltip, e.g. while a modal or a popup is open.
+func (m *Manager) Update(offsetX, offsetY float32, isAnimating bool) {
+	if isAnimating {
+		m.Hide()
+		return
+	}
+
+	cursorX, cursorY := ebiten.CursorPosition()
+	x, y := float32(cursorX)-offsetX, float32(cursorY)-offsetY
+
+	hovered := m.hoveredTarget(x, y)
+	if hovered != m.suppressed {
+		m.suppressed = nil
+	}
+	if hovered != nil && inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+		m.suppressed = hovered
+	}
+	if hovered == m.suppressed {
+		hovered = nil
+	}
+
+	target, byFocus := hovered, false
+	if target == nil {
+		target = m.focusedTarget()
+		byFocus = target != nil
+	}
+	if target != m.active || byFocus != m.byFocus {
+		m.Hide()
+		m.active, m.byFocus, m.since = target, byFocus, time.Now()
+	}
+	if m.active == nil || m.tip.IsOpen() {
+		return
+	}
+
+	delay := m.Delay
+	if m.active.Delay != 0 {
+		delay = m.active.Delay
+	}
+	if time.Since(m.since) < delay {
+		return
+	}
+
+	// Under the cursor for a hover, under the widget for the focus
+	m.box.target = m.active
+	m.box.anchorX, m.box.anchorY, m.box.anchorHeight = x, y, 0
+	if byFocus && m.active.Bounds != nil {
+		bx, by, _, bh := m.active.Bounds()
+		m.box.anchorX, m.box.anchorY, m.box.anchorHeight = bx, by, bh
+	}
+	m.overlays.Open(m.tip)
+}
+
+func (m *Manager) hoveredTarget(x, y float32) *Target {
+	for i := len(m.targets) - 1; i >= 0; i-- {
+		t := m.targets[i]
+		if !t.Disabled && t.isHovered(x, y) {
+			return t
+		}
+	}
+	return nil
+}
+
+func (m *Manager) focusedTarget() *Target {
+	for _, t := range m.targets {
+		if !t.Disabled && t.Focused != nil && t.Focused() {
+			return t
+		}
+	}
+	return nil
+}
+
+// ---------------------
+
+// box is the overlay content. It is placed in Draw, where the size of the area is known.
+type box struct {
+	manager      *Manager
+	target       *Target
+	anchorX      float32
+	anchorY      float32
+	anchorHeight float32
+}
+
+func (b *box) Update(offsetX, offsetY float32, isAnimating bool) {}
+
+func (b *box) Contains(x, y float32) bool {
+	return false
+}
+
+func (b *box) Draw(screen *ebiten.Image) {
+	if b.target == nil {
+		return
+	}
+	m := b.manager
+	padding := float32(m.FontSize) / 2
+
+	var lines []string
+	var lineHeight, width, height float32
+	if b.target.Content != nil {
+		width, height = b.target.Content.Size()
+	} else {
+		m.Text.SetFontSize(m.FontSize)
+		lines = textwrapper.WrapText(m.Text, b.target.Text, float64(m.MaxWidth-2*padding))
+		_, h := m.Text.MeasureText("Ag")
+		lineHeight = float32(h)
+		for _, line := range lines {
+			w, _ := m.Text.MeasureText(line)
+			width = max(width, float32(w))
+		}
+		width += 2 * padding
+		height = lineHeight*float32(len(lines)) + 2*padding
+	}
+
+	x, y := b.place(screen, width, height)
+
+	if b.target.Content != nil {
+		b.target.Content.SetPosition(x, y)
+		b.target.Content.Draw(screen)
+		return
+	}
+
+	vector.DrawFilledRect(screen, x, y, width, height, utils.ColorOr(m.BackgroundColor, color.RGBA{40, 40, 40, 235}), false)
+	vector.StrokeRect(screen, x, y, width, height, 1, utils.ColorOr(m.BorderColor, color.RGBA{110, 110, 110, 255}), false)
+	m.Text.SetColor(utils.ColorOr(m.FontColor, color.White))
+	for i, line := range lines {
+		m.Text.DrawText(screen, line, float64(x+padding), float64(y+padding+float32(i)*lineHeight))
+	}
+}
+
+// place puts the box below the anchor, above it when there is no room below,
+// and keeps it inside the area
+func (b *box) place(screen *ebiten.Image, width, height float32) (float32, float32) {
+	bounds := screen.Bounds()
+	areaWidth, areaHeight := float32(bounds.Dx()), float32(bounds.Dy())
+	const margin = 4
+	gap := b.manager.Gap
+
+	x := b.anchorX
+	y := b.anchorY + b.anchorHeight + gap
+	if y+height > areaHeight-margin {
+		y = b.anchorY - gap - height
+	}
+	x = min(x, areaWidth-margin-width)
+	x = max(x, margin)
+	y = min(y, areaHeight-margin-height)
+	y = max(y, margin)
+	return x, y
+}
@@ -0,0 +1,11 @@
+package utils
+
+import "image/color"
+
+// ColorOr returns c, or fallback when c is nil, for the optional colors of the widgets
+func ColorOr(c, fallback color.Color) color.Color {
+	if c == nil {
+		return fallback
+	}
+	return c
+}
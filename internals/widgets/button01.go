@@ -62,3 +62,12 @@ func (b *Button01) OnMouseDown() {
 func (b *Button01) SetHovered(isHovered bool) {
 	b.isHovered = isHovered
 }
+
+func (b *Button01) IsHovered() bool {
+	return b.isHovered
+}
+
+// Bounds returns the rectangle of the button, e.g. for tooltips
+func (b *Button01) Bounds() (float32, float32, float32, float32) {
+	return float32(b.X), float32(b.Y), float32(b.Width), float32(b.Height)
+}
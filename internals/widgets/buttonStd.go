@@ -45,6 +45,11 @@ func NewButtonStd(
 	}
 }
 
+// Bounds returns the rectangle of the button, e.g. for tooltips
+func (b *ButtonStd) Bounds() (float32, float32, float32, float32) {
+	return b.X, b.Y, b.Width, b.Height
+}
+
 func (b *ButtonStd) Draw(screen *ebiten.Image) {
 
 	vector.DrawFilledRect(screen, b.X, b.Y, b.Width, b.Height, b.BackgroundColor, false)
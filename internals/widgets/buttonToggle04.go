@@ -27,20 +27,19 @@ type ToggleButton04 struct {
 	cachedOnLabelBounds  image.Rectangle
 	cachedOffLabelBounds image.Rectangle
 	OnClickFunc          func()
+	// pressed is set on mouse down, the toggle only flips when the button is released over it
+	pressed bool
 }
 
 func (b *ToggleButton04) OnMouseDown() {
-
-	b.IsToggled = !b.IsToggled
-	if b.IsToggled {
-		b.CurrentColor = b.ToggleColor
-	} else {
-		b.CurrentColor = b.DefaultColor
-	}
+	b.pressed = true
 }
 
 func (b *ToggleButton04) SetHovered(isHovered bool) {
-
+	// Moving off the button cancels the press
+	if !isHovered {
+		b.pressed = false
+	}
 }
 
 func NewToggleButton04(
@@ -56,8 +55,9 @@ func NewToggleButton04(
 		Width: width, Height: height,
 		OnLabel: onLabel, OffLabel: offLabel,
 		DefaultColor: defaultColor, ToggleColor: toggleColor,
-		tx:          tx,
-		OnClickFunc: onClick,
+		CurrentColor: defaultColor,
+		tx:           tx,
+		OnClickFunc:  onClick,
 	}
 	b.knobX = float64(x)
 	onWidth, onHeight := b.tx.MeasureText(onLabel)
@@ -69,13 +69,29 @@ func NewToggleButton04(
 }
 
 func (b *ToggleButton04) OnClick() {
-	b.IsToggled = !b.IsToggled
-	b.animationProgress = 0
+	if !b.pressed {
+		return
+	}
+	b.pressed = false
+	b.SetToggled(!b.IsToggled)
 	if b.OnClickFunc != nil {
 		b.OnClickFunc()
 	}
 }
 
+// SetToggled changes the state and its color without calling OnClickFunc
+func (b *ToggleButton04) SetToggled(on bool) {
+	if b.IsToggled == on {
+		return
+	}
+	b.IsToggled = on
+	b.animationProgress = 0
+	b.CurrentColor = b.DefaultColor
+	if on {
+		b.CurrentColor = b.ToggleColor
+	}
+}
+
 func (b *ToggleButton04) Update() {
 	if b.animationProgress < animationDuration {
 		b.animationProgress++
@@ -0,0 +1,268 @@
+package widgets
+
+import (
+	"image/color"
+
+	"example.com/menu/internals/utils"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+type CheckState int
+
+const (
+	Unchecked CheckState = iota
+	Checked
+	Indeterminate // shown as a dash, e.g. when only some items of a group are checked
+)
+
+// Checkbox is a box with a label. A click or Space, once focused, toggles it.
+//
+// On its own it keeps its state. Bind shows one item of a shared Selection,
+// BindAll turns it into a "select all" box that is Indeterminate when only
+// some items are selected.
+type Checkbox struct {
+	X, Y          float32
+	Width, Height float32
+
+	Label    string
+	TriState bool // clicks cycle Unchecked, Checked, Indeterminate
+	Disabled bool
+	// OnChange runs when the user toggles the box, not on SetState
+	OnChange func(state CheckState)
+
+	Text            TextRenderer
+	FontSize        float64
+	FontColor       color.Color
+	AccentColor     color.Color
+	BorderColor     color.Color
+	BackgroundColor color.Color
+	DisabledColor   color.Color
+	FocusColor      color.Color
+
+	state     CheckState
+	selection *Selection
+	index     int
+	all       bool
+
+	hovered  bool
+	pressed  bool
+	hasFocus bool
+}
+
+func NewCheckbox(x, y float32, label string, text TextRenderer, fontSize float64) *Checkbox {
+	cb := &Checkbox{
+		X:        x,
+		Y:        y,
+		Label:    label,
+		Text:     text,
+		FontSize: fontSize,
+	}
+	cb.Width, cb.Height = cb.PreferredSize()
+	return cb
+}
+
+// Bind makes the checkbox show and toggle item index of selection
+func (cb *Checkbox) Bind(selection *Selection, index int) {
+	cb.selection = selection
+	cb.index = index
+	cb.all = false
+}
+
+// BindAll makes the checkbox select or clear every item of selection
+func (cb *Checkbox) BindAll(selection *Selection) {
+	cb.selection = selection
+	cb.all = true
+}
+
+func (cb *Checkbox) State() CheckState {
+	switch {
+	case cb.selection == nil:
+		return cb.state
+	case cb.all:
+		count := cb.selection.Count()
+		if count == 0 {
+			return Unchecked
+		}
+		// Disabled items that are not selected can't be, they don't count
+		for i := 0; i < cb.selection.Len(); i++ {
+			if !cb.selection.IsSelected(i) && cb.selection.IsEnabled(i) {
+				return Indeterminate
+			}
+		}
+		return Checked
+	case cb.selection.IsSelected(cb.index):
+		return Checked
+	}
+	return Unchecked
+}
+
+func (cb *Checkbox) Checked() bool {
+	return cb.State() == Checked
+}
+
+// SetState changes the state without calling OnChange
+func (cb *Checkbox) SetState(state CheckState) {
+	switch {
+	case cb.selection == nil:
+		cb.state = state
+	case cb.all && state == Checked:
+		cb.selection.SelectAll()
+	case cb.all && state == Unchecked:
+		cb.selection.Clear()
+	case cb.all:
+	case state == Checked:
+		cb.selection.Select(cb.index)
+	default:
+		cb.selection.Deselect(cb.index)
+	}
+}
+
+func (cb *Checkbox) SetChecked(checked bool) {
+	if checked {
+		cb.SetState(Checked)
+	} else {
+		cb.SetState(Unchecked)
+	}
+}
+
+func (cb *Checkbox) isDisabled() bool {
+	return cb.Disabled || (cb.selection != nil && !cb.all && !cb.selection.IsEnabled(cb.index))
+}
+
+// toggle is a user click or Space
+func (cb *Checkbox) toggle() {
+	state := cb.State()
+	switch {
+	case cb.selection == nil && state == Checked && cb.TriState:
+		cb.state = Indeterminate
+	case cb.selection == nil && state == Checked:
+		cb.state = Unchecked
+	case cb.selection == nil && state == Indeterminate:
+		cb.state = Unchecked
+	case cb.selection == nil:
+		cb.state = Checked
+	case cb.all && state == Checked:
+		cb.selection.Clear()
+	case cb.all:
+		cb.selection.SelectAll()
+	default:
+		cb.selection.Toggle(cb.index)
+	}
+	if cb.OnChange != nil && cb.State() != state {
+		cb.OnChange(cb.State())
+	}
+}
+
+func (cb *Checkbox) SetBounds(x, y, width, height float32) {
+	cb.X, cb.Y, cb.Width, cb.Height = x, y, width, height
+}
+
+// Bounds returns the rectangle of the checkbox, e.g. for tooltips
+func (cb *Checkbox) Bounds() (float32, float32, float32, float32) {
+	return cb.X, cb.Y, cb.Width, cb.Height
+}
+
+// PreferredSize returns the size of the box and the label
+func (cb *Checkbox) PreferredSize() (float32, float32) {
+	cb.Text.SetFontSize(cb.FontSize)
+	w, h := cb.Text.MeasureText(cb.Label)
+	box := cb.boxSize()
+	return box + box/2 + float32(w), max(box, float32(h))
+}
+
+func (cb *Checkbox) boxSize() float32 {
+	return float32(cb.FontSize) * 1.2
+}
+
+func (cb *Checkbox) Focus() {
+	if !cb.isDisabled() {
+		cb.hasFocus = true
+	}
+}
+
+func (cb *Checkbox) Blur() {
+	cb.hasFocus = false
+}
+
+func (cb *Checkbox) IsFocused() bool {
+	return cb.hasFocus
+}
+
+// ---------------------
+
+func (cb *Checkbox) Update(navigatorOffsetX, navigatorOffsetY float32, isAnimating bool) {
+	if isAnimating || cb.isDisabled() {
+		cb.hovered, cb.pressed = false, false
+		return
+	}
+
+	x, y := ebiten.CursorPosition()
+	localX := float32(x) - navigatorOffsetX
+	localY := float32(y) - navigatorOffsetY
+	cb.hovered = localX >= cb.X && localX < cb.X+cb.Width && localY >= cb.Y && localY < cb.Y+cb.Height
+
+	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+		cb.pressed = cb.hovered
+		cb.hasFocus = cb.hovered
+	}
+	if inpututil.IsMouseButtonJustReleased(ebiten.MouseButtonLeft) {
+		if cb.pressed && cb.hovered {
+			cb.toggle()
+		}
+		cb.pressed = false
+	}
+
+	if cb.hasFocus {
+		if inpututil.IsKeyJustPressed(ebiten.KeySpace) {
+			cb.toggle()
+		}
+		if inpututil.IsKeyJustPressed(ebiten.KeyEscape) {
+			cb.hasFocus = false
+		}
+	}
+}
+
+func (cb *Checkbox) Draw(screen *ebiten.Image) {
+	fontColor := utils.ColorOr(cb.FontColor, color.Black)
+	accent := utils.ColorOr(cb.AccentColor, color.RGBA{0, 120, 215, 255})
+	border := utils.ColorOr(cb.BorderColor, color.RGBA{120, 120, 120, 255})
+	background := utils.ColorOr(cb.BackgroundColor, color.White)
+	if cb.isDisabled() {
+		disabled := utils.ColorOr(cb.DisabledColor, color.RGBA{170, 170, 170, 255})
+		fontColor, accent, border = disabled, disabled, disabled
+	} else if cb.hovered {
+		border = accent
+	}
+
+	box := cb.boxSize()
+	bx, by := cb.X, cb.Y+(cb.Height-box)/2
+	state := cb.State()
+
+	if state == Unchecked {
+		vector.DrawFilledRect(screen, bx, by, box, box, background, false)
+		vector.StrokeRect(screen, bx, by, box, box, 2, border, false)
+	} else {
+		vector.DrawFilledRect(screen, bx, by, box, box, accent, false)
+	}
+
+	mark := color.White
+	switch state {
+	case Checked:
+		vector.StrokeLine(screen, bx+box*0.2, by+box*0.5, bx+box*0.42, by+box*0.72, 2, mark, true)
+		vector.StrokeLine(screen, bx+box*0.42, by+box*0.72, bx+box*0.8, by+box*0.28, 2, mark, true)
+	case Indeterminate:
+		vector.StrokeLine(screen, bx+box*0.25, by+box/2, bx+box*0.75, by+box/2, 2, mark, true)
+	}
+
+	if cb.hasFocus {
+		focus := utils.ColorOr(cb.FocusColor, color.RGBA{0, 128, 255, 255})
+		vector.StrokeRect(screen, bx-3, by-3, box+6, box+6, 1, focus, false)
+	}
+
+	cb.Text.SetFontSize(cb.FontSize)
+	_, textH := cb.Text.MeasureText(cb.Label)
+	cb.Text.SetColor(fontColor)
+	cb.Text.DrawText(screen, cb.Label, float64(bx+box*1.5), float64(cb.Y+(cb.Height-float32(textH))/2))
+}
@@ -0,0 +1,222 @@
+package widgets
+
+import (
+	"image/color"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+)
+
+// TextRenderer measures and draws labels. Both textwrapper packages implement it.
+type TextRenderer interface {
+	MeasureText(s string) (float64, float64)
+	DrawText(screen *ebiten.Image, s string, x, y float64)
+	SetFontSize(size float64)
+	SetColor(c color.Color)
+}
+
+// choiceList is the input handling shared by RadioGroup and SegmentedControl.
+// The widgets only differ in where the items are and how they are drawn.
+//
+// A click selects an item. Once clicked the list has focus: the arrows move to the
+// next enabled item, Home/End to the first and last, Space/Enter toggles the item.
+// In SelectSingle mode the arrows select as they move, like a radio group.
+type choiceList struct {
+	X, Y          float32
+	Width, Height float32
+
+	Options   []string
+	Selection *Selection
+	Disabled  bool
+
+	Text          TextRenderer
+	FontSize      float64
+	FontColor     color.Color
+	AccentColor   color.Color
+	BorderColor   color.Color
+	DisabledColor color.Color
+	FocusColor    color.Color
+
+	itemRect func(index int) (x, y, width, height float32)
+
+	hovered  int
+	pressed  int
+	focused  int
+	hasFocus bool
+
+	heldKeys map[ebiten.Key]*KeyState
+}
+
+func newChoiceList(x, y float32, options []string, mode SelectionMode, text TextRenderer, fontSize float64) choiceList {
+	selection := NewSelection(len(options), mode)
+	if mode == SelectSingle && len(options) > 0 {
+		selection.Select(0)
+	}
+	return choiceList{
+		X:         x,
+		Y:         y,
+		Options:   options,
+		Selection: selection,
+		Text:      text,
+		FontSize:  fontSize,
+		hovered:   -1,
+		pressed:   -1,
+		heldKeys:  make(map[ebiten.Key]*KeyState),
+	}
+}
+
+// SelectedIndex returns the selected option, -1 when nothing is selected
+func (c *choiceList) SelectedIndex() int {
+	return c.Selection.SelectedIndex()
+}
+
+// SelectedOption returns the label of the selected option, "" when nothing is selected
+func (c *choiceList) SelectedOption() string {
+	if i := c.Selection.SelectedIndex(); i >= 0 && i < len(c.Options) {
+		return c.Options[i]
+	}
+	return ""
+}
+
+func (c *choiceList) SetSelectedIndex(index int) {
+	c.Selection.Select(index)
+	c.focused = index
+}
+
+// SetOptionEnabled disables or enables one option
+func (c *choiceList) SetOptionEnabled(index int, enabled bool) {
+	c.Selection.SetEnabled(index, enabled)
+}
+
+func (c *choiceList) SetBounds(x, y, width, height float32) {
+	c.X, c.Y, c.Width, c.Height = x, y, width, height
+}
+
+// Bounds returns the rectangle of the whole list, e.g. for tooltips
+func (c *choiceList) Bounds() (float32, float32, float32, float32) {
+	return c.X, c.Y, c.Width, c.Height
+}
+
+func (c *choiceList) Focus() {
+	if !c.Disabled {
+		c.hasFocus = true
+	}
+}
+
+func (c *choiceList) Blur() {
+	c.hasFocus = false
+}
+
+func (c *choiceList) IsFocused() bool {
+	return c.hasFocus
+}
+
+// ---------------------
+
+func (c *choiceList) Update(navigatorOffsetX, navigatorOffsetY float32, isAnimating bool) {
+	if isAnimating || c.Disabled {
+		c.hovered, c.pressed = -1, -1
+		return
+	}
+	// Options can be added after the constructor
+	if c.Selection.Len() != len(c.Options) {
+		c.Selection.Resize(len(c.Options))
+	}
+
+	x, y := ebiten.CursorPosition()
+	localX := float32(x) - navigatorOffsetX
+	localY := float32(y) - navigatorOffsetY
+	c.hovered = c.itemAt(localX, localY)
+
+	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+		if c.contains(localX, localY) {
+			c.hasFocus = true
+			c.pressed = c.hovered
+			if c.hovered >= 0 {
+				c.focused = c.hovered
+			}
+		} else {
+			c.hasFocus = false
+			c.pressed = -1
+		}
+	}
+	if inpututil.IsMouseButtonJustReleased(ebiten.MouseButtonLeft) {
+		// Only a press and release on the same item counts as a click
+		if c.pressed >= 0 && c.pressed == c.hovered {
+			c.Selection.Toggle(c.pressed)
+		}
+		c.pressed = -1
+	}
+
+	if c.hasFocus {
+		c.handleKeyboard()
+	}
+}
+
+func (c *choiceList) handleKeyboard() {
+	now := time.Now()
+	keys := []struct {
+		key ebiten.Key
+		dir int
+	}{
+		{ebiten.KeyLeft, -1},
+		{ebiten.KeyUp, -1},
+		{ebiten.KeyRight, 1},
+		{ebiten.KeyDown, 1},
+	}
+	for _, k := range keys {
+		if fired, _ := repeatKeyState(c.heldKeys, k.key, now, 400*time.Millisecond, 80*time.Millisecond); fired {
+			c.moveFocus(c.Selection.nextEnabled(c.focused, k.dir))
+		}
+	}
+
+	if inpututil.IsKeyJustPressed(ebiten.KeyHome) {
+		c.moveFocus(c.Selection.nextEnabled(-1, 1))
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyEnd) {
+		c.moveFocus(c.Selection.nextEnabled(-1, -1))
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeySpace) || inpututil.IsKeyJustPressed(ebiten.KeyEnter) {
+		c.Selection.Toggle(c.focused)
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyEscape) {
+		c.hasFocus = false
+	}
+}
+
+func (c *choiceList) moveFocus(index int) {
+	if index < 0 {
+		return
+	}
+	c.focused = index
+	if c.Selection.Mode == SelectSingle {
+		c.Selection.Select(index)
+	}
+}
+
+func (c *choiceList) contains(x, y float32) bool {
+	return x >= c.X && x < c.X+c.Width && y >= c.Y && y < c.Y+c.Height
+}
+
+func (c *choiceList) itemAt(x, y float32) int {
+	for i := range c.Options {
+		ix, iy, iw, ih := c.itemRect(i)
+		if x >= ix && x < ix+iw && y >= iy && y < iy+ih {
+			return i
+		}
+	}
+	return -1
+}
+
+// isItemDisabled reports whether an option is drawn grayed out
+func (c *choiceList) isItemDisabled(index int) bool {
+	return c.Disabled || !c.Selection.IsEnabled(index)
+}
+
+// measure returns the size of a label at the widget font size
+func (c *choiceList) measure(label string) (float32, float32) {
+	c.Text.SetFontSize(c.FontSize)
+	w, h := c.Text.MeasureText(label)
+	return float32(w), float32(h)
+}
@@ -0,0 +1,72 @@
+package widgets
+
+import (
+	"example.com/menu/internals/overlay"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+)
+
+// ContextMenu opens a Menu at the cursor on a right click inside its area.
+// The menu is a Popup overlay: a click outside of it or Escape closes it.
+type ContextMenu struct {
+	// X, Y, Width, Height is the area that reacts to the right click, a zero Width means everywhere
+	X, Y          float32
+	Width, Height float32
+	Menu          *Menu
+	Style         *MenuStyle
+	Disabled      bool
+
+	overlays *overlay.Manager
+	popup    *menuPopup
+	overlay  *overlay.Overlay
+}
+
+func NewContextMenu(menu *Menu, overlays *overlay.Manager, text TextRenderer, fontSize float64) *ContextMenu {
+	c := &ContextMenu{
+		Menu:     menu,
+		Style:    newMenuStyle(text, fontSize),
+		overlays: overlays,
+	}
+	c.popup = &menuPopup{style: c.Style, close: c.Close}
+	c.overlay = overlay.New(overlay.Popup, c.popup)
+	// Escape closes one submenu at a time
+	c.overlay.DismissOnEscape = false
+	return c
+}
+
+// Open shows the menu with its top left corner at x, y
+func (c *ContextMenu) Open(x, y float32) {
+	c.popup.open(c.Menu, x, y)
+	c.overlays.Open(c.overlay)
+}
+
+func (c *ContextMenu) Close() {
+	c.overlay.Close()
+}
+
+func (c *ContextMenu) IsOpen() bool {
+	return c.overlay.IsOpen()
+}
+
+// Update opens the menu on a right click and runs the accelerators of its items.
+// Call it with the elements of the page, the open menu is updated by the overlay manager.
+func (c *ContextMenu) Update(offsetX, offsetY float32, isAnimating bool) {
+	if isAnimating || c.Disabled {
+		return
+	}
+	if !c.IsOpen() {
+		c.Menu.HandleShortcuts()
+	}
+	if !inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonRight) {
+		return
+	}
+	cursorX, cursorY := ebiten.CursorPosition()
+	x, y := float32(cursorX)-offsetX, float32(cursorY)-offsetY
+	if c.Width > 0 && (x < c.X || x >= c.X+c.Width || y < c.Y || y >= c.Y+c.Height) {
+		return
+	}
+	c.Open(x, y)
+}
+
+// Draw draws nothing, the open menu is drawn by the overlay manager
+func (c *ContextMenu) Draw(screen *ebiten.Image) {}
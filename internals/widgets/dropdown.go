@@ -0,0 +1,827 @@
+package widgets
+
+import (
+	"image"
+	"image/color"
+	"math"
+	"strings"
+	"time"
+	"unicode"
+
+	"example.com/menu/internals/overlay"
+	"example.com/menu/internals/utils"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+// Dropdown picks one option from a list that opens in a popup.
+//
+// The list is a Popup overlay of the Manager given to NewDropdown, drawn above the
+// page: a click outside of it or Escape closes it. It stays inside the image it is
+// drawn on and opens upward when there is no room below.
+//
+// Keyboard, once focused: Enter, Space or Alt+Down open the list, the arrows,
+// PageUp/PageDown and Home/End move in it, Enter picks, Escape closes. Typing jumps
+// to the first option starting with the typed letters. In Editable mode the field is
+// a combo box: the typed text is the value and filters the list.
+type Dropdown struct {
+	X, Y          float32
+	Width, Height float32
+
+	Options     []string
+	Placeholder string
+	Editable    bool
+	MaxVisible  int // rows shown before the list scrolls, 0 for 8
+	Disabled    bool
+	// OnChange runs when the user picks an option, or confirms typed text with Enter
+	// in Editable mode. index is -1 for text that is not one of the options.
+	OnChange func(index int, value string)
+
+	Text             TextRenderer
+	FontSize         float64
+	FontColor        color.Color
+	PlaceholderColor color.Color
+	BackgroundColor  color.Color
+	BorderColor      color.Color
+	HighlightColor   color.Color
+	FocusColor       color.Color
+	DisabledColor    color.Color
+
+	selected  int
+	text      []rune // the value in Editable mode
+	cursor    int
+	committed string // last value reported to OnChange in Editable mode
+
+	overlays      *overlay.Manager
+	popup         *overlay.Overlay
+	filtered      []int // indices of the options in the list
+	highlighted   int   // position in filtered, -1 for none
+	scroll        int   // first visible position in filtered
+	hoverRow      int
+	pressedRow    int
+	draggingThumb bool
+	dragOffset    float32
+
+	typeAhead   string
+	typeAheadAt time.Time
+
+	hasFocus   bool
+	counter    int
+	viewWidth  float32 // size of the image the popup is drawn on, 0 until the first Draw
+	viewHeight float32
+	heldKeys   map[ebiten.Key]*KeyState
+}
+
+const typeAheadTimeout = time.Second
+
+func NewDropdown(x, y, width, height float32, options []string, overlays *overlay.Manager, text TextRenderer, fontSize float64) *Dropdown {
+	d := &Dropdown{
+		X:           x,
+		Y:           y,
+		Width:       width,
+		Height:      height,
+		Options:     options,
+		Text:        text,
+		FontSize:    fontSize,
+		selected:    -1,
+		highlighted: -1,
+		hoverRow:    -1,
+		pressedRow:  -1,
+		overlays:    overlays,
+		heldKeys:    make(map[ebiten.Key]*KeyState),
+	}
+	d.popup = overlay.New(overlay.Popup, &dropdownPopup{dropdown: d})
+	d.popup.OnClose = d.closed
+	return d
+}
+
+// SelectedIndex returns the picked option, -1 when there is none or the typed text is not an option
+func (d *Dropdown) SelectedIndex() int {
+	return d.selected
+}
+
+// SetSelectedIndex picks an option without calling OnChange
+func (d *Dropdown) SetSelectedIndex(index int) {
+	if index < 0 || index >= len(d.Options) {
+		index = -1
+	}
+	d.selected = index
+	d.text = nil
+	if index >= 0 {
+		d.text = []rune(d.Options[index])
+	}
+	d.cursor = len(d.text)
+	d.committed = string(d.text)
+}
+
+// Value returns the picked option, or the typed text in Editable mode
+func (d *Dropdown) Value() string {
+	if d.Editable {
+		return string(d.text)
+	}
+	if d.selected >= 0 && d.selected < len(d.Options) {
+		return d.Options[d.selected]
+	}
+	return ""
+}
+
+// SetValue picks the option equal to value without calling OnChange.
+// In Editable mode any text is kept, otherwise an unknown value clears the selection.
+func (d *Dropdown) SetValue(value string) {
+	d.SetSelectedIndex(d.indexOf(value))
+	if d.Editable {
+		d.text = []rune(value)
+		d.cursor = len(d.text)
+		d.committed = value
+	}
+}
+
+func (d *Dropdown) indexOf(value string) int {
+	for i, option := range d.Options {
+		if option == value {
+			return i
+		}
+	}
+	return -1
+}
+
+func (d *Dropdown) IsOpen() bool {
+	return d.popup.IsOpen()
+}
+
+// Open shows every option, the picked one highlighted
+func (d *Dropdown) Open() {
+	if d.Disabled || d.IsOpen() {
+		return
+	}
+	d.filter("")
+	d.highlighted = 0
+	for pos, index := range d.filtered {
+		if index == d.selected {
+			d.highlighted = pos
+		}
+	}
+	d.scroll = 0
+	d.ensureVisible()
+	d.overlays.Open(d.popup)
+}
+
+func (d *Dropdown) Close() {
+	d.popup.Close()
+}
+
+// closed resets the list once its overlay is closed. The typed text of a combo box
+// is kept as the value, unless Escape closed the list.
+func (d *Dropdown) closed() {
+	d.draggingThumb = false
+	d.pressedRow = -1
+	d.hoverRow = -1
+	if d.Editable && !inpututil.IsKeyJustPressed(ebiten.KeyEscape) {
+		d.commitText()
+	}
+}
+
+func (d *Dropdown) Focus() {
+	if !d.Disabled {
+		d.hasFocus = true
+		d.counter = 0
+	}
+}
+
+func (d *Dropdown) Blur() {
+	d.hasFocus = false
+	d.Close()
+}
+
+func (d *Dropdown) IsFocused() bool {
+	return d.hasFocus
+}
+
+func (d *Dropdown) SetBounds(x, y, width, height float32) {
+	d.X, d.Y, d.Width, d.Height = x, y, width, height
+}
+
+// Bounds returns the rectangle of the closed dropdown, e.g. for tooltips
+func (d *Dropdown) Bounds() (float32, float32, float32, float32) {
+	return d.X, d.Y, d.Width, d.Height
+}
+
+// PreferredSize fits the longest option and the arrow
+func (d *Dropdown) PreferredSize() (float32, float32) {
+	d.Text.SetFontSize(d.FontSize)
+	var widest float64
+	_, height := d.Text.MeasureText("Ag")
+	for _, option := range append([]string{d.Placeholder}, d.Options...) {
+		w, _ := d.Text.MeasureText(option)
+		widest = math.Max(widest, w)
+	}
+	return float32(widest) + d.padding()*3 + d.arrowWidth(), float32(height) + d.padding()*2
+}
+
+// filter keeps the options containing query, case insensitive
+func (d *Dropdown) filter(query string) {
+	query = strings.ToLower(query)
+	d.filtered = d.filtered[:0]
+	for i, option := range d.Options {
+		if query == "" || strings.Contains(strings.ToLower(option), query) {
+			d.filtered = append(d.filtered, i)
+		}
+	}
+	d.highlighted = min(d.highlighted, len(d.filtered)-1)
+	d.scroll = 0
+}
+
+// choose picks an option from the list and closes it
+func (d *Dropdown) choose(index int) {
+	if index < 0 || index >= len(d.Options) {
+		d.Close()
+		return
+	}
+	changed := index != d.selected || (d.Editable && string(d.text) != d.Options[index])
+	d.SetSelectedIndex(index)
+	d.Close()
+	if changed && d.OnChange != nil {
+		d.OnChange(index, d.Options[index])
+	}
+}
+
+// commitText confirms the typed text of a combo box
+func (d *Dropdown) commitText() {
+	value := string(d.text)
+	d.selected = d.indexOf(value)
+	if value != d.committed {
+		d.committed = value
+		if d.OnChange != nil {
+			d.OnChange(d.selected, value)
+		}
+	}
+}
+
+// ---------------------
+
+// Update reads the keyboard of the focused dropdown and opens the list on a click.
+// While the list is open the overlay manager updates it, a click used by it
+// arrives here as isAnimating.
+func (d *Dropdown) Update(navigatorOffsetX, navigatorOffsetY float32, isAnimating bool) {
+	if d.Disabled {
+		d.Close()
+		return
+	}
+	if isAnimating {
+		return
+	}
+	d.counter++
+
+	x, y := ebiten.CursorPosition()
+	localX := float32(x) - navigatorOffsetX
+	localY := float32(y) - navigatorOffsetY
+
+	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+		if d.fieldContains(localX, localY) {
+			d.Focus()
+			d.clickField(localX)
+		} else {
+			d.hasFocus = false
+		}
+	}
+
+	if d.hasFocus {
+		d.handleKeyboard()
+	}
+}
+
+// clickField places the cursor of a combo box, or opens and closes the list
+func (d *Dropdown) clickField(x float32) {
+	onArrow := x >= d.X+d.Width-d.arrowWidth()
+	switch {
+	case d.Editable && !onArrow:
+		d.cursor = d.runeIndexAt(x)
+	case d.IsOpen():
+		d.Close()
+	default:
+		d.Open()
+	}
+}
+
+func (d *Dropdown) updatePopupMouse(x, y float32) {
+	px, py, pw, ph, rows := d.popupLayout()
+	rowH := d.Height
+	scrollbar := len(d.filtered) > rows
+	onScrollbar := scrollbar && x >= px+pw-d.scrollbarWidth() && x < px+pw && y >= py && y < py+ph
+
+	d.hoverRow = -1
+	if d.popupContains(x, y) && !onScrollbar {
+		d.hoverRow = d.scroll + int((y-py)/rowH)
+	}
+
+	if _, wheelY := ebiten.Wheel(); wheelY != 0 && d.popupContains(x, y) {
+		d.scrollTo(d.scroll - int(math.Copysign(math.Max(1, math.Abs(wheelY)), wheelY)))
+	}
+
+	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) && d.popupContains(x, y) {
+		if onScrollbar {
+			thumbY, thumbH := d.thumbRect(py, ph, rows)
+			if y < thumbY || y >= thumbY+thumbH {
+				// Clicking the track centers the thumb there
+				thumbY = y - thumbH/2
+				d.scrollTo(int(math.Round(float64((thumbY - py) / ph * float32(len(d.filtered))))))
+			}
+			d.draggingThumb = true
+			d.dragOffset = y - thumbY
+		} else {
+			d.pressedRow = d.hoverRow
+		}
+	}
+
+	if d.draggingThumb {
+		if ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft) {
+			thumbY := y - d.dragOffset
+			d.scrollTo(int(math.Round(float64((thumbY - py) / ph * float32(len(d.filtered))))))
+		} else {
+			d.draggingThumb = false
+		}
+	}
+
+	if inpututil.IsMouseButtonJustReleased(ebiten.MouseButtonLeft) {
+		if d.pressedRow >= 0 && d.pressedRow == d.hoverRow && d.pressedRow < len(d.filtered) {
+			d.choose(d.filtered[d.pressedRow])
+		}
+		d.pressedRow = -1
+	}
+}
+
+func (d *Dropdown) handleKeyboard() {
+	now := time.Now()
+	mods := currentModifiers()
+	repeat := func(key ebiten.Key) bool {
+		fired, _ := repeatKeyState(d.heldKeys, key, now, 400*time.Millisecond, 50*time.Millisecond)
+		return fired
+	}
+
+	switch {
+	case mods&ModAlt != 0 && inpututil.IsKeyJustPressed(ebiten.KeyDown):
+		d.Open()
+		return
+	case mods&ModAlt != 0 && inpututil.IsKeyJustPressed(ebiten.KeyUp):
+		d.Close()
+		return
+	}
+
+	if d.Editable {
+		d.handleTyping(now, repeat)
+	} else {
+		d.handleTypeAhead(now)
+	}
+
+	rows := d.visibleRows()
+	if repeat(ebiten.KeyDown) {
+		d.moveHighlight(1)
+	}
+	if repeat(ebiten.KeyUp) {
+		d.moveHighlight(-1)
+	}
+	open := d.IsOpen()
+	if open && repeat(ebiten.KeyPageDown) {
+		d.moveHighlight(rows)
+	}
+	if open && repeat(ebiten.KeyPageUp) {
+		d.moveHighlight(-rows)
+	}
+	if open && !d.Editable {
+		if inpututil.IsKeyJustPressed(ebiten.KeyHome) {
+			d.moveHighlight(-len(d.filtered))
+		}
+		if inpututil.IsKeyJustPressed(ebiten.KeyEnd) {
+			d.moveHighlight(len(d.filtered))
+		}
+	}
+
+	confirm := inpututil.IsKeyJustPressed(ebiten.KeyEnter) ||
+		(!d.Editable && d.typeAhead == "" && inpututil.IsKeyJustPressed(ebiten.KeySpace))
+	switch {
+	case confirm && open && d.highlighted >= 0 && d.highlighted < len(d.filtered):
+		d.choose(d.filtered[d.highlighted])
+	case confirm && d.Editable:
+		d.commitText()
+		d.Close()
+	case confirm && !open:
+		d.Open()
+	case confirm:
+		d.Close()
+	}
+
+	if inpututil.IsKeyJustPressed(ebiten.KeyEscape) {
+		if d.IsOpen() {
+			d.Close()
+		} else {
+			d.Blur()
+		}
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyTab) {
+		if d.Editable {
+			d.commitText()
+		}
+		d.Close()
+	}
+}
+
+// moveHighlight moves in the open list, or changes the value of a closed dropdown
+func (d *Dropdown) moveHighlight(delta int) {
+	if !d.IsOpen() {
+		if d.Editable {
+			d.Open()
+			return
+		}
+		next := d.selected + delta
+		if d.selected < 0 {
+			next = 0
+		}
+		next = max(0, min(len(d.Options)-1, next))
+		if next >= 0 && next != d.selected {
+			d.choose(next)
+		}
+		return
+	}
+	if len(d.filtered) == 0 {
+		return
+	}
+	d.highlighted = max(0, min(len(d.filtered)-1, d.highlighted+delta))
+	d.ensureVisible()
+}
+
+// handleTypeAhead jumps to the first option starting with the typed letters.
+// Typing the same letter again goes to the next option with that letter.
+func (d *Dropdown) handleTypeAhead(now time.Time) {
+	chars := ebiten.AppendInputChars(nil)
+	if len(chars) == 0 {
+		if now.Sub(d.typeAheadAt) > typeAheadTimeout {
+			d.typeAhead = ""
+		}
+		return
+	}
+	if now.Sub(d.typeAheadAt) > typeAheadTimeout {
+		d.typeAhead = ""
+	}
+	d.typeAheadAt = now
+	for _, r := range chars {
+		if r == ' ' && d.typeAhead == "" {
+			continue
+		}
+		d.typeAhead += string(unicode.ToLower(r))
+	}
+	if d.typeAhead == "" {
+		return
+	}
+
+	current := d.selected
+	if d.IsOpen() && d.highlighted >= 0 && d.highlighted < len(d.filtered) {
+		current = d.filtered[d.highlighted]
+	}
+	prefix := d.typeAhead
+	start := max(current, 0)
+	if isRepeatedRune(prefix) {
+		// "aaa" cycles through the options starting with "a"
+		prefix = prefix[:len(string([]rune(prefix)[0]))]
+		start = current + 1
+	}
+
+	n := len(d.Options)
+	for step := 0; step < n; step++ {
+		i := (start + step) % n
+		if strings.HasPrefix(strings.ToLower(d.Options[i]), prefix) {
+			if d.IsOpen() {
+				for pos, index := range d.filtered {
+					if index == i {
+						d.highlighted = pos
+					}
+				}
+				d.ensureVisible()
+			} else {
+				d.choose(i)
+			}
+			return
+		}
+	}
+}
+
+func isRepeatedRune(s string) bool {
+	runes := []rune(s)
+	for _, r := range runes {
+		if r != runes[0] {
+			return false
+		}
+	}
+	return len(runes) > 1
+}
+
+// handleTyping edits the text of a combo box, the list opens filtered by it
+func (d *Dropdown) handleTyping(now time.Time, repeat func(ebiten.Key) bool) {
+	edited := false
+	for _, r := range ebiten.AppendInputChars(nil) {
+		if !unicode.IsPrint(r) {
+			continue
+		}
+		d.text = append(d.text[:d.cursor], append([]rune{r}, d.text[d.cursor:]...)...)
+		d.cursor++
+		edited = true
+	}
+	if repeat(ebiten.KeyBackspace) && d.cursor > 0 {
+		d.text = append(d.text[:d.cursor-1], d.text[d.cursor:]...)
+		d.cursor--
+		edited = true
+	}
+	if repeat(ebiten.KeyDelete) && d.cursor < len(d.text) {
+		d.text = append(d.text[:d.cursor], d.text[d.cursor+1:]...)
+		edited = true
+	}
+	if repeat(ebiten.KeyLeft) && d.cursor > 0 {
+		d.cursor--
+	}
+	if repeat(ebiten.KeyRight) && d.cursor < len(d.text) {
+		d.cursor++
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyHome) {
+		d.cursor = 0
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyEnd) {
+		d.cursor = len(d.text)
+	}
+
+	if edited {
+		d.counter = 0
+		if !d.IsOpen() {
+			d.Open()
+		}
+		d.filter(string(d.text))
+		d.highlighted = -1
+		if len(d.filtered) > 0 && len(d.text) > 0 {
+			d.highlighted = 0
+		}
+	}
+}
+
+// ---------------------
+
+func (d *Dropdown) maxVisible() int {
+	if d.MaxVisible > 0 {
+		return d.MaxVisible
+	}
+	return 8
+}
+
+func (d *Dropdown) padding() float32 {
+	return float32(d.FontSize) * 0.4
+}
+
+func (d *Dropdown) arrowWidth() float32 {
+	return d.Height * 0.8
+}
+
+func (d *Dropdown) scrollbarWidth() float32 {
+	return 8
+}
+
+func (d *Dropdown) fieldContains(x, y float32) bool {
+	return x >= d.X && x < d.X+d.Width && y >= d.Y && y < d.Y+d.Height
+}
+
+func (d *Dropdown) popupContains(x, y float32) bool {
+	px, py, pw, ph, _ := d.popupLayout()
+	return x >= px && x < px+pw && y >= py && y < py+ph
+}
+
+// popupLayout places the list under the field, or above it when it fits better,
+// with as many rows as there is room for
+func (d *Dropdown) popupLayout() (x, y, width, height float32, rows int) {
+	rowH := d.Height
+	rows = min(len(d.filtered), d.maxVisible())
+	if rows == 0 || rowH <= 0 {
+		return d.X, d.Y + d.Height, d.Width, 0, 0
+	}
+
+	below := float32(math.Inf(1))
+	if d.viewHeight > 0 {
+		below = d.viewHeight - (d.Y + d.Height)
+	}
+	above := d.Y
+	need := rowH * float32(rows)
+
+	y = d.Y + d.Height
+	if need > below {
+		if above >= need || above > below {
+			rows = max(1, min(rows, int(above/rowH)))
+			y = d.Y - rowH*float32(rows)
+		} else {
+			rows = max(1, min(rows, int(below/rowH)))
+		}
+	}
+
+	x, width = d.X, d.Width
+	if d.viewWidth > 0 && x+width > d.viewWidth {
+		x = max(0, d.viewWidth-width)
+	}
+	return x, y, width, rowH * float32(rows), rows
+}
+
+func (d *Dropdown) visibleRows() int {
+	_, _, _, _, rows := d.popupLayout()
+	return max(rows, 1)
+}
+
+func (d *Dropdown) scrollTo(scroll int) {
+	d.scroll = max(0, min(len(d.filtered)-d.visibleRows(), scroll))
+}
+
+func (d *Dropdown) ensureVisible() {
+	rows := d.visibleRows()
+	if d.highlighted >= 0 {
+		if d.highlighted < d.scroll {
+			d.scroll = d.highlighted
+		}
+		if d.highlighted >= d.scroll+rows {
+			d.scroll = d.highlighted - rows + 1
+		}
+	}
+	d.scrollTo(d.scroll)
+}
+
+// thumbRect returns the scrollbar thumb of a list at py with height ph
+func (d *Dropdown) thumbRect(py, ph float32, rows int) (y, height float32) {
+	total := float32(len(d.filtered))
+	height = max(ph*float32(rows)/total, 12)
+	y = py + (ph-height)*float32(d.scroll)/max(total-float32(rows), 1)
+	return y, height
+}
+
+func (d *Dropdown) runeIndexAt(x float32) int {
+	d.Text.SetFontSize(d.FontSize)
+	textX := d.X + d.padding()
+	for i := range d.text {
+		w, _ := d.Text.MeasureText(string(d.text[:i+1]))
+		prev, _ := d.Text.MeasureText(string(d.text[:i]))
+		if x < textX+float32(prev+w)/2 {
+			return i
+		}
+	}
+	return len(d.text)
+}
+
+// ---------------------
+
+func (d *Dropdown) Draw(screen *ebiten.Image) {
+	d.setView(screen)
+	d.Text.SetFontSize(d.FontSize)
+
+	background := utils.ColorOr(d.BackgroundColor, color.White)
+	fontColor := utils.ColorOr(d.FontColor, color.Black)
+	border := utils.ColorOr(d.BorderColor, color.RGBA{160, 160, 160, 255})
+	if d.Disabled {
+		disabled := utils.ColorOr(d.DisabledColor, color.RGBA{170, 170, 170, 255})
+		fontColor, border = disabled, disabled
+	} else if d.hasFocus {
+		border = utils.ColorOr(d.FocusColor, color.RGBA{0, 128, 255, 255})
+	}
+
+	vector.DrawFilledRect(screen, d.X, d.Y, d.Width, d.Height, background, false)
+	vector.StrokeRect(screen, d.X, d.Y, d.Width, d.Height, 2, border, false)
+
+	// Chevron pointing to where the list opens
+	ax := d.X + d.Width - d.arrowWidth()/2
+	ay := d.Y + d.Height/2
+	size := d.Height * 0.15
+	dir := float32(1)
+	if d.IsOpen() {
+		if _, py, _, _, _ := d.popupLayout(); py < d.Y {
+			dir = -1
+		}
+	}
+	vector.StrokeLine(screen, ax-size, ay-size*dir/2, ax, ay+size*dir/2, 2, fontColor, true)
+	vector.StrokeLine(screen, ax, ay+size*dir/2, ax+size, ay-size*dir/2, 2, fontColor, true)
+
+	label := d.Value()
+	labelColor := fontColor
+	if label == "" {
+		label = d.Placeholder
+		labelColor = utils.ColorOr(d.PlaceholderColor, color.RGBA{150, 150, 150, 255})
+	}
+	clip := image.Rect(int(d.X+2), int(d.Y), int(d.X+d.Width-d.arrowWidth()), int(d.Y+d.Height))
+	inner, ok := screen.SubImage(clip).(*ebiten.Image)
+	if !ok {
+		return
+	}
+	_, textH := d.Text.MeasureText("Ag")
+	textX := float64(d.X + d.padding())
+	textY := float64(d.Y) + (float64(d.Height)-textH)/2
+	d.Text.SetColor(labelColor)
+	d.Text.DrawText(inner, label, textX, textY)
+
+	if d.Editable && d.hasFocus && d.counter%60 < 30 {
+		w, _ := d.Text.MeasureText(string(d.text[:d.cursor]))
+		vector.DrawFilledRect(inner, float32(textX+w), float32(textY), 2, float32(textH), fontColor, false)
+	}
+}
+
+// drawPopup draws the open list, the overlay manager calls it after the page
+func (d *Dropdown) drawPopup(screen *ebiten.Image) {
+	d.setView(screen)
+	px, py, pw, ph, rows := d.popupLayout()
+	if rows == 0 {
+		return
+	}
+	d.Text.SetFontSize(d.FontSize)
+
+	background := utils.ColorOr(d.BackgroundColor, color.White)
+	fontColor := utils.ColorOr(d.FontColor, color.Black)
+	border := utils.ColorOr(d.BorderColor, color.RGBA{160, 160, 160, 255})
+	highlight := utils.ColorOr(d.HighlightColor, color.RGBA{0, 120, 215, 255})
+	hover := color.RGBA{210, 225, 245, 255}
+
+	vector.DrawFilledRect(screen, px+3, py+3, pw, ph, color.RGBA{0, 0, 0, 60}, false)
+	vector.DrawFilledRect(screen, px, py, pw, ph, background, false)
+
+	clip := image.Rect(int(px), int(py), int(px+pw), int(py+ph))
+	list, ok := screen.SubImage(clip).(*ebiten.Image)
+	if !ok {
+		return
+	}
+	rowH := d.Height
+	_, textH := d.Text.MeasureText("Ag")
+	for row := 0; row < rows; row++ {
+		pos := d.scroll + row
+		if pos >= len(d.filtered) {
+			break
+		}
+		index := d.filtered[pos]
+		ry := py + float32(row)*rowH
+		textColor := fontColor
+		switch {
+		case pos == d.highlighted:
+			vector.DrawFilledRect(list, px, ry, pw, rowH, highlight, false)
+			textColor = color.White
+		case pos == d.hoverRow:
+			vector.DrawFilledRect(list, px, ry, pw, rowH, hover, false)
+		}
+		if index == d.selected {
+			vector.DrawFilledRect(list, px, ry+rowH*0.2, 3, rowH*0.6, textColor, false)
+		}
+		d.Text.SetColor(textColor)
+		d.Text.DrawText(list, d.Options[index], float64(px+d.padding()), float64(ry)+(float64(rowH)-textH)/2)
+	}
+
+	if len(d.filtered) > rows {
+		barX := px + pw - d.scrollbarWidth()
+		vector.DrawFilledRect(list, barX, py, d.scrollbarWidth(), ph, color.RGBA{230, 230, 230, 255}, false)
+		thumbY, thumbH := d.thumbRect(py, ph, rows)
+		vector.DrawFilledRect(list, barX+1, thumbY, d.scrollbarWidth()-2, thumbH, color.RGBA{150, 150, 150, 255}, false)
+	}
+	vector.StrokeRect(screen, px, py, pw, ph, 1, border, false)
+}
+
+// setView remembers the size of the image the popup goes on
+func (d *Dropdown) setView(screen *ebiten.Image) {
+	bounds := screen.Bounds()
+	d.viewWidth = float32(bounds.Max.X)
+	d.viewHeight = float32(bounds.Max.Y)
+}
+
+// ---------------------
+
+// dropdownPopup is the overlay content of an open list. The field is part of it,
+// so a click on the field is not an outside click, and the dropdown keeps the
+// keyboard focus while its list is open.
+type dropdownPopup struct {
+	dropdown *Dropdown
+}
+
+func (p *dropdownPopup) Contains(x, y float32) bool {
+	return p.dropdown.popupContains(x, y) || p.dropdown.fieldContains(x, y)
+}
+
+func (p *dropdownPopup) Update(offsetX, offsetY float32, isAnimating bool) {
+	if isAnimating {
+		return
+	}
+	d := p.dropdown
+	cursorX, cursorY := ebiten.CursorPosition()
+	x, y := float32(cursorX)-offsetX, float32(cursorY)-offsetY
+	d.updatePopupMouse(x, y)
+	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) && d.fieldContains(x, y) {
+		d.clickField(x)
+	}
+}
+
+func (p *dropdownPopup) Draw(screen *ebiten.Image) {
+	p.dropdown.drawPopup(screen)
+}
+
+func (p *dropdownPopup) Focus() {
+	p.dropdown.Focus()
+}
+
+// Blur keeps the focus on the dropdown once its list closes
+func (p *dropdownPopup) Blur() {}
+
+func (p *dropdownPopup) IsFocused() bool {
+	return p.dropdown.hasFocus
+}
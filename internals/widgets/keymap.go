@@ -0,0 +1,594 @@
+package widgets
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Modifiers is a set of modifier keys held together with a key
+type Modifiers uint8
+
+const (
+	ModCtrl Modifiers = 1 << iota
+	ModAlt
+	ModShift
+	ModMeta // Cmd on macOS, the Windows key elsewhere
+)
+
+// ModPrimary is the modifier used for shortcuts like copy and paste:
+// Cmd on macOS and Ctrl everywhere else. "Mod" in a key sequence stands for it.
+var ModPrimary = primaryModifier()
+
+func primaryModifier() Modifiers {
+	if runtime.GOOS == "darwin" {
+		return ModMeta
+	}
+	return ModCtrl
+}
+
+// currentModifiers returns the modifier keys held right now
+func currentModifiers() Modifiers {
+	var mods Modifiers
+	if ebiten.IsKeyPressed(ebiten.KeyControl) {
+		mods |= ModCtrl
+	}
+	if ebiten.IsKeyPressed(ebiten.KeyAlt) {
+		mods |= ModAlt
+	}
+	if ebiten.IsKeyPressed(ebiten.KeyShift) {
+		mods |= ModShift
+	}
+	if ebiten.IsKeyPressed(ebiten.KeyMeta) {
+		mods |= ModMeta
+	}
+	return mods
+}
+
+// repeatKeyState tracks a held key in held. The key fires when it goes down,
+// then after delay, then every interval while it stays down.
+func repeatKeyState(held map[ebiten.Key]*KeyState, key ebiten.Key, now time.Time, delay, interval time.Duration) (fired, repeated bool) {
+	if !ebiten.IsKeyPressed(key) {
+		delete(held, key)
+		return false, false
+	}
+
+	state, exists := held[key]
+	if !exists {
+		held[key] = &KeyState{
+			PressedAt:  now,
+			NextRepeat: now.Add(delay),
+		}
+		return true, false
+	}
+	if now.Before(state.NextRepeat) {
+		return false, false
+	}
+	state.NextRepeat = state.NextRepeat.Add(interval)
+	// After a stall, do not fire a burst of repeats to catch up
+	if state.NextRepeat.Before(now) {
+		state.NextRepeat = now.Add(interval)
+	}
+	return true, true
+}
+
+// KeyStroke is one key pressed with a set of modifiers, e.g. Ctrl+Shift+Left
+type KeyStroke struct {
+	Key  ebiten.Key
+	Mods Modifiers
+}
+
+// String returns the stroke with the modifiers in a fixed order, e.g. "Ctrl+Shift+ArrowLeft"
+func (s KeyStroke) String() string {
+	var parts []string
+	if s.Mods&ModCtrl != 0 {
+		parts = append(parts, "Ctrl")
+	}
+	if s.Mods&ModAlt != 0 {
+		parts = append(parts, "Alt")
+	}
+	if s.Mods&ModShift != 0 {
+		parts = append(parts, "Shift")
+	}
+	if s.Mods&ModMeta != 0 {
+		if runtime.GOOS == "darwin" {
+			parts = append(parts, "Cmd")
+		} else {
+			parts = append(parts, "Meta")
+		}
+	}
+	parts = append(parts, s.Key.String())
+	return strings.Join(parts, "+")
+}
+
+var modifierNames = map[string]Modifiers{
+	"ctrl":    ModCtrl,
+	"control": ModCtrl,
+	"alt":     ModAlt,
+	"option":  ModAlt,
+	"opt":     ModAlt,
+	"shift":   ModShift,
+	"meta":    ModMeta,
+	"cmd":     ModMeta,
+	"command": ModMeta,
+	"super":   ModMeta,
+	"win":     ModMeta,
+}
+
+// keyAliases are short names accepted on top of the ebiten key names
+var keyAliases = map[string]ebiten.Key{
+	"left":     ebiten.KeyArrowLeft,
+	"right":    ebiten.KeyArrowRight,
+	"up":       ebiten.KeyArrowUp,
+	"down":     ebiten.KeyArrowDown,
+	"esc":      ebiten.KeyEscape,
+	"del":      ebiten.KeyDelete,
+	"ins":      ebiten.KeyInsert,
+	"return":   ebiten.KeyEnter,
+	"pgup":     ebiten.KeyPageUp,
+	"pgdn":     ebiten.KeyPageDown,
+	"/":        ebiten.KeySlash,
+	"\\":       ebiten.KeyBackslash,
+	",":        ebiten.KeyComma,
+	".":        ebiten.KeyPeriod,
+	";":        ebiten.KeySemicolon,
+	"'":        ebiten.KeyQuote,
+	"-":        ebiten.KeyMinus,
+	"=":        ebiten.KeyEqual,
+	"[":        ebiten.KeyBracketLeft,
+	"]":        ebiten.KeyBracketRight,
+	"`":        ebiten.KeyBackquote,
+	"backtick": ebiten.KeyBackquote,
+}
+
+var keyNames = buildKeyNames()
+
+func buildKeyNames() map[string]ebiten.Key {
+	names := map[string]ebiten.Key{}
+	for k := ebiten.Key(0); k <= ebiten.KeyMax; k++ {
+		names[strings.ToLower(k.String())] = k
+	}
+	// Digits can be written without the "Digit" prefix
+	for d := 0; d <= 9; d++ {
+		names[fmt.Sprint(d)] = ebiten.KeyDigit0 + ebiten.Key(d)
+	}
+	for alias, k := range keyAliases {
+		names[alias] = k
+	}
+	return names
+}
+
+// ParseKeyStroke parses a stroke like "Ctrl+Shift+Left" or "Mod+C".
+// Names are case insensitive, "Mod" is Cmd on macOS and Ctrl elsewhere.
+func ParseKeyStroke(s string) (KeyStroke, error) {
+	fields := strings.Split(strings.TrimSpace(s), "+")
+	// "Ctrl++" binds the plus key
+	if strings.HasSuffix(s, "++") {
+		fields = append(fields[:len(fields)-2], "+")
+	}
+
+	var stroke KeyStroke
+	for i, field := range fields {
+		name := strings.ToLower(strings.TrimSpace(field))
+		if i < len(fields)-1 {
+			if name == "mod" {
+				stroke.Mods |= ModPrimary
+				continue
+			}
+			mod, ok := modifierNames[name]
+			if !ok {
+				return KeyStroke{}, fmt.Errorf("unknown modifier %q in %q", field, s)
+			}
+			stroke.Mods |= mod
+			continue
+		}
+		if name == "+" {
+			// The plus sign is Shift+Equal on most layouts
+			stroke.Key = ebiten.KeyEqual
+			stroke.Mods |= ModShift
+			continue
+		}
+		key, ok := keyNames[name]
+		if !ok {
+			return KeyStroke{}, fmt.Errorf("unknown key %q in %q", field, s)
+		}
+		stroke.Key = key
+	}
+	return stroke, nil
+}
+
+// ParseKeySequence parses strokes separated by spaces, e.g. "Ctrl+K Ctrl+C"
+func ParseKeySequence(s string) ([]KeyStroke, error) {
+	fields := strings.Fields(s)
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("empty key sequence")
+	}
+	strokes := make([]KeyStroke, 0, len(fields))
+	for _, field := range fields {
+		stroke, err := ParseKeyStroke(field)
+		if err != nil {
+			return nil, err
+		}
+		strokes = append(strokes, stroke)
+	}
+	return strokes, nil
+}
+
+func sequenceString(strokes []KeyStroke) string {
+	parts := make([]string, len(strokes))
+	for i, stroke := range strokes {
+		parts[i] = stroke.String()
+	}
+	return strings.Join(parts, " ")
+}
+
+// Keymap maps key sequences to command names.
+// A sequence of more than one stroke is a chord, e.g. "Ctrl+X Ctrl+S".
+type Keymap struct {
+	bindings map[string]string
+	prefixes map[string]int // how many chords start with the sequence
+	keys     map[ebiten.Key]bool
+}
+
+func NewKeymap() *Keymap {
+	return &Keymap{
+		bindings: map[string]string{},
+		prefixes: map[string]int{},
+		keys:     map[ebiten.Key]bool{},
+	}
+}
+
+// Bind binds a key sequence to a command.
+// An empty command removes the binding.
+func (k *Keymap) Bind(sequence, command string) error {
+	strokes, err := ParseKeySequence(sequence)
+	if err != nil {
+		return err
+	}
+	k.bindStrokes(strokes, command)
+	return nil
+}
+
+// MustBind is Bind for the built-in keymaps, it panics on a malformed sequence
+func (k *Keymap) MustBind(sequence, command string) {
+	if err := k.Bind(sequence, command); err != nil {
+		panic(err)
+	}
+}
+
+func (k *Keymap) Unbind(sequence string) error {
+	return k.Bind(sequence, "")
+}
+
+func (k *Keymap) bindStrokes(strokes []KeyStroke, command string) {
+	name := sequenceString(strokes)
+	_, existed := k.bindings[name]
+	if command == "" {
+		if !existed {
+			return
+		}
+		delete(k.bindings, name)
+		k.updatePrefixes(strokes, -1)
+	} else {
+		k.bindings[name] = command
+		if !existed {
+			k.updatePrefixes(strokes, 1)
+		}
+	}
+	k.rebuildKeys()
+}
+
+func (k *Keymap) updatePrefixes(strokes []KeyStroke, delta int) {
+	for i := 1; i < len(strokes); i++ {
+		prefix := sequenceString(strokes[:i])
+		k.prefixes[prefix] += delta
+		if k.prefixes[prefix] <= 0 {
+			delete(k.prefixes, prefix)
+		}
+	}
+}
+
+func (k *Keymap) rebuildKeys() {
+	k.keys = map[ebiten.Key]bool{}
+	for name := range k.bindings {
+		strokes, _ := ParseKeySequence(name)
+		for _, stroke := range strokes {
+			k.keys[stroke.Key] = true
+		}
+	}
+}
+
+// Lookup returns the command bound to the strokes and whether the strokes
+// are the start of a longer chord. An exact binding wins over a chord prefix.
+func (k *Keymap) Lookup(strokes []KeyStroke) (command string, isPrefix bool) {
+	name := sequenceString(strokes)
+	return k.bindings[name], k.prefixes[name] > 0
+}
+
+// Keys returns every key used by a binding, these are the keys the widget listens to
+func (k *Keymap) Keys() []ebiten.Key {
+	keys := make([]ebiten.Key, 0, len(k.keys))
+	for key := range k.keys {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+	return keys
+}
+
+// Bindings returns a copy of the sequence to command map
+func (k *Keymap) Bindings() map[string]string {
+	bindings := make(map[string]string, len(k.bindings))
+	for sequence, command := range k.bindings {
+		bindings[sequence] = command
+	}
+	return bindings
+}
+
+// SequencesFor returns the sequences bound to a command, useful to show shortcuts in menus
+func (k *Keymap) SequencesFor(command string) []string {
+	var sequences []string
+	for sequence, c := range k.bindings {
+		if c == command {
+			sequences = append(sequences, sequence)
+		}
+	}
+	sort.Strings(sequences)
+	return sequences
+}
+
+func (k *Keymap) Clone() *Keymap {
+	clone := NewKeymap()
+	for sequence, command := range k.bindings {
+		clone.bindings[sequence] = command
+	}
+	for prefix, count := range k.prefixes {
+		clone.prefixes[prefix] = count
+	}
+	for key := range k.keys {
+		clone.keys[key] = true
+	}
+	return clone
+}
+
+// ---------------------
+
+// keymapFile is the JSON layout of a keymap file:
+//
+//	{
+//	  "extends": "emacs",
+//	  "bindings": { "Ctrl+K Ctrl+U": "edit.deleteLine", "Ctrl+Y": "" }
+//	}
+//
+// "extends" names a built-in keymap (default, emacs, vim) or is empty to start blank.
+// An empty command removes a binding inherited from the base keymap.
+type keymapFile struct {
+	Extends  string            `json:"extends"`
+	Bindings map[string]string `json:"bindings"`
+}
+
+// LoadKeymap reads a keymap from a JSON file
+func LoadKeymap(path string) (*Keymap, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read keymap: %w", err)
+	}
+	keymap, err := ParseKeymap(data)
+	if err != nil {
+		return nil, fmt.Errorf("keymap %s: %w", path, err)
+	}
+	return keymap, nil
+}
+
+// ParseKeymap builds a keymap from the JSON layout described on keymapFile
+func ParseKeymap(data []byte) (*Keymap, error) {
+	var file keymapFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, err
+	}
+
+	keymap := NewKeymap()
+	if file.Extends != "" {
+		base, ok := KeymapByName(file.Extends)
+		if !ok {
+			return nil, fmt.Errorf("unknown base keymap %q", file.Extends)
+		}
+		keymap = base
+	}
+
+	// Apply in a stable order so errors are reproducible
+	sequences := make([]string, 0, len(file.Bindings))
+	for sequence := range file.Bindings {
+		sequences = append(sequences, sequence)
+	}
+	sort.Strings(sequences)
+	for _, sequence := range sequences {
+		if err := keymap.Bind(sequence, file.Bindings[sequence]); err != nil {
+			return nil, err
+		}
+	}
+	return keymap, nil
+}
+
+// KeymapByName returns a fresh copy of a built-in keymap: "default", "emacs" or "vim"
+func KeymapByName(name string) (*Keymap, bool) {
+	switch strings.ToLower(name) {
+	case "default":
+		return DefaultKeymap(), true
+	case "emacs":
+		return EmacsKeymap(), true
+	case "vim":
+		return VimKeymap(), true
+	}
+	return nil, false
+}
+
+// DefaultKeymap returns the usual text editing bindings for the current platform
+func DefaultKeymap() *Keymap {
+	k := NewKeymap()
+
+	for _, b := range [][2]string{
+		{"Left", "cursor.left"},
+		{"Right", "cursor.right"},
+		{"Up", "cursor.up"},
+		{"Down", "cursor.down"},
+		{"Home", "cursor.lineStart"},
+		{"End", "cursor.lineEnd"},
+		{"Shift+Left", "select.left"},
+		{"Shift+Right", "select.right"},
+		{"Shift+Up", "select.up"},
+		{"Shift+Down", "select.down"},
+		{"Shift+Home", "select.lineStart"},
+		{"Shift+End", "select.lineEnd"},
+		{"PageUp", "view.pageUp"},
+		{"PageDown", "view.pageDown"},
+
+		{"Backspace", "edit.backspace"},
+		{"Shift+Backspace", "edit.backspace"},
+		{"Delete", "edit.delete"},
+		{"Enter", "edit.newline"},
+		{"NumpadEnter", "edit.newline"},
+		{"Tab", "edit.indent"},
+
+		{"Mod+C", "edit.copy"},
+		{"Mod+X", "edit.cut"},
+		{"Mod+V", "edit.paste"},
+		{"Mod+Z", "edit.undo"},
+		{"Mod+Shift+Z", "edit.redo"},
+		{"Mod+A", "select.all"},
+		{"Mod+F", "find.open"},
+		{"Mod+Alt+F", "find.replace"},
+		{"Mod+D", "caret.addNextOccurrence"},
+		{"Escape", "caret.clear"},
+	} {
+		k.MustBind(b[0], b[1])
+	}
+
+	if runtime.GOOS == "darwin" {
+		for _, b := range [][2]string{
+			{"Alt+Left", "cursor.wordLeft"},
+			{"Alt+Right", "cursor.wordRight"},
+			{"Alt+Shift+Left", "select.wordLeft"},
+			{"Alt+Shift+Right", "select.wordRight"},
+			{"Cmd+Left", "cursor.lineStart"},
+			{"Cmd+Right", "cursor.lineEnd"},
+			{"Cmd+Shift+Left", "select.lineStart"},
+			{"Cmd+Shift+Right", "select.lineEnd"},
+			{"Cmd+Up", "cursor.documentStart"},
+			{"Cmd+Down", "cursor.documentEnd"},
+			{"Cmd+Shift+Up", "select.documentStart"},
+			{"Cmd+Shift+Down", "select.documentEnd"},
+			{"Alt+Backspace", "edit.deleteWordLeft"},
+			{"Alt+Delete", "edit.deleteWordRight"},
+			{"Cmd+G", "find.next"},
+			{"Cmd+Shift+G", "find.previous"},
+		} {
+			k.MustBind(b[0], b[1])
+		}
+		return k
+	}
+
+	for _, b := range [][2]string{
+		{"Ctrl+Left", "cursor.wordLeft"},
+		{"Ctrl+Right", "cursor.wordRight"},
+		{"Ctrl+Shift+Left", "select.wordLeft"},
+		{"Ctrl+Shift+Right", "select.wordRight"},
+		{"Ctrl+Up", "cursor.logicalLineStart"},
+		{"Ctrl+Down", "cursor.logicalLineEnd"},
+		{"Ctrl+Shift+Up", "select.logicalLineStart"},
+		{"Ctrl+Shift+Down", "select.logicalLineEnd"},
+		{"Ctrl+Home", "cursor.documentStart"},
+		{"Ctrl+End", "cursor.documentEnd"},
+		{"Ctrl+Shift+Home", "select.documentStart"},
+		{"Ctrl+Shift+End", "select.documentEnd"},
+		{"Ctrl+Backspace", "edit.deleteWordLeft"},
+		{"Ctrl+Delete", "edit.deleteWordRight"},
+		{"Ctrl+Y", "edit.redo"},
+		{"Ctrl+H", "find.replace"},
+	} {
+		k.MustBind(b[0], b[1])
+	}
+	return k
+}
+
+// EmacsKeymap adds the common Emacs movement and kill/yank bindings on top of DefaultKeymap.
+// Ctrl+X becomes a chord prefix, so cut moves to Ctrl+W.
+func EmacsKeymap() *Keymap {
+	k := DefaultKeymap()
+	for _, b := range [][2]string{
+		{"Ctrl+X", ""},
+		{"Ctrl+H", ""},
+
+		{"Ctrl+F", "cursor.right"},
+		{"Ctrl+B", "cursor.left"},
+		{"Ctrl+N", "cursor.down"},
+		{"Ctrl+P", "cursor.up"},
+		{"Ctrl+A", "cursor.logicalLineStart"},
+		{"Ctrl+E", "cursor.logicalLineEnd"},
+		{"Alt+F", "cursor.wordRight"},
+		{"Alt+B", "cursor.wordLeft"},
+		{"Alt+Shift+Comma", "cursor.documentStart"},
+		{"Alt+Shift+Period", "cursor.documentEnd"},
+		{"Ctrl+V", "view.pageDown"},
+		{"Alt+V", "view.pageUp"},
+
+		{"Ctrl+D", "edit.delete"},
+		{"Alt+D", "edit.deleteWordRight"},
+		{"Alt+Backspace", "edit.deleteWordLeft"},
+		{"Ctrl+K", "edit.killLine"},
+		{"Ctrl+W", "edit.cut"},
+		{"Alt+W", "edit.copy"},
+		{"Ctrl+Y", "edit.paste"},
+		{"Ctrl+Slash", "edit.undo"},
+		{"Ctrl+Shift+Minus", "edit.undo"},
+		{"Ctrl+X U", "edit.undo"},
+		{"Ctrl+X H", "select.all"},
+		{"Ctrl+S", "find.open"},
+		{"Ctrl+R", "find.previous"},
+		{"Alt+Shift+5", "find.replace"},
+		{"Ctrl+G", "caret.clear"},
+	} {
+		k.MustBind(b[0], b[1])
+	}
+	return k
+}
+
+// VimKeymap adds vim style motions on top of DefaultKeymap.
+// The text area has no modes, so Alt plays the role of normal mode.
+func VimKeymap() *Keymap {
+	k := DefaultKeymap()
+	for _, b := range [][2]string{
+		{"Alt+H", "cursor.left"},
+		{"Alt+J", "cursor.down"},
+		{"Alt+K", "cursor.up"},
+		{"Alt+L", "cursor.right"},
+		{"Alt+W", "cursor.wordRight"},
+		{"Alt+B", "cursor.wordLeft"},
+		{"Alt+0", "cursor.logicalLineStart"},
+		{"Alt+Shift+4", "cursor.logicalLineEnd"},
+		{"Alt+G Alt+G", "cursor.documentStart"},
+		{"Alt+Shift+G", "cursor.documentEnd"},
+		{"Ctrl+F", "view.pageDown"},
+		{"Ctrl+B", "view.pageUp"},
+
+		{"Alt+X", "edit.delete"},
+		{"Alt+D Alt+D", "edit.deleteLine"},
+		{"Alt+D Alt+W", "edit.deleteWordRight"},
+		{"Alt+Shift+D", "edit.killLine"},
+		{"Alt+P", "edit.paste"},
+		{"Alt+Y Alt+Y", "edit.copyLine"},
+		{"Alt+U", "edit.undo"},
+		{"Ctrl+R", "edit.redo"},
+		{"Alt+Slash", "find.open"},
+		{"Alt+N", "find.next"},
+		{"Alt+Shift+N", "find.previous"},
+	} {
+		k.MustBind(b[0], b[1])
+	}
+	return k
+}
@@ -0,0 +1,492 @@
+package widgets
+
+import (
+	"image/color"
+	"log"
+	"time"
+
+	"example.com/menu/internals/utils"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+// MenuItem is one entry of a Menu: a command, a checkable option, a submenu or a separator
+type MenuItem struct {
+	Label string
+	// Shortcut is an accelerator like "Mod+S" or "Ctrl+Shift+Z", see ParseKeyStroke.
+	// It runs the item while its menu is closed too, and is shown on the right.
+	Shortcut  string
+	Checkable bool
+	Checked   bool
+	Disabled  bool
+	Separator bool
+	Submenu   *Menu
+	// OnSelect runs when the item is chosen, after Checked has been flipped
+	OnSelect func(item *MenuItem)
+
+	stroke      KeyStroke
+	strokeValid bool
+	parsed      bool
+}
+
+func NewMenuItem(label string, onSelect func(item *MenuItem)) *MenuItem {
+	return &MenuItem{Label: label, OnSelect: onSelect}
+}
+
+func NewCheckMenuItem(label string, checked bool, onSelect func(item *MenuItem)) *MenuItem {
+	return &MenuItem{Label: label, Checkable: true, Checked: checked, OnSelect: onSelect}
+}
+
+func NewMenuSeparator() *MenuItem {
+	return &MenuItem{Separator: true}
+}
+
+func NewSubmenu(label string, items ...*MenuItem) *MenuItem {
+	return &MenuItem{Label: label, Submenu: NewMenu(items...)}
+}
+
+// WithShortcut sets the accelerator and returns the item, for use in NewMenu lists
+func (item *MenuItem) WithShortcut(shortcut string) *MenuItem {
+	item.Shortcut = shortcut
+	item.parsed = false
+	return item
+}
+
+func (item *MenuItem) selectable() bool {
+	return !item.Separator && !item.Disabled
+}
+
+// keyStroke parses Shortcut once, a bad shortcut is logged and ignored
+func (item *MenuItem) keyStroke() (KeyStroke, bool) {
+	if !item.parsed {
+		item.parsed = true
+		item.strokeValid = false
+		if item.Shortcut != "" {
+			stroke, err := ParseKeyStroke(item.Shortcut)
+			if err != nil {
+				log.Printf("menu item %q: %v", item.Label, err)
+			} else {
+				item.stroke, item.strokeValid = stroke, true
+			}
+		}
+	}
+	return item.stroke, item.strokeValid
+}
+
+// shortcutText is the accelerator as shown in the menu, e.g. "Ctrl+S" or "Cmd+S"
+func (item *MenuItem) shortcutText() string {
+	if stroke, ok := item.keyStroke(); ok {
+		return stroke.String()
+	}
+	return ""
+}
+
+// activate flips a checkable item and runs it
+func (item *MenuItem) activate() {
+	if item.Checkable {
+		item.Checked = !item.Checked
+	}
+	if item.OnSelect != nil {
+		item.OnSelect(item)
+	}
+}
+
+// ---------------------
+
+// Menu is a list of items, shown by a ContextMenu or a MenuBar
+type Menu struct {
+	Items []*MenuItem
+}
+
+func NewMenu(items ...*MenuItem) *Menu {
+	return &Menu{Items: items}
+}
+
+func (m *Menu) Add(items ...*MenuItem) {
+	m.Items = append(m.Items, items...)
+}
+
+// HandleShortcuts runs the first enabled item, submenus included, whose
+// accelerator was just pressed. It reports whether one ran.
+func (m *Menu) HandleShortcuts() bool {
+	mods := currentModifiers()
+	for _, item := range m.Items {
+		if item.Disabled || item.Separator {
+			continue
+		}
+		if item.Submenu != nil {
+			if item.Submenu.HandleShortcuts() {
+				return true
+			}
+			continue
+		}
+		if stroke, ok := item.keyStroke(); ok && stroke.Mods == mods && inpututil.IsKeyJustPressed(stroke.Key) {
+			item.activate()
+			return true
+		}
+	}
+	return false
+}
+
+// nextSelectable returns the next selectable item from index in dir, -1 when there is none
+func (m *Menu) nextSelectable(index, dir int) int {
+	n := len(m.Items)
+	for step := 1; step <= n; step++ {
+		i := ((index+dir*step)%n + n) % n
+		if m.Items[i].selectable() {
+			return i
+		}
+	}
+	return -1
+}
+
+// ---------------------
+
+// MenuStyle is the look shared by the popups of a ContextMenu or MenuBar
+type MenuStyle struct {
+	Text            TextRenderer
+	FontSize        float64
+	FontColor       color.Color
+	DisabledColor   color.Color
+	BackgroundColor color.Color
+	HighlightColor  color.Color
+	HighlightFont   color.Color
+	BorderColor     color.Color
+	SeparatorColor  color.Color
+	ShortcutColor   color.Color
+	SubmenuDelay    time.Duration // hover time before a submenu opens or closes
+	MinWidth        float32
+}
+
+func newMenuStyle(text TextRenderer, fontSize float64) *MenuStyle {
+	return &MenuStyle{
+		Text:         text,
+		FontSize:     fontSize,
+		SubmenuDelay: 250 * time.Millisecond,
+		MinWidth:     140,
+	}
+}
+
+func (s *MenuStyle) itemHeight() float32      { return float32(s.FontSize) * 1.8 }
+func (s *MenuStyle) separatorHeight() float32 { return float32(s.FontSize) * 0.7 }
+func (s *MenuStyle) padding() float32         { return float32(s.FontSize) * 0.6 }
+
+// menuLevel is one open menu of a popup chain, the root first
+type menuLevel struct {
+	menu                *Menu
+	x, y, width, height float32
+	// anchor is where the menu wants to open, flipLeft is the x to open at when there is no room on the right
+	anchorX, anchorY float32
+	flipLeft         float32
+	highlighted      int
+	hoverItem        int
+	hoverSince       time.Time
+	placed           bool
+}
+
+func (l *menuLevel) itemAt(style *MenuStyle, x, y float32) int {
+	if x < l.x || x >= l.x+l.width || y < l.y || y >= l.y+l.height {
+		return -1
+	}
+	top := l.y + style.padding()/2
+	for i, item := range l.menu.Items {
+		h := style.itemHeight()
+		if item.Separator {
+			h = style.separatorHeight()
+		}
+		if y >= top && y < top+h {
+			return i
+		}
+		top += h
+	}
+	return -1
+}
+
+func (l *menuLevel) itemTop(style *MenuStyle, index int) float32 {
+	top := l.y + style.padding()/2
+	for i := 0; i < index; i++ {
+		if l.menu.Items[i].Separator {
+			top += style.separatorHeight()
+		} else {
+			top += style.itemHeight()
+		}
+	}
+	return top
+}
+
+// menuPopup is a menu with its open submenus, shown as one overlay
+type menuPopup struct {
+	style  *MenuStyle
+	levels []*menuLevel
+	// close closes the overlay, onLeft and onRight get the arrows the root menu does not use
+	close   func()
+	onLeft  func()
+	onRight func()
+}
+
+func (p *menuPopup) open(menu *Menu, x, y float32) {
+	p.levels = []*menuLevel{{menu: menu, anchorX: x, anchorY: y, flipLeft: x, highlighted: -1, hoverItem: -1}}
+}
+
+// openSubmenu opens the submenu of the item of a level, closing the deeper ones
+func (p *menuPopup) openSubmenu(level, index int) {
+	parent := p.levels[level]
+	item := parent.menu.Items[index]
+	p.levels = p.levels[:level+1]
+	if item.Submenu == nil || item.Disabled {
+		return
+	}
+	p.levels = append(p.levels, &menuLevel{
+		menu:        item.Submenu,
+		anchorX:     parent.x + parent.width - 2,
+		anchorY:     parent.itemTop(p.style, index) - p.style.padding()/2,
+		flipLeft:    parent.x + 2,
+		highlighted: -1,
+		hoverItem:   -1,
+	})
+}
+
+func (p *menuPopup) choose(item *MenuItem) {
+	p.close()
+	item.activate()
+}
+
+func (p *menuPopup) Contains(x, y float32) bool {
+	for _, l := range p.levels {
+		if l.placed && x >= l.x && x < l.x+l.width && y >= l.y && y < l.y+l.height {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *menuPopup) Update(offsetX, offsetY float32, isAnimating bool) {
+	if isAnimating || len(p.levels) == 0 || !p.levels[0].placed {
+		return
+	}
+	cursorX, cursorY := ebiten.CursorPosition()
+	x, y := float32(cursorX)-offsetX, float32(cursorY)-offsetY
+	now := time.Now()
+
+	// The deepest menu under the cursor gets the hover
+	for depth := len(p.levels) - 1; depth >= 0; depth-- {
+		l := p.levels[depth]
+		index := l.itemAt(p.style, x, y)
+		if index < 0 {
+			continue
+		}
+		item := l.menu.Items[index]
+		if index != l.hoverItem {
+			l.hoverItem, l.hoverSince = index, now
+		}
+		if item.selectable() {
+			l.highlighted = index
+		}
+
+		// Submenus follow the hover after a delay, so a diagonal move to a submenu does not close it
+		if now.Sub(l.hoverSince) >= p.style.SubmenuDelay {
+			hasChild := depth+1 < len(p.levels)
+			if item.Submenu != nil && item.selectable() && (!hasChild || p.levels[depth+1].menu != item.Submenu) {
+				p.openSubmenu(depth, index)
+			} else if item.Submenu == nil && hasChild {
+				p.levels = p.levels[:depth+1]
+			}
+		}
+
+		if inpututil.IsMouseButtonJustReleased(ebiten.MouseButtonLeft) && item.selectable() {
+			if item.Submenu != nil {
+				p.openSubmenu(depth, index)
+			} else {
+				p.choose(item)
+			}
+			return
+		}
+		break
+	}
+
+	p.handleKeyboard()
+}
+
+func (p *menuPopup) handleKeyboard() {
+	depth := len(p.levels) - 1
+	l := p.levels[depth]
+	highlighted := -1
+	if l.highlighted >= 0 && l.highlighted < len(l.menu.Items) {
+		highlighted = l.highlighted
+	}
+
+	switch {
+	case inpututil.IsKeyJustPressed(ebiten.KeyArrowDown):
+		l.highlighted = l.menu.nextSelectable(highlighted, 1)
+	case inpututil.IsKeyJustPressed(ebiten.KeyArrowUp):
+		if highlighted < 0 {
+			highlighted = 0
+		}
+		l.highlighted = l.menu.nextSelectable(highlighted, -1)
+	case inpututil.IsKeyJustPressed(ebiten.KeyArrowRight):
+		if highlighted >= 0 && l.menu.Items[highlighted].Submenu != nil && l.menu.Items[highlighted].selectable() {
+			p.openSubmenu(depth, highlighted)
+			child := p.levels[len(p.levels)-1]
+			child.highlighted = child.menu.nextSelectable(-1, 1)
+		} else if p.onRight != nil {
+			p.onRight()
+		}
+	case inpututil.IsKeyJustPressed(ebiten.KeyArrowLeft):
+		if depth > 0 {
+			p.levels = p.levels[:depth]
+		} else if p.onLeft != nil {
+			p.onLeft()
+		}
+	case inpututil.IsKeyJustPressed(ebiten.KeyEnter) || inpututil.IsKeyJustPressed(ebiten.KeyNumpadEnter) || inpututil.IsKeyJustPressed(ebiten.KeySpace):
+		if highlighted < 0 {
+			return
+		}
+		item := l.menu.Items[highlighted]
+		if item.Submenu != nil {
+			p.openSubmenu(depth, highlighted)
+			child := p.levels[len(p.levels)-1]
+			child.highlighted = child.menu.nextSelectable(-1, 1)
+			return
+		}
+		p.choose(item)
+	case inpututil.IsKeyJustPressed(ebiten.KeyEscape):
+		// One level at a time, the root closes the popup
+		if depth > 0 {
+			p.levels = p.levels[:depth]
+		} else {
+			p.close()
+		}
+	}
+}
+
+// layout sizes the menus and keeps them inside the area, submenus open on the left when there is no room on the right
+func (p *menuPopup) layout(areaWidth, areaHeight float32) {
+	s := p.style
+	s.Text.SetFontSize(s.FontSize)
+	padding := s.padding()
+
+	for depth, l := range p.levels {
+		if depth > 0 {
+			parent := p.levels[depth-1]
+			l.anchorX = parent.x + parent.width - 2
+			l.flipLeft = parent.x + 2
+		}
+
+		var labelWidth, shortcutWidth float32
+		l.height = padding
+		hasSubmenu := false
+		for _, item := range l.menu.Items {
+			if item.Separator {
+				l.height += s.separatorHeight()
+				continue
+			}
+			l.height += s.itemHeight()
+			w, _ := s.Text.MeasureText(item.Label)
+			labelWidth = max(labelWidth, float32(w))
+			if shortcut := item.shortcutText(); shortcut != "" {
+				w, _ := s.Text.MeasureText(shortcut)
+				shortcutWidth = max(shortcutWidth, float32(w))
+			}
+			hasSubmenu = hasSubmenu || item.Submenu != nil
+		}
+
+		checkColumn := float32(s.FontSize) * 1.4
+		l.width = checkColumn + labelWidth + padding*2
+		if shortcutWidth > 0 {
+			l.width += shortcutWidth + float32(s.FontSize)*2
+		}
+		if hasSubmenu {
+			l.width += float32(s.FontSize)
+		}
+		l.width = max(l.width, s.MinWidth)
+
+		l.x, l.y = l.anchorX, l.anchorY
+		if l.x+l.width > areaWidth {
+			if depth > 0 {
+				l.x = l.flipLeft - l.width
+			} else {
+				l.x = areaWidth - l.width
+			}
+		}
+		if l.y+l.height > areaHeight {
+			l.y = areaHeight - l.height
+		}
+		l.x = max(l.x, 0)
+		l.y = max(l.y, 0)
+		l.placed = true
+	}
+}
+
+func (p *menuPopup) Draw(screen *ebiten.Image) {
+	if len(p.levels) == 0 {
+		return
+	}
+	bounds := screen.Bounds()
+	p.layout(float32(bounds.Dx()), float32(bounds.Dy()))
+
+	s := p.style
+	fontColor := utils.ColorOr(s.FontColor, color.Black)
+	disabled := utils.ColorOr(s.DisabledColor, color.RGBA{160, 160, 160, 255})
+	background := utils.ColorOr(s.BackgroundColor, color.RGBA{248, 248, 248, 255})
+	highlight := utils.ColorOr(s.HighlightColor, color.RGBA{0, 120, 215, 255})
+	highlightFont := utils.ColorOr(s.HighlightFont, color.White)
+	border := utils.ColorOr(s.BorderColor, color.RGBA{150, 150, 150, 255})
+	separator := utils.ColorOr(s.SeparatorColor, color.RGBA{210, 210, 210, 255})
+	shortcutColor := utils.ColorOr(s.ShortcutColor, color.RGBA{110, 110, 110, 255})
+	padding := s.padding()
+	checkColumn := float32(s.FontSize) * 1.4
+
+	for depth, l := range p.levels {
+		// A small shadow sets the menus apart from each other
+		vector.DrawFilledRect(screen, l.x+3, l.y+3, l.width, l.height, color.RGBA{0, 0, 0, 50}, false)
+		vector.DrawFilledRect(screen, l.x, l.y, l.width, l.height, background, false)
+		vector.StrokeRect(screen, l.x, l.y, l.width, l.height, 1, border, false)
+
+		for i, item := range l.menu.Items {
+			top := l.itemTop(s, i)
+			if item.Separator {
+				mid := top + s.separatorHeight()/2
+				vector.StrokeLine(screen, l.x+padding, mid, l.x+l.width-padding, mid, 1, separator, false)
+				continue
+			}
+			h := s.itemHeight()
+
+			labelColor, accelColor := fontColor, shortcutColor
+			// The item leading to the next open level stays highlighted
+			onPath := depth+1 < len(p.levels) && item.Submenu == p.levels[depth+1].menu
+			switch {
+			case item.Disabled:
+				labelColor, accelColor = disabled, disabled
+			case i == l.highlighted || onPath:
+				vector.DrawFilledRect(screen, l.x+1, top, l.width-2, h, highlight, false)
+				labelColor, accelColor = highlightFont, highlightFont
+			}
+
+			_, textH := s.Text.MeasureText(item.Label)
+			textY := float64(top + (h-float32(textH))/2)
+
+			if item.Checkable && item.Checked {
+				cx, cy := l.x+checkColumn/2+padding/2, top+h/2
+				size := float32(s.FontSize) * 0.3
+				vector.StrokeLine(screen, cx-size, cy, cx-size/3, cy+size*0.7, 2, labelColor, true)
+				vector.StrokeLine(screen, cx-size/3, cy+size*0.7, cx+size, cy-size*0.7, 2, labelColor, true)
+			}
+
+			s.Text.SetColor(labelColor)
+			s.Text.DrawText(screen, item.Label, float64(l.x+padding+checkColumn), textY)
+
+			right := l.x + l.width - padding
+			if item.Submenu != nil {
+				ax, ay := right-float32(s.FontSize)*0.3, top+h/2
+				size := float32(s.FontSize) * 0.25
+				vector.StrokeLine(screen, ax-size, ay-size, ax, ay, 1.5, labelColor, true)
+				vector.StrokeLine(screen, ax, ay, ax-size, ay+size, 1.5, labelColor, true)
+			} else if shortcut := item.shortcutText(); shortcut != "" {
+				w, _ := s.Text.MeasureText(shortcut)
+				s.Text.SetColor(accelColor)
+				s.Text.DrawText(screen, shortcut, float64(right)-w, textY)
+			}
+		}
+	}
+}
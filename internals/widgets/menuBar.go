@@ -0,0 +1,231 @@
+package widgets
+
+import (
+	"image/color"
+
+	"example.com/menu/internals/overlay"
+	"example.com/menu/internals/utils"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+// MenuBarEntry is a title of the bar with the menu it opens
+type MenuBarEntry struct {
+	Title    string
+	Menu     *Menu
+	Disabled bool
+}
+
+// MenuBar is a row of titles at the top of a page, each opening a Menu below it.
+//
+// A click opens a menu; while one is open, hovering another title switches to
+// it and Left / Right move between them. F10 opens the first menu from the
+// keyboard. The accelerators of every menu work while the bar is closed.
+type MenuBar struct {
+	X, Y          float32
+	Width, Height float32
+	Entries       []*MenuBarEntry
+	Style         *MenuStyle
+	BarColor      color.Color
+	TitleColor    color.Color
+
+	overlays *overlay.Manager
+	popup    *menuPopup
+	overlay  *overlay.Overlay
+	open     int
+	hovered  int
+}
+
+func NewMenuBar(x, y, width float32, overlays *overlay.Manager, text TextRenderer, fontSize float64) *MenuBar {
+	b := &MenuBar{
+		X:        x,
+		Y:        y,
+		Width:    width,
+		Height:   float32(fontSize) * 1.8,
+		Style:    newMenuStyle(text, fontSize),
+		overlays: overlays,
+		open:     -1,
+		hovered:  -1,
+	}
+	b.popup = &menuPopup{style: b.Style, close: b.Close, onLeft: func() { b.step(-1) }, onRight: func() { b.step(1) }}
+	// The bar is part of the overlay while a menu is open, so a click on another title is not an outside click
+	b.overlay = overlay.New(overlay.Popup, &menuBarPopup{bar: b})
+	b.overlay.DismissOnEscape = false
+	b.overlay.OnClose = func() { b.open = -1 }
+	return b
+}
+
+// Add appends a title and returns its entry
+func (b *MenuBar) Add(title string, menu *Menu) *MenuBarEntry {
+	entry := &MenuBarEntry{Title: title, Menu: menu}
+	b.Entries = append(b.Entries, entry)
+	return entry
+}
+
+// OpenMenu opens the menu of an entry, the root item highlighted when byKeyboard
+func (b *MenuBar) OpenMenu(index int, byKeyboard bool) {
+	if index < 0 || index >= len(b.Entries) || b.Entries[index].Disabled {
+		return
+	}
+	b.open = index
+	x, _, _, _ := b.titleRect(index)
+	b.popup.open(b.Entries[index].Menu, x, b.Y+b.Height)
+	if byKeyboard {
+		root := b.popup.levels[0]
+		root.highlighted = root.menu.nextSelectable(-1, 1)
+	}
+	b.overlays.Open(b.overlay)
+}
+
+func (b *MenuBar) Close() {
+	b.overlay.Close()
+	b.open = -1
+}
+
+func (b *MenuBar) IsOpen() bool {
+	return b.overlay.IsOpen()
+}
+
+// step opens the next enabled menu to the left or right
+func (b *MenuBar) step(dir int) {
+	n := len(b.Entries)
+	for i := 1; i <= n; i++ {
+		next := ((b.open+dir*i)%n + n) % n
+		if !b.Entries[next].Disabled {
+			b.OpenMenu(next, true)
+			return
+		}
+	}
+}
+
+func (b *MenuBar) titleRect(index int) (float32, float32, float32, float32) {
+	b.Style.Text.SetFontSize(b.Style.FontSize)
+	padding := float32(b.Style.FontSize)
+	x := b.X
+	for i, entry := range b.Entries {
+		w, _ := b.Style.Text.MeasureText(entry.Title)
+		width := float32(w) + 2*padding
+		if i == index {
+			return x, b.Y, width, b.Height
+		}
+		x += width
+	}
+	return x, b.Y, 0, b.Height
+}
+
+func (b *MenuBar) titleAt(x, y float32) int {
+	if y < b.Y || y >= b.Y+b.Height {
+		return -1
+	}
+	for i := range b.Entries {
+		tx, _, tw, _ := b.titleRect(i)
+		if x >= tx && x < tx+tw {
+			return i
+		}
+	}
+	return -1
+}
+
+func (b *MenuBar) SetBounds(x, y, width, height float32) {
+	b.X, b.Y, b.Width, b.Height = x, y, width, height
+}
+
+// Bounds returns the rectangle of the bar, e.g. for tooltips
+func (b *MenuBar) Bounds() (float32, float32, float32, float32) {
+	return b.X, b.Y, b.Width, b.Height
+}
+
+// Update handles the closed bar: clicks on titles, F10 and the accelerators.
+// While a menu is open the overlay manager updates the bar through the overlay.
+func (b *MenuBar) Update(offsetX, offsetY float32, isAnimating bool) {
+	cursorX, cursorY := ebiten.CursorPosition()
+	x, y := float32(cursorX)-offsetX, float32(cursorY)-offsetY
+	b.hovered = b.titleAt(x, y)
+	if isAnimating || b.IsOpen() {
+		return
+	}
+
+	for _, entry := range b.Entries {
+		if !entry.Disabled && entry.Menu.HandleShortcuts() {
+			return
+		}
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyF10) {
+		b.open = -1
+		b.step(1)
+		return
+	}
+	if b.hovered >= 0 && inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+		b.OpenMenu(b.hovered, false)
+	}
+}
+
+func (b *MenuBar) Draw(screen *ebiten.Image) {
+	s := b.Style
+	barColor := utils.ColorOr(b.BarColor, color.RGBA{235, 235, 235, 255})
+	titleColor := utils.ColorOr(b.TitleColor, utils.ColorOr(s.FontColor, color.Black))
+	highlight := utils.ColorOr(s.HighlightColor, color.RGBA{0, 120, 215, 255})
+	hover := color.RGBA{215, 225, 240, 255}
+	disabled := utils.ColorOr(s.DisabledColor, color.RGBA{160, 160, 160, 255})
+
+	vector.DrawFilledRect(screen, b.X, b.Y, b.Width, b.Height, barColor, false)
+	vector.StrokeLine(screen, b.X, b.Y+b.Height, b.X+b.Width, b.Y+b.Height, 1, utils.ColorOr(s.BorderColor, color.RGBA{190, 190, 190, 255}), false)
+
+	padding := float32(s.FontSize)
+	for i, entry := range b.Entries {
+		x, y, w, h := b.titleRect(i)
+		labelColor := titleColor
+		switch {
+		case entry.Disabled:
+			labelColor = disabled
+		case i == b.open:
+			vector.DrawFilledRect(screen, x, y, w, h, highlight, false)
+			labelColor = utils.ColorOr(s.HighlightFont, color.White)
+		case i == b.hovered:
+			vector.DrawFilledRect(screen, x, y, w, h, hover, false)
+		}
+		_, textH := s.Text.MeasureText(entry.Title)
+		s.Text.SetColor(labelColor)
+		s.Text.DrawText(screen, entry.Title, float64(x+padding), float64(y+(h-float32(textH))/2))
+	}
+}
+
+// ---------------------
+
+// menuBarPopup is the overlay content of an open bar: the bar titles and the menu popup
+type menuBarPopup struct {
+	bar *MenuBar
+}
+
+func (p *menuBarPopup) Contains(x, y float32) bool {
+	return p.bar.titleAt(x, y) >= 0 || p.bar.popup.Contains(x, y)
+}
+
+func (p *menuBarPopup) Update(offsetX, offsetY float32, isAnimating bool) {
+	b := p.bar
+	if !isAnimating {
+		cursorX, cursorY := ebiten.CursorPosition()
+		x, y := float32(cursorX)-offsetX, float32(cursorY)-offsetY
+		title := b.titleAt(x, y)
+		b.hovered = title
+		switch {
+		case title >= 0 && inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft):
+			// A click on the open title closes its menu, on another one switches
+			if title == b.open {
+				b.Close()
+				return
+			}
+			b.OpenMenu(title, false)
+			return
+		case title >= 0 && title != b.open && !b.Entries[title].Disabled:
+			b.OpenMenu(title, false)
+			return
+		}
+	}
+	b.popup.Update(offsetX, offsetY, isAnimating)
+}
+
+func (p *menuBarPopup) Draw(screen *ebiten.Image) {
+	p.bar.popup.Draw(screen)
+}
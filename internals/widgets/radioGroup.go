@@ -0,0 +1,100 @@
+package widgets
+
+import (
+	"image/color"
+
+	"example.com/menu/internals/utils"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+// RadioGroup shows options with a round button each, exactly one is selected.
+// Set Selection.AllowNone to let a click clear the selected option.
+type RadioGroup struct {
+	choiceList
+	Horizontal bool
+	Spacing    float32 // between two options
+}
+
+func NewRadioGroup(x, y float32, options []string, text TextRenderer, fontSize float64) *RadioGroup {
+	rg := &RadioGroup{
+		choiceList: newChoiceList(x, y, options, SelectSingle, text, fontSize),
+		Spacing:    8,
+	}
+	rg.itemRect = rg.optionRect
+	rg.Width, rg.Height = rg.PreferredSize()
+	return rg
+}
+
+func (rg *RadioGroup) rowHeight() float32 {
+	return float32(rg.FontSize) * 1.6
+}
+
+func (rg *RadioGroup) optionWidth(index int) float32 {
+	w, _ := rg.measure(rg.Options[index])
+	return rg.rowHeight() + w
+}
+
+// PreferredSize returns the size that fits every option
+func (rg *RadioGroup) PreferredSize() (float32, float32) {
+	var width float32
+	for i := range rg.Options {
+		w := rg.optionWidth(i)
+		if rg.Horizontal {
+			width += w
+			continue
+		}
+		width = max(width, w)
+	}
+	gaps := rg.Spacing * float32(max(len(rg.Options)-1, 0))
+	if rg.Horizontal {
+		return width + gaps, rg.rowHeight()
+	}
+	return width, rg.rowHeight()*float32(len(rg.Options)) + gaps
+}
+
+func (rg *RadioGroup) optionRect(index int) (float32, float32, float32, float32) {
+	if !rg.Horizontal {
+		return rg.X, rg.Y + float32(index)*(rg.rowHeight()+rg.Spacing), rg.Width, rg.rowHeight()
+	}
+	x := rg.X
+	for i := 0; i < index; i++ {
+		x += rg.optionWidth(i) + rg.Spacing
+	}
+	return x, rg.Y, rg.optionWidth(index), rg.rowHeight()
+}
+
+func (rg *RadioGroup) Draw(screen *ebiten.Image) {
+	fontColor := utils.ColorOr(rg.FontColor, color.Black)
+	accent := utils.ColorOr(rg.AccentColor, color.RGBA{0, 120, 215, 255})
+	border := utils.ColorOr(rg.BorderColor, color.RGBA{120, 120, 120, 255})
+	disabled := utils.ColorOr(rg.DisabledColor, color.RGBA{170, 170, 170, 255})
+	focus := utils.ColorOr(rg.FocusColor, color.RGBA{0, 128, 255, 255})
+
+	for i, option := range rg.Options {
+		x, y, w, h := rg.optionRect(i)
+		radius := h * 0.3
+		cx, cy := x+h/2, y+h/2
+
+		ringColor, labelColor := border, fontColor
+		switch {
+		case rg.isItemDisabled(i):
+			ringColor, labelColor = disabled, disabled
+		case i == rg.hovered || rg.Selection.IsSelected(i):
+			ringColor = accent
+		}
+
+		vector.StrokeCircle(screen, cx, cy, radius, 2, ringColor, true)
+		if rg.Selection.IsSelected(i) {
+			vector.DrawFilledCircle(screen, cx, cy, radius*0.55, ringColor, true)
+		}
+
+		_, textH := rg.measure(option)
+		rg.Text.SetColor(labelColor)
+		rg.Text.DrawText(screen, option, float64(x+h), float64(y+(h-textH)/2))
+
+		if rg.hasFocus && i == rg.focused {
+			vector.StrokeRect(screen, x-2, y, w+4, h, 1, focus, false)
+		}
+	}
+}
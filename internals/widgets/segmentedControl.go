@@ -0,0 +1,91 @@
+package widgets
+
+import (
+	"image/color"
+
+	"example.com/menu/internals/utils"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+// SegmentedControl is a row of joined buttons of equal width. It selects one
+// option by default, set Selection.Mode to SelectMultiple to toggle several.
+type SegmentedControl struct {
+	choiceList
+	BackgroundColor   color.Color
+	SelectedFontColor color.Color
+	Padding           float32 // around the widest label
+}
+
+func NewSegmentedControl(x, y float32, options []string, text TextRenderer, fontSize float64) *SegmentedControl {
+	sc := &SegmentedControl{
+		choiceList: newChoiceList(x, y, options, SelectSingle, text, fontSize),
+		Padding:    12,
+	}
+	sc.itemRect = sc.segmentRect
+	sc.Width, sc.Height = sc.PreferredSize()
+	return sc
+}
+
+// PreferredSize gives every segment the width of the widest label
+func (sc *SegmentedControl) PreferredSize() (float32, float32) {
+	var widest float32
+	for _, option := range sc.Options {
+		w, _ := sc.measure(option)
+		widest = max(widest, w)
+	}
+	return (widest + 2*sc.Padding) * float32(len(sc.Options)), float32(sc.FontSize) * 2
+}
+
+func (sc *SegmentedControl) segmentRect(index int) (float32, float32, float32, float32) {
+	if len(sc.Options) == 0 {
+		return sc.X, sc.Y, 0, 0
+	}
+	w := sc.Width / float32(len(sc.Options))
+	return sc.X + float32(index)*w, sc.Y, w, sc.Height
+}
+
+func (sc *SegmentedControl) Draw(screen *ebiten.Image) {
+	background := utils.ColorOr(sc.BackgroundColor, color.RGBA{235, 235, 235, 255})
+	fontColor := utils.ColorOr(sc.FontColor, color.Black)
+	selectedFont := utils.ColorOr(sc.SelectedFontColor, color.White)
+	accent := utils.ColorOr(sc.AccentColor, color.RGBA{0, 120, 215, 255})
+	border := utils.ColorOr(sc.BorderColor, color.RGBA{120, 120, 120, 255})
+	disabled := utils.ColorOr(sc.DisabledColor, color.RGBA{170, 170, 170, 255})
+	focus := utils.ColorOr(sc.FocusColor, color.RGBA{0, 128, 255, 255})
+	hover := color.RGBA{210, 225, 245, 255}
+
+	vector.DrawFilledRect(screen, sc.X, sc.Y, sc.Width, sc.Height, background, false)
+
+	for i, option := range sc.Options {
+		x, y, w, h := sc.segmentRect(i)
+		labelColor := fontColor
+		switch {
+		case sc.Selection.IsSelected(i):
+			fill := accent
+			if sc.isItemDisabled(i) {
+				fill = disabled
+			}
+			vector.DrawFilledRect(screen, x, y, w, h, fill, false)
+			labelColor = selectedFont
+		case sc.isItemDisabled(i):
+			labelColor = disabled
+		case i == sc.hovered:
+			vector.DrawFilledRect(screen, x, y, w, h, hover, false)
+		}
+
+		if i > 0 {
+			vector.StrokeLine(screen, x, y, x, y+h, 1, border, false)
+		}
+
+		textW, textH := sc.measure(option)
+		sc.Text.SetColor(labelColor)
+		sc.Text.DrawText(screen, option, float64(x+(w-textW)/2), float64(y+(h-textH)/2))
+
+		if sc.hasFocus && i == sc.focused {
+			vector.StrokeRect(screen, x+3, y+3, w-6, h-6, 1, focus, false)
+		}
+	}
+
+	vector.StrokeRect(screen, sc.X, sc.Y, sc.Width, sc.Height, 1, border, false)
+}
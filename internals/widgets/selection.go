@@ -0,0 +1,186 @@
+package widgets
+
+type SelectionMode int
+
+const (
+	SelectSingle   SelectionMode = iota // one item at a time, like radio buttons
+	SelectMultiple                      // any number of items, like checkboxes
+)
+
+// Selection is the list of selected items shared by Checkbox, RadioGroup and
+// SegmentedControl. Several widgets can show the same Selection, e.g. a group of
+// checkboxes and a "select all" checkbox.
+//
+// Disabled items cannot be selected or deselected, by the user or by the methods below.
+type Selection struct {
+	Mode SelectionMode
+	// AllowNone lets the user clear the selected item in SelectSingle mode
+	AllowNone bool
+	// OnChange runs once for every item that was selected or deselected
+	OnChange func(index int, selected bool)
+
+	selected []bool
+	disabled []bool
+}
+
+func NewSelection(count int, mode SelectionMode) *Selection {
+	return &Selection{
+		Mode:     mode,
+		selected: make([]bool, count),
+		disabled: make([]bool, count),
+	}
+}
+
+func (s *Selection) Len() int {
+	return len(s.selected)
+}
+
+// Resize changes the number of items, new items are enabled and not selected
+func (s *Selection) Resize(count int) {
+	for len(s.selected) < count {
+		s.selected = append(s.selected, false)
+		s.disabled = append(s.disabled, false)
+	}
+	s.selected = s.selected[:count]
+	s.disabled = s.disabled[:count]
+}
+
+func (s *Selection) valid(index int) bool {
+	return index >= 0 && index < len(s.selected)
+}
+
+func (s *Selection) IsSelected(index int) bool {
+	return s.valid(index) && s.selected[index]
+}
+
+func (s *Selection) IsEnabled(index int) bool {
+	return s.valid(index) && !s.disabled[index]
+}
+
+func (s *Selection) SetEnabled(index int, enabled bool) {
+	if s.valid(index) {
+		s.disabled[index] = !enabled
+	}
+}
+
+// Select selects an item, in SelectSingle mode the other item is deselected.
+// It reports whether the item is selected afterwards.
+func (s *Selection) Select(index int) bool {
+	if !s.IsEnabled(index) {
+		return s.IsSelected(index)
+	}
+	if s.selected[index] {
+		return true
+	}
+	if s.Mode == SelectSingle {
+		for i, selected := range s.selected {
+			if selected {
+				if s.disabled[i] {
+					// The selected item is locked, it keeps the selection
+					return false
+				}
+				s.set(i, false)
+			}
+		}
+	}
+	s.set(index, true)
+	return true
+}
+
+// Deselect clears an item. In SelectSingle mode the last item stays selected unless AllowNone is set.
+func (s *Selection) Deselect(index int) {
+	if !s.IsEnabled(index) || !s.selected[index] {
+		return
+	}
+	if s.Mode == SelectSingle && !s.AllowNone {
+		return
+	}
+	s.set(index, false)
+}
+
+// Toggle flips an item, the way a click does
+func (s *Selection) Toggle(index int) {
+	if s.IsSelected(index) {
+		s.Deselect(index)
+	} else {
+		s.Select(index)
+	}
+}
+
+// SelectAll selects every enabled item, only in SelectMultiple mode
+func (s *Selection) SelectAll() {
+	if s.Mode != SelectMultiple {
+		return
+	}
+	for i := range s.selected {
+		if !s.selected[i] && !s.disabled[i] {
+			s.set(i, true)
+		}
+	}
+}
+
+// Clear deselects every enabled item, even in SelectSingle mode
+func (s *Selection) Clear() {
+	for i := range s.selected {
+		if s.selected[i] && !s.disabled[i] {
+			s.set(i, false)
+		}
+	}
+}
+
+// SelectedIndex returns the first selected item, -1 when nothing is selected
+func (s *Selection) SelectedIndex() int {
+	for i, selected := range s.selected {
+		if selected {
+			return i
+		}
+	}
+	return -1
+}
+
+func (s *Selection) SelectedIndices() []int {
+	var indices []int
+	for i, selected := range s.selected {
+		if selected {
+			indices = append(indices, i)
+		}
+	}
+	return indices
+}
+
+// Count returns the number of selected items
+func (s *Selection) Count() int {
+	count := 0
+	for _, selected := range s.selected {
+		if selected {
+			count++
+		}
+	}
+	return count
+}
+
+// nextEnabled returns the closest enabled item after from in direction dir (+1 or -1),
+// wrapping around, or from when there is none
+func (s *Selection) nextEnabled(from, dir int) int {
+	n := len(s.selected)
+	if n == 0 {
+		return -1
+	}
+	if from < 0 && dir < 0 {
+		from = n
+	}
+	for step := 1; step <= n; step++ {
+		i := ((from+dir*step)%n + n) % n
+		if !s.disabled[i] {
+			return i
+		}
+	}
+	return from
+}
+
+func (s *Selection) set(index int, selected bool) {
+	s.selected[index] = selected
+	if s.OnChange != nil {
+		s.OnChange(index, selected)
+	}
+}
@@ -2,49 +2,455 @@ package widgets
 
 import (
 	"image/color"
+	"math"
+	"strconv"
+	"time"
 
+	"example.com/menu/internals/textwrapper"
+	"example.com/menu/internals/utils"
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/inpututil"
 	"github.com/hajimehoshi/ebiten/v2/vector"
 )
 
+type SliderOrientation int
+
+const (
+	SliderHorizontal SliderOrientation = iota
+	SliderVertical                     // Min at the bottom, Max at the top
+)
+
+// Slider picks a number between Min and Max, or a range of numbers with two thumbs.
+//
+// Drag a thumb or click the track, the closest thumb jumps there. Once clicked the
+// slider has focus: arrows and the wheel move by Step, PageUp/PageDown by PageStep,
+// Home/End go to Min/Max and Tab switches the thumb of a range slider.
 type Slider struct {
 	X, Y          float64
 	Width, Height float64
-	HandlePos     float64
 	Dragging      bool
+
+	Min, Max    float64
+	Step        float64 // values snap to Min + n*Step, 0 for no snapping
+	PageStep    float64 // PageUp/PageDown increment, 0 for 10 steps
+	Orientation SliderOrientation
+	IsRange     bool // two thumbs, see RangeValues
+	Disabled    bool
+
+	TickStep    float64 // distance between tick marks, 0 for none
+	ShowLabels  bool    // draws the value of every tick, needs TextWrapper
+	LabelFormat func(value float64) string
+	TextWrapper *textwrapper.TextWrapper
+	FontSize    float64
+
+	// OnChange runs every time a thumb moves, OnChangeEnd when the user lets go of it.
+	// value is the value of the thumb that moved.
+	OnChange    func(value float64)
+	OnChangeEnd func(value float64)
+
+	TrackColor    color.Color
+	FillColor     color.Color
+	ThumbColor    color.Color
+	FocusColor    color.Color
+	DisabledColor color.Color
+	TickColor     color.Color
+
+	ThumbSize float64 // along the track, the thumb spans the whole cross size
+
+	values     [2]float64 // values[1] is only used by range sliders
+	active     int        // the thumb that is dragged or moved with the keyboard
+	grabOffset float64    // keeps the thumb under the cursor when it was grabbed off-center
+	hasFocus   bool
+	changed    bool // a thumb moved since the last OnChangeEnd
+
+	heldKeys          map[ebiten.Key]*KeyState
+	keyRepeatDelay    time.Duration
+	keyRepeatInterval time.Duration
 }
 
-func (s *Slider) Update() {
-	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
-		mx, my := ebiten.CursorPosition()
-		mxf, myf := float64(mx), float64(my)
+func NewSlider(x, y, width, height, min, max float64) *Slider {
+	s := &Slider{
+		X:      x,
+		Y:      y,
+		Width:  width,
+		Height: height,
+		Min:    min,
+		Max:    max,
+	}
+	s.values = [2]float64{min, max}
+	return s
+}
+
+// Value returns the value of a single thumb slider, or the low end of a range
+func (s *Slider) Value() float64 {
+	return s.values[0]
+}
+
+// SetValue moves the thumb without calling OnChange. The value is clamped and snapped.
+func (s *Slider) SetValue(value float64) {
+	s.values[0] = s.normalize(value)
+	if s.IsRange && s.values[1] < s.values[0] {
+		s.values[1] = s.values[0]
+	}
+}
+
+// RangeValues returns both ends of a range slider
+func (s *Slider) RangeValues() (low, high float64) {
+	return s.values[0], s.values[1]
+}
+
+// SetRange moves both thumbs without calling OnChange
+func (s *Slider) SetRange(low, high float64) {
+	low, high = s.normalize(low), s.normalize(high)
+	if high < low {
+		low, high = high, low
+	}
+	s.values = [2]float64{low, high}
+}
+
+// Ratio returns where value sits between Min and Max, from 0 to 1
+func (s *Slider) Ratio(value float64) float64 {
+	if s.Max == s.Min {
+		return 0
+	}
+	return (value - s.Min) / (s.Max - s.Min)
+}
+
+func (s *Slider) Focus() {
+	if !s.Disabled {
+		s.hasFocus = true
+	}
+}
+
+func (s *Slider) Blur() {
+	s.hasFocus = false
+}
+
+func (s *Slider) IsFocused() bool {
+	return s.hasFocus
+}
 
-		if s.Dragging || (mxf >= s.X && mxf <= s.X+s.Width && myf >= s.Y && myf <= s.Y+s.Height) {
+// Bounds returns the rectangle of the track, e.g. for tooltips
+func (s *Slider) Bounds() (float32, float32, float32, float32) {
+	return float32(s.X), float32(s.Y), float32(s.Width), float32(s.Height)
+}
+
+// normalize clamps value to Min..Max and snaps it to Step
+func (s *Slider) normalize(value float64) float64 {
+	lo, hi := math.Min(s.Min, s.Max), math.Max(s.Min, s.Max)
+	if s.Step > 0 {
+		value = s.Min + math.Round((value-s.Min)/s.Step)*s.Step
+		// Remove float noise like 0.30000000000000004
+		value = math.Round(value*1e9) / 1e9
+	}
+	return math.Max(lo, math.Min(hi, value))
+}
+
+// ---------------------
+
+func (s *Slider) Update(navigatorOffsetX, navigatorOffsetY float32, isAnimating bool) {
+	if isAnimating || s.Disabled {
+		if s.Dragging {
+			s.Dragging = false
+			s.endChange()
+		}
+		return
+	}
+
+	x, y := ebiten.CursorPosition()
+	localX := float64(x) - float64(navigatorOffsetX)
+	localY := float64(y) - float64(navigatorOffsetY)
+	hovered := s.contains(localX, localY)
 
+	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+		if hovered {
+			s.hasFocus = true
 			s.Dragging = true
+			s.grab(localX, localY)
+		} else {
+			s.hasFocus = false
+		}
+	}
 
-			s.HandlePos = mxf - s.X
-			if s.HandlePos < 0 {
-				s.HandlePos = 0
-			}
-			if s.HandlePos > s.Width {
-				s.HandlePos = s.Width
-			}
+	if s.Dragging {
+		if ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft) {
+			s.moveActive(s.valueAt(localX, localY) + s.grabOffset)
+		} else {
+			s.Dragging = false
+			s.endChange()
 		}
-	} else {
+	}
+
+	if hovered && !s.Dragging {
+		if _, wheelY := ebiten.Wheel(); wheelY != 0 {
+			s.hasFocus = true
+			s.moveActive(s.values[s.active] + math.Copysign(s.stepSize(), wheelY))
+			s.endChange()
+		}
+	}
+
+	if s.hasFocus {
+		s.handleKeyboard()
+	}
+}
+
+// grab picks the thumb closest to the cursor. A click on the track moves it there,
+// a click on the thumb itself only holds it.
+func (s *Slider) grab(x, y float64) {
+	value := s.valueAt(x, y)
+	s.active = 0
+	if s.IsRange {
+		dLow := math.Abs(value - s.values[0])
+		dHigh := math.Abs(value - s.values[1])
+		// Stacked thumbs: take the one that can move in the direction of the click
+		if dHigh < dLow || (dHigh == dLow && value > s.values[1]) {
+			s.active = 1
+		}
+	}
+
+	s.grabOffset = 0
+	tx, ty := s.thumbCenter(s.active)
+	along := x - tx
+	if s.Orientation == SliderVertical {
+		along = y - ty
+	}
+	if math.Abs(along) <= s.thumbSize()/2 {
+		s.grabOffset = s.values[s.active] - value
+		return
+	}
+	s.moveActive(value)
+}
+
+// moveActive moves the active thumb, range thumbs stop at each other
+func (s *Slider) moveActive(value float64) {
+	value = s.normalize(value)
+	if s.IsRange {
+		if s.active == 0 {
+			value = math.Min(value, s.values[1])
+		} else {
+			value = math.Max(value, s.values[0])
+		}
+	}
+	if value == s.values[s.active] {
+		return
+	}
+	s.values[s.active] = value
+	s.changed = true
+	if s.OnChange != nil {
+		s.OnChange(value)
+	}
+}
+
+func (s *Slider) endChange() {
+	if !s.changed {
+		return
+	}
+	s.changed = false
+	if s.OnChangeEnd != nil {
+		s.OnChangeEnd(s.values[s.active])
+	}
+}
+
+func (s *Slider) handleKeyboard() {
+	if s.heldKeys == nil {
+		s.heldKeys = make(map[ebiten.Key]*KeyState)
+		s.keyRepeatDelay = 400 * time.Millisecond
+		s.keyRepeatInterval = 50 * time.Millisecond
+	}
+	now := time.Now()
+
+	// Up is "more" on both orientations, like Right
+	increase, decrease := ebiten.KeyRight, ebiten.KeyLeft
+	if s.Orientation == SliderVertical {
+		increase, decrease = ebiten.KeyUp, ebiten.KeyDown
+	}
+	keys := []struct {
+		key   ebiten.Key
+		delta float64
+	}{
+		{increase, s.stepSize()},
+		{decrease, -s.stepSize()},
+		{ebiten.KeyPageUp, s.pageSize()},
+		{ebiten.KeyPageDown, -s.pageSize()},
+	}
+	for _, k := range keys {
+		if fired, _ := repeatKeyState(s.heldKeys, k.key, now, s.keyRepeatDelay, s.keyRepeatInterval); fired {
+			s.moveActive(s.values[s.active] + k.delta)
+		}
+	}
+
+	if inpututil.IsKeyJustPressed(ebiten.KeyHome) {
+		s.moveActive(s.Min)
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyEnd) {
+		s.moveActive(s.Max)
+	}
+	if s.IsRange && inpututil.IsKeyJustPressed(ebiten.KeyTab) {
+		s.endChange()
+		s.active = 1 - s.active
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyEscape) {
+		s.hasFocus = false
+	}
+
+	// A keyboard change ends when no key moves the thumb anymore
+	if len(s.heldKeys) == 0 && !s.Dragging {
+		s.endChange()
+	}
+}
+
+func (s *Slider) stepSize() float64 {
+	if s.Step > 0 {
+		return s.Step
+	}
+	return math.Abs(s.Max-s.Min) / 100
+}
+
+func (s *Slider) pageSize() float64 {
+	if s.PageStep > 0 {
+		return s.PageStep
+	}
+	return s.stepSize() * 10
+}
+
+func (s *Slider) thumbSize() float64 {
+	if s.ThumbSize > 0 {
+		return s.ThumbSize
+	}
+	return 10
+}
+
+// contains also covers the thumbs hanging over the ends of the track
+func (s *Slider) contains(x, y float64) bool {
+	half := s.thumbSize() / 2
+	if s.Orientation == SliderVertical {
+		return x >= s.X && x <= s.X+s.Width && y >= s.Y-half && y <= s.Y+s.Height+half
+	}
+	return x >= s.X-half && x <= s.X+s.Width+half && y >= s.Y && y <= s.Y+s.Height
+}
 
-		s.Dragging = false
+// valueAt converts a point to a value, not clamped
+func (s *Slider) valueAt(x, y float64) float64 {
+	var ratio float64
+	if s.Orientation == SliderVertical {
+		if s.Height > 0 {
+			ratio = (s.Y + s.Height - y) / s.Height
+		}
+	} else if s.Width > 0 {
+		ratio = (x - s.X) / s.Width
+	}
+	return s.Min + ratio*(s.Max-s.Min)
+}
+
+// position returns the point on the track where value sits
+func (s *Slider) position(value float64) (float64, float64) {
+	ratio := s.Ratio(value)
+	if s.Orientation == SliderVertical {
+		return s.X + s.Width/2, s.Y + s.Height - ratio*s.Height
+	}
+	return s.X + ratio*s.Width, s.Y + s.Height/2
+}
+
+func (s *Slider) thumbCenter(thumb int) (float64, float64) {
+	return s.position(s.values[thumb])
+}
+
+func (s *Slider) formatLabel(value float64) string {
+	if s.LabelFormat != nil {
+		return s.LabelFormat(value)
 	}
+	return strconv.FormatFloat(value, 'f', -1, 64)
 }
 
+// ---------------------
+
 func (s *Slider) Draw(screen *ebiten.Image) {
+	trackColor := utils.ColorOr(s.TrackColor, color.RGBA{200, 200, 200, 255})
+	fillColor := utils.ColorOr(s.FillColor, color.RGBA{0, 120, 215, 255})
+	thumbColor := utils.ColorOr(s.ThumbColor, color.RGBA{100, 100, 100, 255})
+	if s.Disabled {
+		disabled := utils.ColorOr(s.DisabledColor, color.RGBA{150, 150, 150, 255})
+		fillColor, thumbColor = disabled, disabled
+	}
+
+	vector.DrawFilledRect(screen, float32(s.X), float32(s.Y), float32(s.Width), float32(s.Height), trackColor, true)
+
+	// Filled part: from Min to the value, or between the two thumbs
+	fromX, fromY := s.position(s.Min)
+	if s.IsRange {
+		fromX, fromY = s.thumbCenter(0)
+	}
+	toX, toY := s.thumbCenter(len(s.thumbs()) - 1)
+	if s.Orientation == SliderVertical {
+		vector.DrawFilledRect(screen, float32(s.X), float32(toY), float32(s.Width), float32(fromY-toY), fillColor, true)
+	} else {
+		vector.DrawFilledRect(screen, float32(fromX), float32(s.Y), float32(toX-fromX), float32(s.Height), fillColor, true)
+	}
 
-	vector.DrawFilledRect(screen, float32(s.X), float32(s.Y), float32(s.Width), float32(s.Height), color.RGBA{200, 200, 200, 255}, true)
+	s.drawTicks(screen)
 
-	handleWidth := 10.0
-	handleHeight := s.Height
-	handleX := s.X + s.HandlePos - handleWidth/2
-	handleY := s.Y
-	vector.DrawFilledRect(screen, float32(handleX), float32(handleY), float32(handleWidth), float32(handleHeight), color.RGBA{100, 100, 100, 255}, true)
+	size := s.thumbSize()
+	for _, thumb := range s.thumbs() {
+		cx, cy := s.thumbCenter(thumb)
+		tx, ty, tw, th := cx-size/2, s.Y, size, s.Height
+		if s.Orientation == SliderVertical {
+			tx, ty, tw, th = s.X, cy-size/2, s.Width, size
+		}
+		vector.DrawFilledRect(screen, float32(tx), float32(ty), float32(tw), float32(th), thumbColor, true)
+		if s.hasFocus && thumb == s.active {
+			focusColor := utils.ColorOr(s.FocusColor, color.RGBA{0, 128, 255, 255})
+			vector.StrokeRect(screen, float32(tx-2), float32(ty-2), float32(tw+4), float32(th+4), 2, focusColor, true)
+		}
+	}
+}
+
+func (s *Slider) thumbs() []int {
+	if s.IsRange {
+		return []int{0, 1}
+	}
+	return []int{0}
+}
+
+// drawTicks draws the tick marks under a horizontal track, or right of a vertical one
+func (s *Slider) drawTicks(screen *ebiten.Image) {
+	if s.TickStep <= 0 || s.Max == s.Min {
+		return
+	}
+	tickColor := utils.ColorOr(s.TickColor, color.RGBA{160, 160, 160, 255})
+	const tickLength = 6
+
+	labels := s.ShowLabels && s.TextWrapper != nil
+	var baseColor color.Color
+	if labels {
+		if s.FontSize > 0 {
+			s.TextWrapper.SetFontSize(s.FontSize)
+		}
+		baseColor = s.TextWrapper.Color
+		s.TextWrapper.SetColor(tickColor)
+		defer s.TextWrapper.SetColor(baseColor)
+	}
+
+	lo, hi := math.Min(s.Min, s.Max), math.Max(s.Min, s.Max)
+	count := int(math.Floor((hi-lo)/s.TickStep + 1e-9))
+	for i := 0; i <= count; i++ {
+		value := lo + float64(i)*s.TickStep
+		px, py := s.position(value)
+		if s.Orientation == SliderVertical {
+			edge := s.X + s.Width + 2
+			vector.StrokeLine(screen, float32(edge), float32(py), float32(edge+tickLength), float32(py), 1, tickColor, true)
+			if labels {
+				label := s.formatLabel(value)
+				_, h := s.TextWrapper.MeasureString(label)
+				s.TextWrapper.DrawText(screen, label, edge+tickLength+4, py-h/2)
+			}
+			continue
+		}
+		edge := s.Y + s.Height + 2
+		vector.StrokeLine(screen, float32(px), float32(edge), float32(px), float32(edge+tickLength), 1, tickColor, true)
+		if labels {
+			label := s.formatLabel(value)
+			w, _ := s.TextWrapper.MeasureString(label)
+			s.TextWrapper.DrawText(screen, label, px-w/2, edge+tickLength+2)
+		}
+	}
 }
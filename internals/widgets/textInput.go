@@ -0,0 +1,616 @@
+package widgets
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"example.com/menu/internals/textwrapper"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"golang.design/x/clipboard"
+)
+
+// InputFilter decides if a candidate value may be typed or pasted into a TextInput.
+// It sees the whole value after the edit, so partial input like "-" must be accepted
+// when the final value may start with it.
+type InputFilter func(candidate string) bool
+
+// Validator checks a TextInput value, a non nil error is shown under the field
+type Validator func(value string) error
+
+// NumericFilter accepts digits, with an optional leading minus and one decimal point
+func NumericFilter(allowNegative, allowDecimal bool) InputFilter {
+	return func(candidate string) bool {
+		seenPoint := false
+		for i, r := range candidate {
+			switch {
+			case unicode.IsDigit(r):
+			case r == '-' && allowNegative && i == 0:
+			case r == '.' && allowDecimal && !seenPoint:
+				seenPoint = true
+			default:
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// RegexFilter accepts values matching pattern in full.
+// The pattern must also match the partial values typed on the way.
+func RegexFilter(pattern string) (InputFilter, error) {
+	re, err := regexp.Compile("^(?:" + pattern + ")$")
+	if err != nil {
+		return nil, err
+	}
+	return re.MatchString, nil
+}
+
+// RequiredValidator rejects empty or blank values
+func RequiredValidator(message string) Validator {
+	return func(value string) error {
+		if strings.TrimSpace(value) == "" {
+			return errors.New(message)
+		}
+		return nil
+	}
+}
+
+// IntRangeValidator accepts whole numbers between min and max included
+func IntRangeValidator(min, max int) Validator {
+	return func(value string) error {
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return errors.New("must be a whole number")
+		}
+		if n < min || n > max {
+			return fmt.Errorf("must be between %d and %d", min, max)
+		}
+		return nil
+	}
+}
+
+// TextInput is a single line text field
+type TextInput struct {
+	X, Y          float32
+	Width, Height float32
+
+	Placeholder string
+	MaxLength   int  // in characters, 0 for no limit
+	Password    bool // draws MaskChar instead of the characters
+	MaskChar    rune
+	Filter      InputFilter
+	Validate    Validator
+	Disabled    bool
+
+	OnChange func(value string)
+	OnSubmit func(value string) // Enter, only called when the value is valid
+	OnCancel func(value string) // Escape, called with the restored value
+
+	TextWrapper      *textwrapper.TextWrapper
+	FontSize         float64
+	FontColor        color.Color
+	PlaceholderColor color.Color
+	BackgroundColor  color.Color
+	BorderColor      color.Color
+	FocusColor       color.Color
+	ErrorColor       color.Color
+	SelectionColor   color.Color
+
+	text      []rune
+	cursorPos int // in runes
+	anchor    int // other end of the selection, equals cursorPos when nothing is selected
+	scrollX   float64
+	hasFocus  bool
+	err       error
+	// value when the field got focus, restored on Escape
+	focusText string
+
+	isDragging        bool
+	counter           int
+	heldKeys          map[ebiten.Key]*KeyState
+	keyRepeatDelay    time.Duration
+	keyRepeatInterval time.Duration
+	hasClipboard      bool
+}
+
+func NewTextInput(
+	x, y,
+	width, height float32,
+	textWrapper *textwrapper.TextWrapper,
+	fontSize float64,
+	placeholder string,
+) *TextInput {
+	err := clipboard.Init()
+	if err != nil {
+		fmt.Println("Clipboard initialization failed:", err)
+	}
+
+	return &TextInput{
+		X:                 x,
+		Y:                 y,
+		Width:             width,
+		Height:            height,
+		Placeholder:       placeholder,
+		MaskChar:          '*',
+		TextWrapper:       textWrapper,
+		FontSize:          fontSize,
+		FontColor:         color.Black,
+		PlaceholderColor:  color.RGBA{150, 150, 150, 255},
+		BackgroundColor:   color.White,
+		BorderColor:       color.RGBA{160, 160, 160, 255},
+		FocusColor:        color.RGBA{0, 128, 255, 255},
+		ErrorColor:        color.RGBA{220, 40, 40, 255},
+		SelectionColor:    color.RGBA{0, 120, 215, 120},
+		heldKeys:          make(map[ebiten.Key]*KeyState),
+		keyRepeatDelay:    500 * time.Millisecond,
+		keyRepeatInterval: 50 * time.Millisecond,
+		hasClipboard:      err == nil,
+	}
+}
+
+// Text returns the current value
+func (ti *TextInput) Text() string {
+	return string(ti.text)
+}
+
+// SetText replaces the value without going through the filter, it is still cut to MaxLength
+func (ti *TextInput) SetText(value string) {
+	runes := []rune(value)
+	if ti.MaxLength > 0 && len(runes) > ti.MaxLength {
+		runes = runes[:ti.MaxLength]
+	}
+	ti.text = runes
+	ti.cursorPos = len(ti.text)
+	ti.anchor = ti.cursorPos
+	ti.runValidation()
+}
+
+func (ti *TextInput) Focus() {
+	if ti.Disabled || ti.hasFocus {
+		return
+	}
+	ti.hasFocus = true
+	ti.focusText = ti.Text()
+	ti.counter = 0
+}
+
+func (ti *TextInput) Blur() {
+	ti.hasFocus = false
+	ti.isDragging = false
+	ti.anchor = ti.cursorPos
+}
+
+func (ti *TextInput) IsFocused() bool {
+	return ti.hasFocus
+}
+
+// Bounds returns the rectangle of the field, e.g. for tooltips
+func (ti *TextInput) Bounds() (float32, float32, float32, float32) {
+	return ti.X, ti.Y, ti.Width, ti.Height
+}
+
+// Error returns the last validation error, nil when the value is valid
+func (ti *TextInput) Error() error {
+	return ti.err
+}
+
+// Valid runs the validator on the current value and reports if it passed
+func (ti *TextInput) Valid() bool {
+	ti.runValidation()
+	return ti.err == nil
+}
+
+func (ti *TextInput) runValidation() {
+	ti.err = nil
+	if ti.Validate != nil {
+		ti.err = ti.Validate(ti.Text())
+	}
+}
+
+// ---------------------
+
+func (ti *TextInput) Update(navigatorOffsetX, navigatorOffsetY float32, isAnimating bool) {
+	if isAnimating || ti.Disabled {
+		return
+	}
+
+	x, y := ebiten.CursorPosition()
+	localX := float32(x) - navigatorOffsetX
+	localY := float32(y) - navigatorOffsetY
+
+	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+		if ti.contains(localX, localY) {
+			ti.Focus()
+			ti.cursorPos = ti.runeIndexAt(localX)
+			if !ebiten.IsKeyPressed(ebiten.KeyShift) {
+				ti.anchor = ti.cursorPos
+			}
+			ti.isDragging = true
+		} else {
+			ti.Blur()
+		}
+	}
+	if ti.isDragging {
+		if ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft) {
+			ti.cursorPos = ti.runeIndexAt(localX)
+		} else {
+			ti.isDragging = false
+		}
+	}
+
+	if ti.hasFocus {
+		ti.handleKeyboard()
+	}
+
+	ti.scrollToCursor()
+	ti.counter++
+}
+
+func (ti *TextInput) contains(x, y float32) bool {
+	return x >= ti.X && x < ti.X+ti.Width && y >= ti.Y && y < ti.Y+ti.Height
+}
+
+func (ti *TextInput) handleKeyboard() {
+	now := time.Now()
+	mods := currentModifiers()
+	shift := mods&ModShift != 0
+	// Words are Ctrl+arrow, or Option+arrow on macOS
+	word := mods&ModCtrl != 0
+	if ModPrimary == ModMeta {
+		word = mods&ModAlt != 0
+	}
+
+	repeated := func(key ebiten.Key) bool {
+		fired, _ := repeatKeyState(ti.heldKeys, key, now, ti.keyRepeatDelay, ti.keyRepeatInterval)
+		return fired
+	}
+
+	switch {
+	case inpututil.IsKeyJustPressed(ebiten.KeyEnter) || inpututil.IsKeyJustPressed(ebiten.KeyNumpadEnter):
+		ti.submit()
+		return
+	case inpututil.IsKeyJustPressed(ebiten.KeyEscape):
+		ti.cancel()
+		return
+	}
+
+	if repeated(ebiten.KeyArrowLeft) {
+		if word {
+			ti.moveCursor(ti.wordStart(ti.cursorPos), shift)
+		} else if ti.hasSelection() && !shift {
+			ti.moveCursor(ti.selectionStart(), false)
+		} else {
+			ti.moveCursor(ti.cursorPos-1, shift)
+		}
+	}
+	if repeated(ebiten.KeyArrowRight) {
+		if word {
+			ti.moveCursor(ti.wordEnd(ti.cursorPos), shift)
+		} else if ti.hasSelection() && !shift {
+			ti.moveCursor(ti.selectionEnd(), false)
+		} else {
+			ti.moveCursor(ti.cursorPos+1, shift)
+		}
+	}
+	if repeated(ebiten.KeyHome) {
+		ti.moveCursor(0, shift)
+	}
+	if repeated(ebiten.KeyEnd) {
+		ti.moveCursor(len(ti.text), shift)
+	}
+	if repeated(ebiten.KeyBackspace) {
+		switch {
+		case ti.hasSelection():
+			ti.replaceSelection("")
+		case word:
+			ti.anchor = ti.wordStart(ti.cursorPos)
+			ti.replaceSelection("")
+		case ti.cursorPos > 0:
+			ti.anchor = ti.cursorPos - 1
+			ti.replaceSelection("")
+		}
+	}
+	if repeated(ebiten.KeyDelete) {
+		switch {
+		case ti.hasSelection():
+			ti.replaceSelection("")
+		case word:
+			ti.anchor = ti.wordEnd(ti.cursorPos)
+			ti.replaceSelection("")
+		case ti.cursorPos < len(ti.text):
+			ti.anchor = ti.cursorPos + 1
+			ti.replaceSelection("")
+		}
+	}
+
+	if mods&ModPrimary != 0 {
+		switch {
+		case inpututil.IsKeyJustPressed(ebiten.KeyA):
+			ti.anchor = 0
+			ti.cursorPos = len(ti.text)
+		case inpututil.IsKeyJustPressed(ebiten.KeyC):
+			ti.copySelection()
+		case inpututil.IsKeyJustPressed(ebiten.KeyX):
+			ti.copySelection()
+			if !ti.Password {
+				ti.replaceSelection("")
+			}
+		case repeated(ebiten.KeyV):
+			ti.paste()
+		}
+		// Shortcuts do not type characters
+		return
+	}
+
+	var typed []rune
+	for _, char := range ebiten.AppendInputChars(nil) {
+		if unicode.IsPrint(char) {
+			typed = append(typed, char)
+		}
+	}
+	if len(typed) > 0 {
+		ti.replaceSelection(string(typed))
+	}
+}
+
+// replaceSelection puts str in place of the selection, or at the cursor.
+// The edit is dropped when the filter rejects the result,
+// and str is shortened to fit MaxLength.
+func (ti *TextInput) replaceSelection(str string) {
+	start, end := ti.selectionStart(), ti.selectionEnd()
+	insert := []rune(str)
+	if ti.MaxLength > 0 {
+		room := ti.MaxLength - (len(ti.text) - (end - start))
+		if room < len(insert) {
+			insert = insert[:max(0, room)]
+		}
+	}
+	if start == end && len(insert) == 0 {
+		return
+	}
+
+	candidate := make([]rune, 0, len(ti.text)-(end-start)+len(insert))
+	candidate = append(candidate, ti.text[:start]...)
+	candidate = append(candidate, insert...)
+	candidate = append(candidate, ti.text[end:]...)
+	if ti.Filter != nil && !ti.Filter(string(candidate)) {
+		return
+	}
+
+	ti.text = candidate
+	ti.cursorPos = start + len(insert)
+	ti.anchor = ti.cursorPos
+	ti.counter = 0
+	ti.runValidation()
+	if ti.OnChange != nil {
+		ti.OnChange(ti.Text())
+	}
+}
+
+func (ti *TextInput) submit() {
+	if !ti.Valid() {
+		return
+	}
+	ti.focusText = ti.Text()
+	if ti.OnSubmit != nil {
+		ti.OnSubmit(ti.Text())
+	}
+}
+
+// cancel restores the value the field had when it got focus and gives the focus up
+func (ti *TextInput) cancel() {
+	changed := ti.Text() != ti.focusText
+	ti.text = []rune(ti.focusText)
+	ti.cursorPos = len(ti.text)
+	ti.anchor = ti.cursorPos
+	ti.runValidation()
+	ti.Blur()
+	if changed && ti.OnChange != nil {
+		ti.OnChange(ti.Text())
+	}
+	if ti.OnCancel != nil {
+		ti.OnCancel(ti.Text())
+	}
+}
+
+func (ti *TextInput) copySelection() {
+	// Never put a password on the clipboard
+	if !ti.hasSelection() || ti.Password || !ti.hasClipboard {
+		return
+	}
+	selected := string(ti.text[ti.selectionStart():ti.selectionEnd()])
+	err := clipboard.Write(clipboard.FmtText, []byte(selected))
+	if err != nil {
+		fmt.Println("TextInput copySelection - Error writing to clipboard:", err)
+	}
+}
+
+func (ti *TextInput) paste() {
+	if !ti.hasClipboard {
+		return
+	}
+	pasted := string(clipboard.Read(clipboard.FmtText))
+	// Only the first line fits in a single line field
+	if i := strings.IndexAny(pasted, "\r\n"); i >= 0 {
+		pasted = pasted[:i]
+	}
+	ti.replaceSelection(pasted)
+}
+
+// ---------------------
+
+func (ti *TextInput) hasSelection() bool {
+	return ti.anchor != ti.cursorPos
+}
+
+func (ti *TextInput) selectionStart() int {
+	return min(ti.anchor, ti.cursorPos)
+}
+
+func (ti *TextInput) selectionEnd() int {
+	return max(ti.anchor, ti.cursorPos)
+}
+
+func (ti *TextInput) moveCursor(pos int, extend bool) {
+	ti.cursorPos = clamp(pos, 0, len(ti.text))
+	if !extend {
+		ti.anchor = ti.cursorPos
+	}
+	ti.counter = 0
+}
+
+// wordStart and wordEnd jump over words, a password field has a single word
+func (ti *TextInput) wordStart(pos int) int {
+	if ti.Password {
+		return 0
+	}
+	for pos > 0 && isWordSeparator(ti.text[pos-1]) {
+		pos--
+	}
+	for pos > 0 && !isWordSeparator(ti.text[pos-1]) {
+		pos--
+	}
+	return pos
+}
+
+func (ti *TextInput) wordEnd(pos int) int {
+	if ti.Password {
+		return len(ti.text)
+	}
+	for pos < len(ti.text) && isWordSeparator(ti.text[pos]) {
+		pos++
+	}
+	for pos < len(ti.text) && !isWordSeparator(ti.text[pos]) {
+		pos++
+	}
+	return pos
+}
+
+// ---------------------
+
+// displayText is what gets drawn, the mask when Password is set
+func (ti *TextInput) displayText() []rune {
+	if !ti.Password {
+		return ti.text
+	}
+	mask := make([]rune, len(ti.text))
+	for i := range mask {
+		mask[i] = ti.MaskChar
+	}
+	return mask
+}
+
+func (ti *TextInput) measure(runes []rune) float64 {
+	width, _ := ti.TextWrapper.MeasureText(string(runes))
+	return width
+}
+
+func (ti *TextInput) padding() float32 {
+	return ti.Height / 4
+}
+
+func (ti *TextInput) innerWidth() float64 {
+	return float64(ti.Width - 2*ti.padding())
+}
+
+// scrollToCursor scrolls the value horizontally so the cursor stays visible
+func (ti *TextInput) scrollToCursor() {
+	ti.TextWrapper.SetFontSize(ti.FontSize)
+	display := ti.displayText()
+	cursorX := ti.measure(display[:ti.cursorPos])
+	textWidth := ti.measure(display)
+	inner := ti.innerWidth()
+
+	if cursorX-ti.scrollX > inner {
+		ti.scrollX = cursorX - inner
+	}
+	if cursorX < ti.scrollX {
+		ti.scrollX = cursorX
+	}
+	// Do not leave empty space on the right once the text got shorter
+	ti.scrollX = clampFloat(ti.scrollX, 0, max(0, textWidth-inner))
+}
+
+// runeIndexAt returns the cursor position closest to the local x coordinate
+func (ti *TextInput) runeIndexAt(x float32) int {
+	ti.TextWrapper.SetFontSize(ti.FontSize)
+	display := ti.displayText()
+	target := float64(x-ti.X-ti.padding()) + ti.scrollX
+	for i := range display {
+		left := ti.measure(display[:i])
+		right := ti.measure(display[:i+1])
+		if target < (left+right)/2 {
+			return i
+		}
+	}
+	return len(display)
+}
+
+func (ti *TextInput) Draw(screen *ebiten.Image) {
+	ti.TextWrapper.SetFontSize(ti.FontSize)
+
+	vector.DrawFilledRect(screen, ti.X, ti.Y, ti.Width, ti.Height, ti.BackgroundColor, false)
+
+	borderColor := ti.BorderColor
+	switch {
+	case ti.err != nil:
+		borderColor = ti.ErrorColor
+	case ti.hasFocus:
+		borderColor = ti.FocusColor
+	}
+	vector.StrokeRect(screen, ti.X, ti.Y, ti.Width, ti.Height, 2, borderColor, false)
+
+	// Everything inside the box is clipped so long values scroll
+	pad := ti.padding()
+	clip := image.Rect(int(ti.X+pad/2), int(ti.Y), int(ti.X+ti.Width-pad/2), int(ti.Y+ti.Height))
+	inner, ok := screen.SubImage(clip).(*ebiten.Image)
+	if !ok {
+		return
+	}
+
+	_, textHeight := ti.TextWrapper.MeasureText("Ag")
+	textX := float64(ti.X+pad) - ti.scrollX
+	textY := float64(ti.Y) + (float64(ti.Height)-textHeight)/2
+	display := ti.displayText()
+
+	baseColor := ti.TextWrapper.Color
+	defer ti.TextWrapper.SetColor(baseColor)
+
+	if len(display) == 0 && ti.Placeholder != "" {
+		ti.TextWrapper.SetColor(ti.PlaceholderColor)
+		ti.TextWrapper.DrawText(inner, ti.Placeholder, float64(ti.X+pad), textY)
+	}
+
+	if ti.hasFocus && ti.hasSelection() {
+		selX := textX + ti.measure(display[:ti.selectionStart()])
+		selW := ti.measure(display[ti.selectionStart():ti.selectionEnd()])
+		vector.DrawFilledRect(inner, float32(selX), float32(textY), float32(selW), float32(textHeight), ti.SelectionColor, false)
+	}
+
+	if len(display) > 0 {
+		ti.TextWrapper.SetColor(ti.FontColor)
+		ti.TextWrapper.DrawText(inner, string(display), textX, textY)
+	}
+
+	if ti.hasFocus && ti.counter%60 < 30 {
+		cursorX := textX + ti.measure(display[:ti.cursorPos])
+		vector.DrawFilledRect(inner, float32(cursorX), float32(textY), 2, float32(textHeight), ti.FontColor, false)
+	}
+
+	// The error message goes under the box
+	if ti.err != nil {
+		ti.TextWrapper.SetFontSize(ti.FontSize * 0.7)
+		ti.TextWrapper.SetColor(ti.ErrorColor)
+		ti.TextWrapper.DrawText(screen, ti.err.Error(), float64(ti.X), float64(ti.Y+ti.Height+4))
+		ti.TextWrapper.SetFontSize(ti.FontSize)
+	}
+}
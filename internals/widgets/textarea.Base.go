@@ -1,9 +1,11 @@
 package widgets
 
 import (
+	"example.com/menu/internals/syntax"
 	"example.com/menu/internals/textwrapper"
 	//"example.com/menu/internals/textwrapper02"
 	"fmt"
+	"time"
 
 	"github.com/hajimehoshi/ebiten/v2"
 	"golang.design/x/clipboard"
@@ -11,9 +13,8 @@ import (
 
 // KeyState tracks the repeat state of a specific key
 type KeyState struct {
-	InitialPress    bool // Indicates if the initial press has been handled
-	FramesHeld      int  // Number of frames the key has been held down
-	FramesUntilNext int  // Frames remaining until the next action
+	PressedAt  time.Time // When the key went down
+	NextRepeat time.Time // When the key fires again while it is held
 }
 
 type TextState struct {
@@ -58,12 +59,37 @@ type TextArea struct {
 	isDraggingThumb bool    // Indicates if the scrollbar thumb is being dragged
 	dragOffsetY     float64 // Offset between mouse position and thumb position during drag
 
-	// Key repeat constants
-	keyRepeatInitialDelay int
-	keyRepeatInterval     int
+	// Key repeat timing
+	keyRepeatDelay    time.Duration
+	keyRepeatInterval time.Duration
+	// key bindings and commands
+	keymap       *Keymap
+	pendingChord []KeyStroke
+	commands     map[string]TextAreaCommand
 	// performance
 	cachedLines   []string
 	isTextChanged bool
+	// soft wrap
+	softWrap    bool
+	visualRows  []visualRow
+	rowsWidth   float64
+	isRowsDirty bool
+	// syntax highlighting and decorations
+	tokenizer          syntax.Tokenizer
+	syntaxTheme        syntax.Theme
+	lineTokens         [][]syntax.Token
+	isTokensDirty      bool
+	showLineNumbers    bool
+	showWhitespace     bool
+	bracketPos         int
+	matchingBracketPos int
+	// find and replace
+	find *FindBar
+	// multiple cursors, the primary one is cursorPos and selection
+	extraCarets       []caret
+	isColumnSelecting bool
+	columnAnchorRow   int
+	columnAnchorX     float64
 	//minSelectionPos int
 	//maxSelectionPos int
 	// Minimum movement to consider as drag
@@ -71,9 +97,6 @@ type TextArea struct {
 	paddingTop    int
 	paddingBottom int
 	clicked       bool
-
-	stepX float64
-	stepY float64
 }
 
 // func NewTextAreaSelection(textWrapper *textwrapper02.TextWrapper, x, y, w, h int, startTxt string) *TextAreaSelection {
@@ -93,14 +116,11 @@ func NewTextAreaSelection(textWrapper *textwrapper.TextWrapper, x, y, w, h int,
 	//lineHeight := textWrapper.MeasureTextHeightWrap(startTxt)
 	//_, lineHeight := textWrapper.MeasureText(startTxt)
 
-	//monospaceWidth := textWrapper.GetMonospaceWidth()
-	monospaceWidth, _ := textWrapper.MeasureString("s")
-
 	// Calculate maxLines based on the height of the TextAreaSelection and the line height
 	padding := 10
 	maxLines := int((h - 2*padding) / int(lineHeight))
 
-	return &TextArea{
+	t := &TextArea{
 		textWrapper:          textWrapper,
 		selection:            NewSelectionBounds(),
 		x:                    x,
@@ -120,16 +140,23 @@ func NewTextAreaSelection(textWrapper *textwrapper.TextWrapper, x, y, w, h int,
 		scrollOffset:         0,
 		text:                 startTxt, // Default text added here
 
-		keyRepeatInitialDelay: 30,
-		keyRepeatInterval:     5,
-		isTextChanged:         true,
-		paddingLeft:           padding,
-		paddingTop:            padding,
-		paddingBottom:         padding,
-		clicked:               false,
-		isMouseLeftPressed:    false,
-
-		stepX: monospaceWidth,
-		stepY: float64(lineHeight),
+		keyRepeatDelay:     500 * time.Millisecond,
+		keyRepeatInterval:  80 * time.Millisecond,
+		keymap:             DefaultKeymap(),
+		isTextChanged:      true,
+		paddingLeft:        padding,
+		paddingTop:         padding,
+		paddingBottom:      padding,
+		clicked:            false,
+		isMouseLeftPressed: false,
+
+		scrollbarWidth: 10,
+
+		syntaxTheme:        syntax.DefaultTheme(),
+		bracketPos:         -1,
+		matchingBracketPos: -1,
+		find:               newFindBar(),
 	}
+	t.refreshLayout()
+	return t
 }
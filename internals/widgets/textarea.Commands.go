@@ -0,0 +1,279 @@
+package widgets
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"golang.design/x/clipboard"
+)
+
+// TextAreaCommand is an action that can be bound to a key sequence
+type TextAreaCommand func(t *TextArea)
+
+// textAreaCommands is the registry shared by every TextArea.
+// Single text areas can add or override commands with RegisterCommand.
+var textAreaCommands = map[string]TextAreaCommand{
+	"cursor.left":             func(t *TextArea) { t.moveOrCollapse(true, t.handleLeftArrow) },
+	"cursor.right":            func(t *TextArea) { t.moveOrCollapse(false, t.handleRightArrow) },
+	"cursor.up":               func(t *TextArea) { t.moveOrCollapse(true, t.handleUpArrow) },
+	"cursor.down":             func(t *TextArea) { t.moveOrCollapse(false, t.handleDownArrow) },
+	"cursor.lineStart":        func(t *TextArea) { t.moveOrCollapse(true, t.handleHome) },
+	"cursor.lineEnd":          func(t *TextArea) { t.moveOrCollapse(false, t.handleEnd) },
+	"cursor.wordLeft":         (*TextArea).handleCtrlLeftArrow,
+	"cursor.wordRight":        (*TextArea).handleCtrlRightArrow,
+	"cursor.logicalLineStart": (*TextArea).handleCtrlUpArrow,
+	"cursor.logicalLineEnd":   (*TextArea).handleCtrlDownArrow,
+	"cursor.documentStart":    (*TextArea).handleCtrlHome,
+	"cursor.documentEnd":      (*TextArea).handleCtrlEnd,
+
+	"select.left":             (*TextArea).handleShiftLeftArrow,
+	"select.right":            (*TextArea).handleShiftRightArrow,
+	"select.up":               (*TextArea).handleShiftUp,
+	"select.down":             (*TextArea).handleShiftDown,
+	"select.lineStart":        (*TextArea).handleShiftHome,
+	"select.lineEnd":          (*TextArea).handleShiftEnd,
+	"select.wordLeft":         (*TextArea).handleCtrlShiftLeftArrow,
+	"select.wordRight":        (*TextArea).handleCtrlShiftRightArrow,
+	"select.logicalLineStart": (*TextArea).handleCtrlShiftUpArrow,
+	"select.logicalLineEnd":   (*TextArea).handleCtrlShiftDownArrow,
+	"select.documentStart":    (*TextArea).handleCtrlShiftHome,
+	"select.documentEnd":      (*TextArea).handleCtrlShiftEnd,
+	"select.all":              (*TextArea).handleSelectAll,
+
+	"view.pageUp":   (*TextArea).handlePageUp,
+	"view.pageDown": (*TextArea).handlePageDown,
+
+	"edit.backspace":       (*TextArea).handleBackspace,
+	"edit.delete":          (*TextArea).handleDelete,
+	"edit.deleteWordLeft":  (*TextArea).handleCtrlBackspace,
+	"edit.deleteWordRight": (*TextArea).handleCtrlDelete,
+	"edit.newline":         (*TextArea).handleEnter,
+	"edit.indent":          (*TextArea).handleTab,
+	"edit.copy":            (*TextArea).handleCopySelection,
+	"edit.cut":             (*TextArea).handleCutSelection,
+	"edit.paste":           (*TextArea).handlePasteClipboard,
+	"edit.undo":            (*TextArea).handleUndo,
+	"edit.redo":            (*TextArea).handleRedo,
+	"edit.killLine":        (*TextArea).handleKillLine,
+	"edit.deleteLine":      (*TextArea).handleDeleteLine,
+	"edit.copyLine":        (*TextArea).handleCopyLine,
+
+	"find.open":     func(t *TextArea) { t.OpenFindBar(false) },
+	"find.replace":  func(t *TextArea) { t.OpenFindBar(true) },
+	"find.next":     (*TextArea).FindNext,
+	"find.previous": (*TextArea).FindPrevious,
+
+	"caret.addNextOccurrence": (*TextArea).addNextOccurrence,
+	"caret.clear":             (*TextArea).clearCarets,
+}
+
+// multiCaretCommands replace a command while more than one cursor is active.
+// Any other command drops the extra cursors first.
+var multiCaretCommands = map[string]TextAreaCommand{
+	"edit.backspace":          (*TextArea).backspaceAtCarets,
+	"edit.delete":             (*TextArea).deleteAtCarets,
+	"edit.newline":            func(t *TextArea) { t.insertAtCarets("\n") },
+	"edit.indent":             (*TextArea).indentCarets,
+	"edit.copy":               (*TextArea).copyCarets,
+	"edit.cut":                (*TextArea).cutCarets,
+	"edit.paste":              (*TextArea).pasteCarets,
+	"caret.addNextOccurrence": (*TextArea).addNextOccurrence,
+	"caret.clear":             (*TextArea).clearCarets,
+}
+
+// RegisterTextAreaCommand adds or replaces a command for every TextArea
+func RegisterTextAreaCommand(name string, command TextAreaCommand) {
+	textAreaCommands[name] = command
+}
+
+// RegisterCommand adds or replaces a command on this text area only
+func (t *TextArea) RegisterCommand(name string, command TextAreaCommand) {
+	if t.commands == nil {
+		t.commands = map[string]TextAreaCommand{}
+	}
+	t.commands[name] = command
+}
+
+// CommandNames returns the names of every command the text area knows, sorted
+func (t *TextArea) CommandNames() []string {
+	seen := map[string]bool{}
+	var names []string
+	for _, registry := range []map[string]TextAreaCommand{textAreaCommands, t.commands} {
+		for name := range registry {
+			if !seen[name] {
+				seen[name] = true
+				names = append(names, name)
+			}
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ExecuteCommand runs a command by name, it returns false when the command is unknown
+func (t *TextArea) ExecuteCommand(name string) bool {
+	command, ok := t.commands[name]
+	if !ok {
+		command, ok = textAreaCommands[name]
+	}
+	if !ok {
+		fmt.Printf("ExecuteCommand - Unknown command %q\n", name)
+		return false
+	}
+
+	// A previous command in the same frame may have changed the text
+	t.refreshLayout()
+
+	if t.hasExtraCarets() {
+		if multi, ok := multiCaretCommands[name]; ok {
+			multi(t)
+			return true
+		}
+		t.clearExtraCarets()
+	}
+	command(t)
+	return true
+}
+
+// SetKeymap replaces the key bindings, see DefaultKeymap and LoadKeymap
+func (t *TextArea) SetKeymap(keymap *Keymap) {
+	t.keymap = keymap
+	t.pendingChord = nil
+}
+
+func (t *TextArea) Keymap() *Keymap {
+	return t.keymap
+}
+
+// SetKeyRepeat sets how long a key is held before it repeats and the time between repeats
+func (t *TextArea) SetKeyRepeat(delay, interval time.Duration) {
+	t.keyRepeatDelay = delay
+	t.keyRepeatInterval = interval
+}
+
+// handleKeyStroke feeds a stroke to the keymap and runs the bound command.
+// Repeated strokes only run single stroke bindings, they never advance a chord.
+// It returns true when the stroke was used by a binding.
+func (t *TextArea) handleKeyStroke(stroke KeyStroke, repeated bool) bool {
+	if repeated && len(t.pendingChord) > 0 {
+		return true
+	}
+
+	chord := append(append([]KeyStroke{}, t.pendingChord...), stroke)
+	command, isPrefix := t.keymap.Lookup(chord)
+	switch {
+	case command != "":
+		t.pendingChord = nil
+		t.ExecuteCommand(command)
+		return true
+	case isPrefix:
+		if !repeated {
+			t.pendingChord = chord
+		}
+		return true
+	case len(t.pendingChord) > 0:
+		// The chord went nowhere, try the stroke on its own
+		t.pendingChord = nil
+		return t.handleKeyStroke(stroke, repeated)
+	}
+	return false
+}
+
+// moveOrCollapse collapses an active selection to one of its ends,
+// and only moves the cursor when nothing is selected
+func (t *TextArea) moveOrCollapse(toStart bool, move func()) {
+	if t.selection.selectionStart == t.selection.selectionEnd {
+		move()
+		return
+	}
+	if toStart {
+		t.setCursorPos(t.selection.getSelectionBoundsStart())
+	} else {
+		t.setCursorPos(t.selection.getSelectionBoundsEnd())
+	}
+	t.selection.ClearSelection(t.cursorPos)
+}
+
+// clearCarets goes back to a single cursor without a selection
+func (t *TextArea) clearCarets() {
+	t.clearExtraCarets()
+	t.selection.ClearSelection(t.cursorPos)
+}
+
+// currentLineBounds returns the start and end position of the logical line holding the cursor
+func (t *TextArea) currentLineBounds() (int, int) {
+	line, _ := t.getCursorLineAndColForPos(t.cursorPos)
+	if line >= len(t.cachedLines) {
+		return len(t.text), len(t.text)
+	}
+	start := t.getCharPosFromLineAndColWithclamp(line, 0)
+	return start, start + len(t.cachedLines[line])
+}
+
+// handleKillLine cuts from the cursor to the end of the line (Emacs Ctrl+K).
+// At the end of a line it removes the line break instead.
+func (t *TextArea) handleKillLine() {
+	_, end := t.currentLineBounds()
+	if t.cursorPos == end && end < len(t.text) {
+		end++
+	}
+	if end <= t.cursorPos {
+		return
+	}
+	t.pushUndo()
+	t.writeClipboard(t.text[t.cursorPos:end])
+	t.text = t.text[:t.cursorPos] + t.text[end:]
+	t.isTextChanged = true
+	t.selection.ClearSelection(t.cursorPos)
+}
+
+// handleDeleteLine cuts the whole line holding the cursor, line break included
+func (t *TextArea) handleDeleteLine() {
+	start, end := t.currentLineBounds()
+	if end < len(t.text) {
+		end++
+	} else if start > 0 {
+		start--
+	}
+	if start == end {
+		return
+	}
+	t.pushUndo()
+	t.writeClipboard(t.text[start:end])
+	t.text = t.text[:start] + t.text[end:]
+	t.setCursorPos(start)
+	t.isTextChanged = true
+	t.selection.ClearSelection(t.cursorPos)
+}
+
+// handleCopyLine copies the whole line holding the cursor, line break included
+func (t *TextArea) handleCopyLine() {
+	start, end := t.currentLineBounds()
+	if end < len(t.text) {
+		end++
+	}
+	t.writeClipboard(t.text[start:end])
+}
+
+func (t *TextArea) writeClipboard(text string) {
+	err := clipboard.Write(clipboard.FmtText, []byte(text))
+	if err != nil {
+		fmt.Println("writeClipboard - Error writing to clipboard:", err)
+	}
+}
+
+// ---------------------
+
+// isKeyRepeated reports a key when it goes down, and again at the key repeat rate while it is held.
+// The timing uses the wall clock so it does not depend on the frame rate.
+func (t *TextArea) isKeyRepeated(key ebiten.Key) bool {
+	fired, _ := t.keyRepeatState(key, time.Now())
+	return fired
+}
+
+// keyRepeatState reports whether the key fires now, and whether that is a repeat rather than the first press
+func (t *TextArea) keyRepeatState(key ebiten.Key, now time.Time) (fired, repeated bool) {
+	return repeatKeyState(t.heldKeys, key, now, t.keyRepeatDelay, t.keyRepeatInterval)
+}
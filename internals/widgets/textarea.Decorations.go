@@ -0,0 +1,122 @@
+package widgets
+
+import (
+	"image/color"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+// maxBracketScan limits how far the matching bracket is searched for
+const maxBracketScan = 20000
+
+// SetShowWhitespace makes tabs and trailing spaces visible
+func (t *TextArea) SetShowWhitespace(show bool) {
+	t.showWhitespace = show
+}
+
+// drawWhitespace marks tabs with an arrow and trailing spaces with a dot
+func (t *TextArea) drawWhitespace(screen *ebiten.Image, row visualRow, x, y float64) {
+	markColor := color.RGBA{140, 140, 140, 255}
+	rowText := t.getRowText(row)
+
+	trailingStart := len(rowText)
+	if row.lastOfLine {
+		trailingStart = len(strings.TrimRight(rowText, " \t"))
+	}
+
+	midY := float32(y + t.lineHeight/2)
+	for i := 0; i < len(rowText); i++ {
+		switch rowText[i] {
+		case '\t':
+			startX := float32(x + t.textWidth(rowText[:i]))
+			endX := startX + float32(max(int(t.textWidth("\t")), int(t.textWidth(" "))))
+			vector.StrokeLine(screen, startX+1, midY, endX-1, midY, 1, markColor, true)
+			vector.StrokeLine(screen, endX-4, midY-3, endX-1, midY, 1, markColor, true)
+			vector.StrokeLine(screen, endX-4, midY+3, endX-1, midY, 1, markColor, true)
+		case ' ':
+			if i >= trailingStart {
+				charX := x + t.textWidth(rowText[:i]) + t.textWidth(" ")/2
+				vector.DrawFilledCircle(screen, float32(charX), midY, 1.5, markColor, true)
+			}
+		}
+	}
+}
+
+// updateBracketMatch looks for a bracket next to the cursor and its counterpart
+func (t *TextArea) updateBracketMatch() {
+	t.bracketPos, t.matchingBracketPos = -1, -1
+
+	candidates := []int{t.cursorPos - 1, t.cursorPos}
+	for _, pos := range candidates {
+		if pos < 0 || pos >= len(t.text) {
+			continue
+		}
+		if match := t.findMatchingBracket(pos); match >= 0 {
+			t.bracketPos, t.matchingBracketPos = pos, match
+			return
+		}
+	}
+}
+
+// findMatchingBracket returns the position of the bracket matching the one at pos, or -1
+func (t *TextArea) findMatchingBracket(pos int) int {
+	pairs := map[byte]byte{'(': ')', '[': ']', '{': '}'}
+	reversePairs := map[byte]byte{')': '(', ']': '[', '}': '{'}
+
+	char := t.text[pos]
+	depth := 0
+	if closing, ok := pairs[char]; ok {
+		for i := pos; i < len(t.text) && i-pos < maxBracketScan; i++ {
+			switch t.text[i] {
+			case char:
+				depth++
+			case closing:
+				depth--
+				if depth == 0 {
+					return i
+				}
+			}
+		}
+	} else if opening, ok := reversePairs[char]; ok {
+		for i := pos; i >= 0 && pos-i < maxBracketScan; i-- {
+			switch t.text[i] {
+			case char:
+				depth++
+			case opening:
+				depth--
+				if depth == 0 {
+					return i
+				}
+			}
+		}
+	}
+	return -1
+}
+
+func (t *TextArea) drawBracketMatch(screen *ebiten.Image) {
+	if t.bracketPos < 0 || t.matchingBracketPos < 0 {
+		return
+	}
+	for _, pos := range []int{t.bracketPos, t.matchingBracketPos} {
+		t.drawCharBox(screen, pos, color.RGBA{0, 120, 0, 255})
+	}
+}
+
+// drawCharBox outlines the character at pos if its row is visible
+func (t *TextArea) drawCharBox(screen *ebiten.Image, pos int, clr color.Color) {
+	rowIndex, col := t.getCursorRowAndColForPos(pos)
+	if rowIndex < t.scrollOffset || rowIndex >= t.scrollOffset+t.maxLines {
+		return
+	}
+	rowText := t.getRowText(t.visualRows[rowIndex])
+	if col < 0 || col >= len(rowText) {
+		return
+	}
+
+	charX := float64(t.textX()) + t.textWidth(rowText[:col])
+	charW := t.textWidth(rowText[col : col+1])
+	charY := float64(t.y+t.paddingTop) + float64(rowIndex-t.scrollOffset)*t.lineHeight
+	vector.StrokeRect(screen, float32(charX), float32(charY), float32(charW), float32(t.lineHeight), 1, clr, true)
+}
@@ -10,40 +10,55 @@ func (t *TextArea) Draw(screen *ebiten.Image) {
 
 	// Draw the background of the text area
 	t.drawBackground(screen)
+	if t.hasFocus {
+		t.drawCurrentLine(screen)
+	}
+	t.drawGutter(screen)
+	t.drawFindMatches(screen)
 
 	yOffset := float64(t.y + t.paddingTop)
 	// Apply scroll offset
-	startLine := t.scrollOffset
-	endLine := clamp(startLine+t.maxLines, 0, len(t.cachedLines))
+	startRow := t.scrollOffset
+	endRow := clamp(startRow+t.maxLines, 0, t.totalRows())
 
 	// Retrieve normalized selection bounds
 	minPos, maxPos := t.selection.getSelectionBounds()
 	//fmt.Printf("Drawing selection from byte %d to byte %d\n", minPos, maxPos)
 
-	for i := startLine; i < endLine; i++ {
-		line := t.cachedLines[i]
+	for i := startRow; i < endRow; i++ {
+		row := t.visualRows[i]
 
-		lineText := line
-		lineX := t.x + t.paddingLeft
+		lineX := t.textX()
 		lineY := int(yOffset)
 
-		// Draw selection if active and within this line
+		// Draw selection if active and within this row
 		if minPos != maxPos {
-			t.drawSelection(screen, minPos, maxPos, i, line, yOffset)
+			t.drawSelection(screen, minPos, maxPos, row, yOffset)
 		}
+		t.drawExtraSelections(screen, row, yOffset)
 
-		t.textWrapper.DrawText(screen, lineText, float64(lineX), float64(lineY))
+		t.drawRowText(screen, row, float64(lineX), float64(lineY))
+		if t.showWhitespace {
+			t.drawWhitespace(screen, row, float64(lineX), float64(lineY))
+		}
 
 		yOffset += t.lineHeight
 	}
 
+	if t.hasFocus {
+		t.drawBracketMatch(screen)
+	}
+
 	// Draw the scrollbar if content exceeds maxLines
-	if len(t.cachedLines) > t.maxLines {
-		t.drawScrollbar(screen, len(t.cachedLines))
+	if t.totalRows() > t.maxLines {
+		t.drawScrollbar(screen, t.totalRows())
 	}
 
 	// Draw the cursor if the text area has focus and the cursor is within the visible lines
 	if t.hasFocus {
 		t.drawCursor(screen)
+		t.drawExtraCursors(screen)
 	}
+
+	t.drawFindBar(screen)
 }
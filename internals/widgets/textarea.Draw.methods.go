@@ -2,7 +2,6 @@ package widgets
 
 import (
 	"image/color"
-	"strings"
 
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/vector"
@@ -11,47 +10,44 @@ import (
 func (t *TextArea) drawBackground(screen *ebiten.Image) {
 	// Draw the background of the text area
 	vector.DrawFilledRect(screen, float32(t.x), float32(t.y), float32(t.w), float32(t.h), color.RGBA{200, 200, 200, 255}, true)
-	t.drawGrid(screen)
 }
 
-func (t *TextArea) drawSelection(screen *ebiten.Image, minPos, maxPos, lineIndex int, line string, yOffset float64) {
-	startLineSel, startCol := t.getCursorLineAndColForPos(minPos)
-	endLineSel, endCol := t.getCursorLineAndColForPos(maxPos)
-
-	if lineIndex >= startLineSel && lineIndex <= endLineSel {
-		// Determine selection bounds within the current line
-		var selStart, selEnd int
-		if lineIndex == startLineSel {
-			selStart = startCol
-		} else {
-			selStart = 0
-		}
-		if lineIndex == endLineSel {
-			selEnd = endCol
-		} else {
-			selEnd = len(line)
-		}
+func (t *TextArea) drawSelection(screen *ebiten.Image, minPos, maxPos int, row visualRow, yOffset float64) {
+	t.drawRowRange(screen, minPos, maxPos, row, yOffset, color.RGBA{0, 0, 255, 128})
+}
 
-		// Calculate x positions based on byte indices
-		selectionXStart := t.x + t.paddingLeft + int(t.textWidth(line[:selStart]))
-		selectionXEnd := t.x + t.paddingLeft + int(t.textWidth(line[:selEnd]))
-
-		// Clamp the selection rectangle within textarea bounds
-		selectionXStart = clamp(selectionXStart, t.x, t.x+t.w)
-		selectionXEnd = clamp(selectionXEnd, t.x, t.x+t.w)
-
-		// Clamp the yOffset within textarea bounds
-		clampedYOffset := clampFloat(yOffset, float64(t.y+t.paddingTop), float64(t.y+t.h+t.paddingTop))
-
-		// Draw the selection rectangle
-		vector.DrawFilledRect(screen,
-			float32(selectionXStart),
-			float32(clampedYOffset),
-			float32(selectionXEnd-selectionXStart),
-			float32(t.lineHeight),
-			color.RGBA{0, 0, 255, 128},
-			true)
+// drawRowRange fills the part of the [minPos, maxPos] range that lies on the row
+func (t *TextArea) drawRowRange(screen *ebiten.Image, minPos, maxPos int, row visualRow, yOffset float64, clr color.Color) {
+	if maxPos < row.start || minPos > row.end {
+		return
 	}
+
+	// Determine selection bounds within the current row
+	selStart := max(minPos, row.start)
+	selEnd := min(maxPos, row.end)
+	rowText := t.getRowText(row)
+	selStart = clamp(selStart-row.start, 0, len(rowText))
+	selEnd = clamp(selEnd-row.start, selStart, len(rowText))
+
+	// Calculate x positions based on byte indices
+	selectionXStart := t.textX() + int(t.textWidth(rowText[:selStart]))
+	selectionXEnd := t.textX() + int(t.textWidth(rowText[:selEnd]))
+
+	// Clamp the selection rectangle within textarea bounds
+	selectionXStart = clamp(selectionXStart, t.x, t.x+t.w)
+	selectionXEnd = clamp(selectionXEnd, t.x, t.x+t.w)
+
+	// Clamp the yOffset within textarea bounds
+	clampedYOffset := clampFloat(yOffset, float64(t.y+t.paddingTop), float64(t.y+t.h+t.paddingTop))
+
+	// Draw the selection rectangle
+	vector.DrawFilledRect(screen,
+		float32(selectionXStart),
+		float32(clampedYOffset),
+		float32(selectionXEnd-selectionXStart),
+		float32(t.lineHeight),
+		clr,
+		true)
 }
 
 func (t *TextArea) drawScrollbar(screen *ebiten.Image, totalLines int) {
@@ -91,12 +87,18 @@ func (t *TextArea) drawScrollbar(screen *ebiten.Image, totalLines int) {
 }
 
 func (t *TextArea) drawCursor(screen *ebiten.Image) {
-	cursorLine, cursorCol := t.getCursorLineAndCol()
-	if cursorLine >= t.scrollOffset && cursorLine < t.scrollOffset+t.maxLines {
+	t.drawCursorAt(screen, t.cursorPos)
+}
 
-		cursorX := t.x + t.paddingLeft + int(t.textWidth(strings.Split(t.text, "\n")[cursorLine][:cursorCol]))
+func (t *TextArea) drawCursorAt(screen *ebiten.Image, pos int) {
+	cursorRow, cursorCol := t.getCursorRowAndColForPos(pos)
+	if cursorRow >= t.scrollOffset && cursorRow < t.scrollOffset+t.maxLines {
+		rowText := t.getRowText(t.visualRows[cursorRow])
+		cursorCol = clamp(cursorCol, 0, len(rowText))
 
-		cursorY := float64(t.y+t.paddingTop) + float64(cursorLine-t.scrollOffset)*t.lineHeight
+		cursorX := t.textX() + int(t.textWidth(rowText[:cursorCol]))
+
+		cursorY := float64(t.y+t.paddingTop) + float64(cursorRow-t.scrollOffset)*t.lineHeight
 
 		// Clamp the cursor position within textarea bounds
 		cursorX = clamp(cursorX, t.x, t.x+t.w)
@@ -115,45 +117,6 @@ func (t *TextArea) drawCursor(screen *ebiten.Image) {
 	}
 }
 
-func (t *TextArea) drawGrid(screen *ebiten.Image) {
-	gridColor := color.RGBA{255, 0, 0, 255} // Red color
-	strokeWidth := float32(1)               // Thickness of grid lines
-
-	// Calculate the drawable area considering padding
-	startX := float32(t.x + t.paddingLeft)
-	endX := float32(t.x + t.w - t.paddingLeft)
-	startY := float32(t.y + t.paddingTop)
-	endY := float32(t.y + t.h - t.paddingTop)
-
-	// Draw vertical lines
-	for x := float64(startX); x <= float64(endX); x += t.stepX {
-		vector.StrokeLine(
-			screen,
-			float32(x),
-			startY,
-			float32(x),
-			endY,
-			strokeWidth,
-			gridColor,
-			false, // antialiasing
-		)
-	}
-
-	// Draw horizontal lines
-	for y := float64(startY); y <= float64(endY); y += t.stepY {
-		vector.StrokeLine(
-			screen,
-			startX,
-			float32(y),
-			endX,
-			float32(y),
-			strokeWidth,
-			gridColor,
-			false, // antialiasing
-		)
-	}
-}
-
 func (t *TextArea) getCursorLineAndCol() (int, int) {
 	return t.getCursorLineAndColForPos(t.cursorPos)
 }
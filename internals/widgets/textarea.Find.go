@@ -0,0 +1,468 @@
+package widgets
+
+import (
+	"fmt"
+	"image/color"
+	"regexp"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+// FindBar holds the state of the inline find and replace bar of a TextArea.
+// It is opened by the find.open and find.replace commands, bound to Mod+F and
+// Mod+Alt+F (also Ctrl+H off macOS) in DefaultKeymap. They switch the open bar too.
+//
+// Keys while the bar is open:
+//   - Enter / F3: next match, Shift+Enter / Shift+F3: previous match
+//   - Tab: switch between the find and the replace field
+//   - Enter in the replace field: replace the current match
+//   - Mod+Enter in the replace field: replace all matches
+//   - Alt+C: case sensitive, Alt+W: whole word, Alt+R: regular expression
+//   - Escape: close the bar
+type FindBar struct {
+	open          bool
+	replaceMode   bool
+	focusReplace  bool
+	query         string
+	replacement   string
+	caseSensitive bool
+	wholeWord     bool
+	useRegex      bool
+
+	regex        *regexp.Regexp
+	err          error
+	matches      [][]int // submatch indices of every match
+	current      int     // index of the current match, -1 if none
+	matchedText  string  // text the matches were computed on
+	matchesDirty bool
+}
+
+func newFindBar() *FindBar {
+	return &FindBar{current: -1}
+}
+
+// coveredRows returns how many text rows the bar hides at the top of the text area
+func (f *FindBar) coveredRows() int {
+	switch {
+	case !f.open:
+		return 0
+	case f.replaceMode:
+		return 2
+	default:
+		return 1
+	}
+}
+
+func (t *TextArea) IsFindBarOpen() bool {
+	return t.find.open
+}
+
+// OpenFindBar opens the find bar, with the replace field when replace is true.
+// A selection on a single line is used as the initial query.
+func (t *TextArea) OpenFindBar(replace bool) {
+	f := t.find
+	f.open = true
+	f.replaceMode = replace
+	f.focusReplace = false
+
+	minPos, maxPos := t.selection.getSelectionBounds()
+	if minPos != maxPos && !strings.Contains(t.text[minPos:maxPos], "\n") {
+		f.query = t.text[minPos:maxPos]
+	}
+	f.matchesDirty = true
+	t.hasFocus = true
+}
+
+func (t *TextArea) CloseFindBar() {
+	t.find.open = false
+	t.find.matches = nil
+	t.find.current = -1
+}
+
+func (t *TextArea) SetFindOptions(caseSensitive, wholeWord, useRegex bool) {
+	t.find.caseSensitive = caseSensitive
+	t.find.wholeWord = wholeWord
+	t.find.useRegex = useRegex
+	t.find.matchesDirty = true
+}
+
+// updateFindBar handles the keyboard while the find bar is open
+func (t *TextArea) updateFindBar() {
+	f := t.find
+
+	if inpututil.IsKeyJustPressed(ebiten.KeyEscape) {
+		t.CloseFindBar()
+		return
+	}
+
+	mods := currentModifiers()
+	if t.runFindCommands(mods) {
+		return
+	}
+
+	if t.isAltPressed() {
+		switch {
+		case inpututil.IsKeyJustPressed(ebiten.KeyC):
+			f.caseSensitive = !f.caseSensitive
+			f.matchesDirty = true
+		case inpututil.IsKeyJustPressed(ebiten.KeyW):
+			f.wholeWord = !f.wholeWord
+			f.matchesDirty = true
+		case inpututil.IsKeyJustPressed(ebiten.KeyR):
+			f.useRegex = !f.useRegex
+			f.matchesDirty = true
+		}
+	} else if mods&ModPrimary == 0 {
+		// Typing into the focused field
+		for _, char := range ebiten.InputChars() {
+			if char == '\n' || char == '\r' {
+				continue
+			}
+			if f.focusReplace {
+				f.replacement += string(char)
+			} else {
+				f.query += string(char)
+				f.matchesDirty = true
+			}
+		}
+	}
+
+	if t.isKeyRepeated(ebiten.KeyBackspace) {
+		if f.focusReplace {
+			f.replacement = trimLastRune(f.replacement)
+		} else if len(f.query) > 0 {
+			f.query = trimLastRune(f.query)
+			f.matchesDirty = true
+		}
+	}
+
+	if inpututil.IsKeyJustPressed(ebiten.KeyTab) && f.replaceMode {
+		f.focusReplace = !f.focusReplace
+	}
+
+	t.updateFindMatches()
+
+	switch {
+	case t.isKeyRepeated(ebiten.KeyEnter) || t.isKeyRepeated(ebiten.KeyNumpadEnter):
+		if f.focusReplace && mods&ModPrimary != 0 {
+			t.ReplaceAll()
+		} else if f.focusReplace {
+			t.ReplaceCurrent()
+		} else if t.isShiftPressed() {
+			t.FindPrevious()
+		} else {
+			t.FindNext()
+		}
+	case t.isKeyRepeated(ebiten.KeyF3):
+		if t.isShiftPressed() {
+			t.FindPrevious()
+		} else {
+			t.FindNext()
+		}
+	}
+}
+
+// runFindCommands runs the find commands bound to the keys pressed this frame,
+// so the keymap that opens the bar also switches it between find and replace.
+// It returns true when a command ran.
+func (t *TextArea) runFindCommands(mods Modifiers) bool {
+	ran := false
+	for _, key := range t.keymap.Keys() {
+		if !inpututil.IsKeyJustPressed(key) {
+			continue
+		}
+		command, _ := t.keymap.Lookup([]KeyStroke{{Key: key, Mods: mods}})
+		if strings.HasPrefix(command, "find.") {
+			t.ExecuteCommand(command)
+			ran = true
+		}
+	}
+	return ran
+}
+
+// updateFindMatches recompiles the query and searches the text when needed
+func (t *TextArea) updateFindMatches() {
+	f := t.find
+	if !f.matchesDirty && f.matchedText == t.text {
+		return
+	}
+	f.matchesDirty = false
+	f.matchedText = t.text
+	f.matches = nil
+	f.current = -1
+	f.regex = nil
+	f.err = nil
+
+	if f.query == "" {
+		return
+	}
+
+	pattern := f.query
+	if !f.useRegex {
+		pattern = regexp.QuoteMeta(pattern)
+	}
+	if f.wholeWord {
+		pattern = `\b(?:` + pattern + `)\b`
+	}
+	if !f.caseSensitive {
+		pattern = `(?i)` + pattern
+	}
+
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		f.err = err
+		return
+	}
+	f.regex = re
+
+	for _, match := range re.FindAllStringSubmatchIndex(t.text, -1) {
+		// Empty matches can not be selected or replaced
+		if match[1] > match[0] {
+			f.matches = append(f.matches, match)
+		}
+	}
+
+	// Keep the current match on the selection if it is one
+	minPos, maxPos := t.selection.getSelectionBounds()
+	for i, match := range f.matches {
+		if match[0] == minPos && match[1] == maxPos {
+			f.current = i
+			break
+		}
+	}
+}
+
+// FindNext selects the first match after the cursor, wrapping around to the start
+func (t *TextArea) FindNext() {
+	t.updateFindMatches()
+	f := t.find
+	if len(f.matches) == 0 {
+		return
+	}
+	next := 0
+	for i, match := range f.matches {
+		if match[0] >= t.cursorPos {
+			next = i
+			break
+		}
+	}
+	t.selectMatch(next)
+}
+
+// FindPrevious selects the last match before the selection, wrapping around to the end
+func (t *TextArea) FindPrevious() {
+	t.updateFindMatches()
+	f := t.find
+	if len(f.matches) == 0 {
+		return
+	}
+	minPos, _ := t.selection.getSelectionBounds()
+	if minPos == t.selection.getSelectionBoundsEnd() {
+		minPos = t.cursorPos
+	}
+	previous := len(f.matches) - 1
+	for i := len(f.matches) - 1; i >= 0; i-- {
+		if f.matches[i][0] < minPos {
+			previous = i
+			break
+		}
+	}
+	t.selectMatch(previous)
+}
+
+func (t *TextArea) selectMatch(index int) {
+	f := t.find
+	match := f.matches[index]
+	f.current = index
+	t.selection.setSelectionStart(match[0])
+	t.selection.setSelectionEnd(match[1])
+	t.setCursorPos(match[1])
+	t.refreshLayout()
+	t.scrollToPos(match[0])
+}
+
+// ReplaceCurrent replaces the selected match and moves to the next one
+func (t *TextArea) ReplaceCurrent() {
+	t.updateFindMatches()
+	f := t.find
+	if f.current < 0 || f.current >= len(f.matches) {
+		t.FindNext()
+		return
+	}
+
+	match := f.matches[f.current]
+	replacement := t.expandReplacement(match)
+
+	t.pushUndo()
+	t.text = t.text[:match[0]] + replacement + t.text[match[1]:]
+	t.setCursorPos(match[0] + len(replacement))
+	t.selection.ClearSelection(t.cursorPos)
+	t.isTextChanged = true
+	t.refreshLayout()
+
+	t.updateFindMatches()
+	t.FindNext()
+}
+
+// ReplaceAll replaces every match as a single undoable step and returns how many were replaced
+func (t *TextArea) ReplaceAll() int {
+	t.updateFindMatches()
+	f := t.find
+	if len(f.matches) == 0 {
+		return 0
+	}
+	replaced := len(f.matches)
+
+	t.pushUndo()
+	var builder strings.Builder
+	last := 0
+	cursorPos := t.cursorPos
+	for _, match := range f.matches {
+		replacement := t.expandReplacement(match)
+		builder.WriteString(t.text[last:match[0]])
+		builder.WriteString(replacement)
+		if match[1] <= t.cursorPos {
+			cursorPos += len(replacement) - (match[1] - match[0])
+		}
+		last = match[1]
+	}
+	builder.WriteString(t.text[last:])
+
+	t.text = builder.String()
+	t.setCursorPos(cursorPos)
+	t.selection.ClearSelection(t.cursorPos)
+	t.isTextChanged = true
+	t.refreshLayout()
+	t.scrollToPos(t.cursorPos)
+	return replaced
+}
+
+// expandReplacement returns the replacement of a match.
+// In regex mode $1, ${name} and the like refer to the groups of the match.
+func (t *TextArea) expandReplacement(match []int) string {
+	f := t.find
+	if !f.useRegex || f.regex == nil {
+		return f.replacement
+	}
+	return string(f.regex.ExpandString(nil, f.replacement, t.text, match))
+}
+
+// ---------------------
+
+func (t *TextArea) drawFindMatches(screen *ebiten.Image) {
+	f := t.find
+	if !f.open || len(f.matches) == 0 {
+		return
+	}
+
+	startRow := t.scrollOffset
+	endRow := clamp(startRow+t.maxLines, 0, t.totalRows())
+	if startRow >= endRow {
+		return
+	}
+	firstVisible := t.visualRows[startRow].start
+	lastVisible := t.visualRows[endRow-1].end
+
+	for i, match := range f.matches {
+		if match[1] < firstVisible || match[0] > lastVisible {
+			continue
+		}
+		matchColor := color.RGBA{255, 220, 0, 140}
+		if i == f.current {
+			matchColor = color.RGBA{255, 140, 0, 180}
+		}
+		yOffset := float64(t.y + t.paddingTop)
+		for r := startRow; r < endRow; r++ {
+			t.drawRowRange(screen, match[0], match[1], t.visualRows[r], yOffset, matchColor)
+			yOffset += t.lineHeight
+		}
+	}
+}
+
+func (t *TextArea) drawFindBar(screen *ebiten.Image) {
+	f := t.find
+	if !f.open {
+		return
+	}
+
+	rows := f.coveredRows()
+	barX := float32(t.x)
+	barY := float32(t.y)
+	barW := float32(t.w - t.scrollbarWidth)
+	barH := float32(float64(rows)*t.lineHeight + float64(t.paddingTop))
+
+	vector.DrawFilledRect(screen, barX, barY, barW, barH, color.RGBA{235, 235, 235, 255}, true)
+	vector.StrokeLine(screen, barX, barY+barH, barX+barW, barY+barH, 1, color.RGBA{120, 120, 120, 255}, true)
+
+	baseColor := t.textWrapper.Color
+	defer t.textWrapper.SetColor(baseColor)
+
+	// Options and match counter on the first row
+	options := ""
+	if f.caseSensitive {
+		options += " Aa"
+	}
+	if f.wholeWord {
+		options += " W"
+	}
+	if f.useRegex {
+		options += " .*"
+	}
+	status := "No results"
+	if f.err != nil {
+		status = "Invalid regex"
+	} else if len(f.matches) > 0 {
+		current := "?"
+		if f.current >= 0 {
+			current = fmt.Sprint(f.current + 1)
+		}
+		status = fmt.Sprintf("%s/%d", current, len(f.matches))
+	}
+
+	textX := float64(t.x + t.paddingLeft)
+	textY := float64(t.y + t.paddingTop/2)
+	fields := []struct {
+		label   string
+		value   string
+		focused bool
+	}{
+		{"Find: ", f.query, !f.focusReplace},
+		{"Replace: ", f.replacement, f.focusReplace},
+	}
+	for i := 0; i < rows; i++ {
+		field := fields[i]
+		t.textWrapper.SetColor(color.RGBA{80, 80, 80, 255})
+		t.textWrapper.DrawText(screen, field.label, textX, textY)
+		valueX := textX + t.textWidth(field.label)
+		t.textWrapper.SetColor(color.Black)
+		t.textWrapper.DrawText(screen, field.value, valueX, textY)
+
+		if field.focused && t.counter%(t.cursorBlinkRate*2) < t.cursorBlinkRate {
+			caretX := valueX + t.textWidth(field.value)
+			vector.DrawFilledRect(screen, float32(caretX), float32(textY), 2, float32(t.lineHeight), color.Black, true)
+		}
+		textY += t.lineHeight
+	}
+
+	info := strings.TrimSpace(options + "  " + status)
+	infoX := float64(barX+barW) - t.textWidth(info) - float64(t.paddingLeft)
+	if f.err != nil {
+		t.textWrapper.SetColor(color.RGBA{200, 0, 0, 255})
+	} else {
+		t.textWrapper.SetColor(color.RGBA{80, 80, 80, 255})
+	}
+	t.textWrapper.DrawText(screen, info, infoX, float64(t.y+t.paddingTop/2))
+}
+
+// ---------------------
+
+func trimLastRune(s string) string {
+	runes := []rune(s)
+	if len(runes) == 0 {
+		return s
+	}
+	return string(runes[:len(runes)-1])
+}
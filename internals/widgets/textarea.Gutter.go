@@ -0,0 +1,80 @@
+package widgets
+
+import (
+	"image/color"
+	"strconv"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+// SetLineNumbers shows or hides the line number gutter
+func (t *TextArea) SetLineNumbers(show bool) {
+	t.showLineNumbers = show
+	t.isRowsDirty = true
+	t.refreshLayout()
+}
+
+// gutterWidth returns the width of the line number gutter, 0 when hidden.
+// It grows with the number of digits of the last line number.
+func (t *TextArea) gutterWidth() int {
+	if !t.showLineNumbers {
+		return 0
+	}
+	digits := len(strconv.Itoa(max(1, len(t.cachedLines))))
+	return int(t.textWidth(strings.Repeat("0", digits))) + t.paddingLeft*2
+}
+
+// textX returns the x position where the text of every row starts
+func (t *TextArea) textX() int {
+	return t.x + t.gutterWidth() + t.paddingLeft
+}
+
+func (t *TextArea) drawGutter(screen *ebiten.Image) {
+	gutterWidth := t.gutterWidth()
+	if gutterWidth == 0 {
+		return
+	}
+
+	vector.DrawFilledRect(screen, float32(t.x), float32(t.y), float32(gutterWidth), float32(t.h), color.RGBA{180, 180, 180, 255}, true)
+
+	cursorLine, _ := t.getCursorLineAndCol()
+
+	baseColor := t.textWrapper.Color
+	defer t.textWrapper.SetColor(baseColor)
+
+	yOffset := float64(t.y + t.paddingTop)
+	endRow := clamp(t.scrollOffset+t.maxLines, 0, t.totalRows())
+	for i := t.scrollOffset; i < endRow; i++ {
+		row := t.visualRows[i]
+		// Only the first row of a wrapped line gets a number
+		if row.start == row.lineStart {
+			number := strconv.Itoa(row.line + 1)
+			numberX := float64(t.x+gutterWidth-t.paddingLeft) - t.textWidth(number)
+			if row.line == cursorLine && t.hasFocus {
+				t.textWrapper.SetColor(color.Black)
+			} else {
+				t.textWrapper.SetColor(color.RGBA{100, 100, 100, 255})
+			}
+			t.textWrapper.DrawText(screen, number, numberX, yOffset)
+		}
+		yOffset += t.lineHeight
+	}
+}
+
+// drawCurrentLine highlights every visible row of the line holding the cursor
+func (t *TextArea) drawCurrentLine(screen *ebiten.Image) {
+	cursorLine, _ := t.getCursorLineAndCol()
+	x := t.x + t.gutterWidth()
+	width := t.w - t.gutterWidth() - t.scrollbarWidth
+
+	endRow := clamp(t.scrollOffset+t.maxLines, 0, t.totalRows())
+	for i := t.scrollOffset; i < endRow; i++ {
+		if t.visualRows[i].line != cursorLine {
+			continue
+		}
+		rowY := float64(t.y+t.paddingTop) + float64(i-t.scrollOffset)*t.lineHeight
+		vector.DrawFilledRect(screen, float32(x), float32(rowY), float32(width), float32(t.lineHeight), color.RGBA{220, 220, 220, 255}, true)
+	}
+}
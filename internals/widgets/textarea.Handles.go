@@ -9,12 +9,11 @@ import (
 
 func (t *TextArea) handlePageDown() {
 	t.pushUndo()
-	lines := strings.Split(t.text, "\n")
-	totalLines := len(lines)
+	totalRows := t.totalRows()
 	// Calculate the new scroll offset
 	newScrollOffset := t.scrollOffset + t.maxLines
-	if newScrollOffset > totalLines-t.maxLines {
-		newScrollOffset = totalLines - t.maxLines
+	if newScrollOffset > totalRows-t.maxLines {
+		newScrollOffset = totalRows - t.maxLines
 	}
 	if newScrollOffset < 0 {
 		newScrollOffset = 0
@@ -111,7 +110,7 @@ func (t *TextArea) handleCtrlShiftEnd() {
 	t.selection.setSelectionEnd(len(t.text))
 	t.setCursorPos(len(t.text))
 	// Scroll to the bottom of the textarea
-	maxScrollOffset := len(strings.Split(t.text, "\n")) - t.maxLines
+	maxScrollOffset := t.totalRows() - t.maxLines
 	if maxScrollOffset > 0 {
 		t.SetScrollOffset(maxScrollOffset)
 	}
@@ -142,7 +141,7 @@ func (t *TextArea) handleCtrlEnd() {
 		t.selection.ClearSelection(t.cursorPos)
 	}
 	// Scroll to the bottom of the textarea
-	maxScrollOffset := len(strings.Split(t.text, "\n")) - t.maxLines
+	maxScrollOffset := t.totalRows() - t.maxLines
 	if maxScrollOffset > 0 {
 		t.SetScrollOffset(maxScrollOffset)
 	}
@@ -276,21 +275,19 @@ func (t *TextArea) handleCtrlDelete() {
 	}
 }
 
+// handleTab indents the selected lines, or inserts spaces at the cursor
 func (t *TextArea) handleTab() {
-	t.pushUndo()
-	if t.selection.isSelecting {
-		t.indentSelection()
-	} else {
-		t.text = t.text[:t.cursorPos] + strings.Repeat(" ", t.tabWidth) + t.text[t.cursorPos:]
-		t.cursorPos += t.tabWidth
-	}
-	t.selection.ClearSelection(t.cursorPos)
+	t.indentCarets()
 }
 
 func (t *TextArea) handleEnter() {
 	t.pushUndo()
+	if t.selection.selectionStart != t.selection.selectionEnd {
+		t.deleteSelection()
+	}
 	t.text = t.text[:t.cursorPos] + "\n" + t.text[t.cursorPos:]
 	t.cursorPos++
+	t.isTextChanged = true
 	t.selection.ClearSelection(t.cursorPos)
 }
 
@@ -336,8 +333,8 @@ func (t *TextArea) handleSelectAll() {
 
 func (t *TextArea) handleHome() {
 	t.pushUndo()
-	line, _ := t.getCursorLineAndColForPos(t.cursorPos)
-	newPos := t.getCharPosFromLineAndColWithclamp(line, 0)
+	row, _ := t.getCursorRowAndColForPos(t.cursorPos)
+	newPos := t.getCharPosFromRowAndCol(row, 0)
 	t.selection.ClearSelection(t.cursorPos)
 	t.setCursorPos(newPos)
 
@@ -345,12 +342,8 @@ func (t *TextArea) handleHome() {
 
 func (t *TextArea) handleEnd() {
 	t.pushUndo()
-	line, _ := t.getCursorLineAndColForPos(t.cursorPos)
-	lines := strings.Split(t.text, "\n")
-	if line >= len(lines) {
-		line = len(lines) - 1
-	}
-	newPos := t.getCharPosFromLineAndColWithclamp(line, len(lines[line]))
+	row, _ := t.getCursorRowAndColForPos(t.cursorPos)
+	newPos := t.getRowEndPos(row)
 
 	t.selection.ClearSelection(t.cursorPos)
 	t.setCursorPos(newPos)
@@ -374,15 +367,9 @@ func (t *TextArea) handleCtrlRightArrow() {
 // ---------------------
 func (t *TextArea) handleUpArrow() {
 	t.pushUndo()
-	currentLine, currentCol := t.getCursorLineAndColForPos(t.cursorPos)
-	if currentLine > 0 {
-		targetLine := currentLine - 1
-		lines := strings.Split(t.text, "\n")
-		targetCol := currentCol
-		if targetCol > len(lines[targetLine]) {
-			targetCol = len(lines[targetLine])
-		}
-		newPos := t.getCharPosFromLineAndColWithclamp(targetLine, targetCol)
+	currentRow, currentCol := t.getCursorRowAndColForPos(t.cursorPos)
+	if currentRow > 0 {
+		newPos := t.getCharPosFromRowAndCol(currentRow-1, currentCol)
 		t.setCursorPos(newPos)
 		t.selection.ClearSelection(t.cursorPos)
 	}
@@ -390,15 +377,9 @@ func (t *TextArea) handleUpArrow() {
 
 func (t *TextArea) handleDownArrow() {
 	t.pushUndo()
-	currentLine, currentCol := t.getCursorLineAndColForPos(t.cursorPos)
-	lines := strings.Split(t.text, "\n")
-	if currentLine < len(lines)-1 {
-		targetLine := currentLine + 1
-		targetCol := currentCol
-		if targetCol > len(lines[targetLine]) {
-			targetCol = len(lines[targetLine])
-		}
-		newPos := t.getCharPosFromLineAndColWithclamp(targetLine, targetCol)
+	currentRow, currentCol := t.getCursorRowAndColForPos(t.cursorPos)
+	if currentRow < t.totalRows()-1 {
+		newPos := t.getCharPosFromRowAndCol(currentRow+1, currentCol)
 		t.setCursorPos(newPos)
 		t.selection.ClearSelection(t.cursorPos)
 	}
@@ -406,19 +387,14 @@ func (t *TextArea) handleDownArrow() {
 
 func (t *TextArea) handleShiftUp() {
 	t.pushUndo()
-	currentLine, currentCol := t.getCursorLineAndColForPos(t.cursorPos)
-	if currentLine > 0 {
-		targetLine := currentLine - 1
-		lines := strings.Split(t.text, "\n")
+	currentRow, currentCol := t.getCursorRowAndColForPos(t.cursorPos)
+	if currentRow > 0 {
 		desiredCol := t.desiredCursorCol
 		if desiredCol == -1 {
 			desiredCol = currentCol
 			t.desiredCursorCol = desiredCol
 		}
-		if desiredCol > len(lines[targetLine]) {
-			desiredCol = len(lines[targetLine])
-		}
-		newPos := t.getCharPosFromLineAndColWithclamp(targetLine, desiredCol)
+		newPos := t.getCharPosFromRowAndCol(currentRow-1, desiredCol)
 		t.updateSelection(newPos)
 		t.desiredCursorCol = -1
 	}
@@ -426,19 +402,14 @@ func (t *TextArea) handleShiftUp() {
 
 func (t *TextArea) handleShiftDown() {
 	t.pushUndo()
-	currentLine, currentCol := t.getCursorLineAndColForPos(t.cursorPos)
-	lines := strings.Split(t.text, "\n")
-	if currentLine < len(lines)-1 {
-		targetLine := currentLine + 1
+	currentRow, currentCol := t.getCursorRowAndColForPos(t.cursorPos)
+	if currentRow < t.totalRows()-1 {
 		desiredCol := t.desiredCursorCol
 		if desiredCol == -1 {
 			desiredCol = currentCol
 			t.desiredCursorCol = desiredCol
 		}
-		if desiredCol > len(lines[targetLine]) {
-			desiredCol = len(lines[targetLine])
-		}
-		newPos := t.getCharPosFromLineAndColWithclamp(targetLine, desiredCol)
+		newPos := t.getCharPosFromRowAndCol(currentRow+1, desiredCol)
 		t.updateSelection(newPos)
 		t.desiredCursorCol = -1
 	}
@@ -454,8 +425,8 @@ func (t *TextArea) handleShiftHome() {
 	if t.selection.selectionStart == t.selection.selectionEnd {
 		t.selection.setSelectionStart(t.cursorPos)
 	}
-	currentLine, _ := t.getCursorLineAndColForPos(t.cursorPos)
-	newPos := t.getCharPosFromLineAndColWithclamp(currentLine, 0)
+	currentRow, _ := t.getCursorRowAndColForPos(t.cursorPos)
+	newPos := t.getCharPosFromRowAndCol(currentRow, 0)
 	t.updateSelection(newPos)
 }
 
@@ -470,9 +441,8 @@ func (t *TextArea) handleShiftEnd() {
 		t.selection.setSelectionStart(t.cursorPos)
 	}
 
-	currentLine, _ := t.getCursorLineAndColForPos(t.cursorPos)
-	lines := strings.Split(t.text, "\n")
-	newPos := t.getCharPosFromLineAndColWithclamp(currentLine, len(lines[currentLine]))
+	currentRow, _ := t.getCursorRowAndColForPos(t.cursorPos)
+	newPos := t.getRowEndPos(currentRow)
 	t.updateSelection(newPos)
 }
 
@@ -8,6 +8,10 @@ func (t *TextArea) isCtrlPressed() bool {
 	return ebiten.IsKeyPressed(ebiten.KeyControlLeft) || ebiten.IsKeyPressed(ebiten.KeyControlRight)
 }
 
+func (t *TextArea) isAltPressed() bool {
+	return ebiten.IsKeyPressed(ebiten.KeyAltLeft) || ebiten.IsKeyPressed(ebiten.KeyAltRight)
+}
+
 func (t *TextArea) isShiftPressed() bool {
 	return ebiten.IsKeyPressed(ebiten.KeyShiftLeft) || ebiten.IsKeyPressed(ebiten.KeyShiftRight)
 }
@@ -0,0 +1,395 @@
+package widgets
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"golang.design/x/clipboard"
+)
+
+// caret is a cursor with an optional selection.
+// anchor is where the selection started, it equals pos when nothing is selected.
+type caret struct {
+	pos, anchor int
+}
+
+func (c caret) bounds() (int, int) {
+	if c.anchor < c.pos {
+		return c.anchor, c.pos
+	}
+	return c.pos, c.anchor
+}
+
+func (c caret) hasSelection() bool {
+	return c.pos != c.anchor
+}
+
+// caretEdit replaces the text between start and end with insert.
+// cursor is the new caret position, relative to start.
+type caretEdit struct {
+	start, end int
+	insert     string
+	cursor     int
+}
+
+func (t *TextArea) hasExtraCarets() bool {
+	return len(t.extraCarets) > 0
+}
+
+func (t *TextArea) clearExtraCarets() {
+	t.extraCarets = nil
+}
+
+// primaryCaret returns the main cursor and its selection as a caret
+func (t *TextArea) primaryCaret() caret {
+	if t.selection.selectionStart == t.selection.selectionEnd {
+		return caret{pos: t.cursorPos, anchor: t.cursorPos}
+	}
+	anchor := t.selection.selectionStart
+	if anchor == t.cursorPos {
+		anchor = t.selection.selectionEnd
+	}
+	return caret{pos: t.cursorPos, anchor: anchor}
+}
+
+func (t *TextArea) setPrimaryCaret(c caret) {
+	t.setCursorPos(c.pos)
+	t.selection.setSelectionStart(clamp(c.anchor, 0, len(t.text)))
+	t.selection.setSelectionEnd(t.cursorPos)
+}
+
+// allCarets returns the primary caret first, followed by the extra ones
+func (t *TextArea) allCarets() []caret {
+	return append([]caret{t.primaryCaret()}, t.extraCarets...)
+}
+
+// setCarets makes the first caret the primary one and drops duplicates
+func (t *TextArea) setCarets(carets []caret) {
+	if len(carets) == 0 {
+		return
+	}
+	t.setPrimaryCaret(carets[0])
+	t.extraCarets = nil
+	seen := map[int]bool{carets[0].pos: true}
+	for _, c := range carets[1:] {
+		if seen[c.pos] {
+			continue
+		}
+		seen[c.pos] = true
+		t.extraCarets = append(t.extraCarets, c)
+	}
+}
+
+// AddCaretAt adds a cursor at pos. The new cursor becomes the primary one.
+func (t *TextArea) AddCaretAt(pos int) {
+	pos = clamp(pos, 0, len(t.text))
+	for _, c := range t.allCarets() {
+		if c.pos == pos {
+			return
+		}
+	}
+	t.extraCarets = append(t.extraCarets, t.primaryCaret())
+	t.setPrimaryCaret(caret{pos: pos, anchor: pos})
+}
+
+// editCarets applies the same kind of edit at every caret as a single undoable step.
+// Edits are applied in text order, a caret whose edit overlaps the previous one is merged away.
+func (t *TextArea) editCarets(edit func(c caret) caretEdit) {
+	carets := t.allCarets()
+	order := make([]int, len(carets))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		a, _ := carets[order[i]].bounds()
+		b, _ := carets[order[j]].bounds()
+		return a < b
+	})
+
+	t.pushUndo()
+
+	var builder strings.Builder
+	results := make([]caret, len(carets))
+	kept := make([]bool, len(carets))
+	last := 0
+	delta := 0
+	for _, idx := range order {
+		e := edit(carets[idx])
+		if e.start < last {
+			continue
+		}
+		e.start = clamp(e.start, last, len(t.text))
+		e.end = clamp(e.end, e.start, len(t.text))
+
+		builder.WriteString(t.text[last:e.start])
+		builder.WriteString(e.insert)
+
+		newPos := e.start + delta + e.cursor
+		results[idx] = caret{pos: newPos, anchor: newPos}
+		kept[idx] = true
+
+		delta += len(e.insert) - (e.end - e.start)
+		last = e.end
+	}
+	builder.WriteString(t.text[last:])
+
+	t.text = builder.String()
+	t.isTextChanged = true
+
+	var newCarets []caret
+	for i, c := range results {
+		if kept[i] {
+			newCarets = append(newCarets, c)
+		}
+	}
+	t.setCarets(newCarets)
+	t.refreshLayout()
+}
+
+func (t *TextArea) insertAtCarets(str string) {
+	t.editCarets(func(c caret) caretEdit {
+		minPos, maxPos := c.bounds()
+		return caretEdit{start: minPos, end: maxPos, insert: str, cursor: len(str)}
+	})
+}
+
+func (t *TextArea) backspaceAtCarets() {
+	t.editCarets(func(c caret) caretEdit {
+		minPos, maxPos := c.bounds()
+		if c.hasSelection() {
+			return caretEdit{start: minPos, end: maxPos}
+		}
+		return caretEdit{start: max(0, c.pos-1), end: c.pos}
+	})
+}
+
+func (t *TextArea) deleteAtCarets() {
+	t.editCarets(func(c caret) caretEdit {
+		minPos, maxPos := c.bounds()
+		if c.hasSelection() {
+			return caretEdit{start: minPos, end: maxPos}
+		}
+		return caretEdit{start: c.pos, end: min(len(t.text), c.pos+1)}
+	})
+}
+
+// indentCarets indents every line touched by a selection,
+// or inserts spaces at each caret when nothing is selected
+func (t *TextArea) indentCarets() {
+	carets := t.allCarets()
+	hasSelection := false
+	for _, c := range carets {
+		if c.hasSelection() {
+			hasSelection = true
+			break
+		}
+	}
+	indent := strings.Repeat(" ", t.tabWidth)
+	if !hasSelection {
+		t.insertAtCarets(indent)
+		return
+	}
+
+	// Collect the start positions of the lines to indent
+	lineSet := map[int]bool{}
+	for _, c := range carets {
+		minPos, maxPos := c.bounds()
+		startLine, _ := t.getCursorLineAndColForPos(minPos)
+		endLine, _ := t.getCursorLineAndColForPos(maxPos)
+		for line := startLine; line <= endLine; line++ {
+			lineSet[t.getCharPosFromLineAndColWithclamp(line, 0)] = true
+		}
+	}
+	lineStarts := make([]int, 0, len(lineSet))
+	for start := range lineSet {
+		lineStarts = append(lineStarts, start)
+	}
+	sort.Ints(lineStarts)
+
+	t.pushUndo()
+	var builder strings.Builder
+	last := 0
+	for _, start := range lineStarts {
+		builder.WriteString(t.text[last:start])
+		builder.WriteString(indent)
+		last = start
+	}
+	builder.WriteString(t.text[last:])
+	t.text = builder.String()
+	t.isTextChanged = true
+
+	// Every inserted indent before a position moves it forward
+	shift := func(pos int) int {
+		count := sort.SearchInts(lineStarts, pos+1)
+		return pos + count*len(indent)
+	}
+	for i := range carets {
+		carets[i] = caret{pos: shift(carets[i].pos), anchor: shift(carets[i].anchor)}
+	}
+	t.setCarets(carets)
+	t.refreshLayout()
+}
+
+// copyCarets copies the selections of every caret, one per line, in text order
+func (t *TextArea) copyCarets() {
+	var parts []string
+	for _, c := range t.sortedCarets() {
+		if c.hasSelection() {
+			minPos, maxPos := c.bounds()
+			parts = append(parts, t.text[minPos:maxPos])
+		}
+	}
+	if len(parts) == 0 {
+		fmt.Println("copyCarets - No selection to copy.")
+		return
+	}
+	err := clipboard.Write(clipboard.FmtText, []byte(strings.Join(parts, "\n")))
+	if err != nil {
+		fmt.Println("copyCarets - Error writing to clipboard:", err)
+	}
+}
+
+func (t *TextArea) cutCarets() {
+	t.copyCarets()
+	t.editCarets(func(c caret) caretEdit {
+		minPos, maxPos := c.bounds()
+		return caretEdit{start: minPos, end: maxPos}
+	})
+}
+
+// pasteCarets pastes the clipboard at every caret.
+// When the clipboard holds one line per caret, each caret gets its own line.
+func (t *TextArea) pasteCarets() {
+	clipboardText := string(clipboard.Read(clipboard.FmtText))
+	lines := strings.Split(clipboardText, "\n")
+
+	sorted := t.sortedCarets()
+	perCaret := map[caret]string{}
+	for i, c := range sorted {
+		if len(lines) == len(sorted) {
+			perCaret[c] = lines[i]
+		} else {
+			perCaret[c] = clipboardText
+		}
+	}
+
+	t.editCarets(func(c caret) caretEdit {
+		minPos, maxPos := c.bounds()
+		insert := perCaret[c]
+		return caretEdit{start: minPos, end: maxPos, insert: insert, cursor: len(insert)}
+	})
+}
+
+func (t *TextArea) sortedCarets() []caret {
+	carets := t.allCarets()
+	sort.Slice(carets, func(i, j int) bool {
+		a, _ := carets[i].bounds()
+		b, _ := carets[j].bounds()
+		return a < b
+	})
+	return carets
+}
+
+// addNextOccurrence selects the word at the cursor, or when a selection exists
+// adds a caret on the next occurrence of the selected text (Ctrl+D)
+func (t *TextArea) addNextOccurrence() {
+	primary := t.primaryCaret()
+	if !primary.hasSelection() {
+		pos := t.cursorPos
+		if pos > 0 && (pos >= len(t.text) || isWordSeparator(rune(t.text[pos]))) {
+			pos--
+		}
+		t.selectWordAt(pos)
+		return
+	}
+
+	minPos, maxPos := primary.bounds()
+	needle := t.text[minPos:maxPos]
+
+	// Search after the caret that is the furthest in the text, then wrap around
+	from := 0
+	for _, c := range t.allCarets() {
+		_, cMax := c.bounds()
+		from = max(from, cMax)
+	}
+	index := strings.Index(t.text[from:], needle)
+	if index >= 0 {
+		index += from
+	} else {
+		index = strings.Index(t.text, needle)
+	}
+	if index < 0 {
+		return
+	}
+
+	// Every occurrence already has a caret
+	for _, c := range t.allCarets() {
+		if cMin, _ := c.bounds(); cMin == index {
+			return
+		}
+	}
+
+	t.extraCarets = append(t.extraCarets, primary)
+	t.setPrimaryCaret(caret{pos: index + len(needle), anchor: index})
+	t.scrollToPos(index)
+}
+
+// ---------------------
+
+// startColumnSelection starts a rectangular selection at the mouse position (Alt+drag)
+func (t *TextArea) startColumnSelection(x, y int) {
+	t.isColumnSelecting = true
+	t.columnAnchorRow = t.getRowFromY(y)
+	t.columnAnchorX = float64(x - t.textX())
+	t.hasFocus = true
+	t.updateColumnSelection(x, y)
+}
+
+// updateColumnSelection puts one caret on every row between the anchor and the mouse,
+// each selecting the columns between the anchor x and the mouse x
+func (t *TextArea) updateColumnSelection(x, y int) {
+	currentRow := t.getRowFromY(y)
+	currentX := float64(x - t.textX())
+
+	fromRow, toRow := t.columnAnchorRow, currentRow
+	if fromRow > toRow {
+		fromRow, toRow = toRow, fromRow
+	}
+
+	// The caret on the row under the mouse is the primary one
+	carets := []caret{{
+		pos:    t.getCharPosFromRowAndX(currentRow, currentX),
+		anchor: t.getCharPosFromRowAndX(currentRow, t.columnAnchorX),
+	}}
+	for row := fromRow; row <= toRow; row++ {
+		if row == currentRow {
+			continue
+		}
+		carets = append(carets, caret{
+			pos:    t.getCharPosFromRowAndX(row, currentX),
+			anchor: t.getCharPosFromRowAndX(row, t.columnAnchorX),
+		})
+	}
+
+	t.setPrimaryCaret(carets[0])
+	t.extraCarets = carets[1:]
+}
+
+// ---------------------
+
+func (t *TextArea) drawExtraSelections(screen *ebiten.Image, row visualRow, yOffset float64) {
+	for _, c := range t.extraCarets {
+		if c.hasSelection() {
+			minPos, maxPos := c.bounds()
+			t.drawSelection(screen, minPos, maxPos, row, yOffset)
+		}
+	}
+}
+
+func (t *TextArea) drawExtraCursors(screen *ebiten.Image) {
+	for _, c := range t.extraCarets {
+		t.drawCursorAt(screen, c.pos)
+	}
+}
@@ -12,3 +12,28 @@ func (t *TextArea) SetScrollOffset(offset int) {
 func (t *TextArea) SetIsDraggingThumb(isDragging bool) {
 	t.isDraggingThumb = isDragging
 }
+
+// SetSoftWrap enables or disables soft line wrapping.
+// In soft wrap mode long lines are broken into several visual rows
+// that fit the width of the text area.
+func (t *TextArea) SetSoftWrap(enabled bool) {
+	if t.softWrap == enabled {
+		return
+	}
+	t.softWrap = enabled
+	t.isRowsDirty = true
+	t.refreshLayout()
+}
+
+func (t *TextArea) IsSoftWrap() bool {
+	return t.softWrap
+}
+
+// SetBounds moves and resizes the text area.
+// The number of visible lines and the soft wrapped rows are recomputed.
+func (t *TextArea) SetBounds(x, y, w, h int) {
+	t.x, t.y, t.w, t.h = x, y, w, h
+	t.maxLines = int((h - t.paddingTop - t.paddingBottom) / int(t.lineHeight))
+	t.isRowsDirty = true
+	t.refreshLayout()
+}
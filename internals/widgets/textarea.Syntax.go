@@ -0,0 +1,69 @@
+package widgets
+
+import (
+	"example.com/menu/internals/syntax"
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// SetTokenizer sets the tokenizer used to color the text.
+// A nil tokenizer disables syntax highlighting.
+func (t *TextArea) SetTokenizer(tokenizer syntax.Tokenizer) {
+	t.tokenizer = tokenizer
+	t.isTokensDirty = true
+	t.refreshLayout()
+}
+
+func (t *TextArea) SetSyntaxTheme(theme syntax.Theme) {
+	t.syntaxTheme = theme
+}
+
+func (t *TextArea) updateTokens() {
+	t.isTokensDirty = false
+	if t.tokenizer == nil {
+		t.lineTokens = nil
+		return
+	}
+	t.lineTokens = syntax.TokenizeLines(t.tokenizer, t.cachedLines)
+}
+
+// drawRowText draws the text of a row, coloring each token with the syntax theme
+func (t *TextArea) drawRowText(screen *ebiten.Image, row visualRow, x, y float64) {
+	rowText := t.getRowText(row)
+	if t.tokenizer == nil || row.line >= len(t.lineTokens) {
+		t.textWrapper.DrawText(screen, rowText, x, y)
+		return
+	}
+
+	baseColor := t.textWrapper.Color
+	defer t.textWrapper.SetColor(baseColor)
+
+	// Tokens are relative to the logical line, the row may start further in it
+	rowOffset := row.start - row.lineStart
+	pos := 0
+	for _, token := range t.lineTokens[row.line] {
+		start := clamp(token.Start-rowOffset, 0, len(rowText))
+		end := clamp(token.End-rowOffset, 0, len(rowText))
+		if end <= start || start < pos {
+			continue
+		}
+
+		// Plain text between two tokens
+		if start > pos {
+			t.textWrapper.SetColor(baseColor)
+			t.textWrapper.DrawText(screen, rowText[pos:start], x+t.textWidth(rowText[:pos]), y)
+		}
+
+		tokenColor, ok := t.syntaxTheme[token.Kind]
+		if !ok {
+			tokenColor = baseColor
+		}
+		t.textWrapper.SetColor(tokenColor)
+		t.textWrapper.DrawText(screen, rowText[start:end], x+t.textWidth(rowText[:start]), y)
+		pos = end
+	}
+
+	if pos < len(rowText) {
+		t.textWrapper.SetColor(baseColor)
+		t.textWrapper.DrawText(screen, rowText[pos:], x+t.textWidth(rowText[:pos]), y)
+	}
+}
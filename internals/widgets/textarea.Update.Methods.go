@@ -2,7 +2,6 @@ package widgets
 
 import (
 	"fmt"
-	"strings"
 )
 
 func (t *TextArea) isOverScrollbar(x, y int) bool {
@@ -25,8 +24,8 @@ func (t *TextArea) dragScrollbar(mouseY int) {
 	t.scrollbarThumbY = newThumbY
 
 	// Calculate the corresponding scrollOffset
-	totalLines := len(strings.Split(t.text, "\n"))
-	maxScrollOffset := totalLines - t.maxLines
+	totalRows := t.totalRows()
+	maxScrollOffset := totalRows - t.maxLines
 	if maxScrollOffset < 1 {
 		maxScrollOffset = 1
 	}
@@ -37,6 +36,18 @@ func (t *TextArea) dragScrollbar(mouseY int) {
 	//fmt.Printf("Dragging Scrollbar: ThumbY=%.2f, ScrollOffset=%d\n", t.scrollbarThumbY, t.scrollOffset)
 }
 
+// scrollToPos scrolls the least needed to bring the row holding pos into view.
+// Rows covered by the find bar do not count as visible.
+func (t *TextArea) scrollToPos(pos int) {
+	row := t.getRowForPos(pos)
+	hiddenRows := t.find.coveredRows()
+	if row < t.scrollOffset+hiddenRows {
+		t.SetScrollOffset(max(0, row-hiddenRows))
+	} else if row >= t.scrollOffset+t.maxLines {
+		t.SetScrollOffset(row - t.maxLines + 1)
+	}
+}
+
 func (t *TextArea) selectWordAt(pos int) {
 	if len(t.text) == 0 {
 		return
@@ -98,31 +109,36 @@ func (t *TextArea) selectEntireLineAt(x, y int) {
 }
 
 func (t *TextArea) getCharPosFromPosition(x, y int) int {
-	// Adjust the line calculation by adding the scrollOffset
-	line := float64(y-t.y-t.paddingTop)/t.lineHeight + float64(t.scrollOffset)
-	col := float64(x - t.x - t.paddingLeft)
+	rowInt := t.getRowFromY(y)
+	col := float64(x - t.textX())
 
-	lines := t.cachedLines
-	if line >= float64(len(lines)) {
-		line = float64(len(lines)) - 1
-	}
-	if line < 0 {
-		line = 0
-	}
+	charPos := t.getCharPosFromRowAndX(rowInt, col)
+	fmt.Printf("Mouse click at (x=%d, y=%d) mapped to byte position %d\n", x, y, charPos)
+	return charPos
+}
+
+// getRowFromY returns the visual row under the screen y position, clamped to the existing rows
+func (t *TextArea) getRowFromY(y int) int {
+	// Adjust the row calculation by adding the scrollOffset
+	row := float64(y-t.y-t.paddingTop)/t.lineHeight + float64(t.scrollOffset)
 
-	lineInt := int(line)
-	if lineInt >= len(lines) {
-		lineInt = len(lines) - 1
+	rows := t.visualRows
+	if row >= float64(len(rows)) {
+		row = float64(len(rows)) - 1
 	}
-	if lineInt < 0 {
-		lineInt = 0
+	if row < 0 {
+		row = 0
 	}
+	return clamp(int(row), 0, len(rows)-1)
+}
 
-	lineText := lines[lineInt]
+// getCharPosFromRowAndX maps an x offset from the start of the text to a position on the row
+func (t *TextArea) getCharPosFromRowAndX(rowInt int, col float64) int {
+	rowText := t.getRowText(t.visualRows[rowInt])
 	colIndex := 0
 	accumulatedWidth := 0.0
 
-	for i, char := range lineText {
+	for i, char := range rowText {
 		charWidth := t.textWidth(string(char))
 
 		// Check if the click is within the current character's width
@@ -139,12 +155,6 @@ func (t *TextArea) getCharPosFromPosition(x, y int) int {
 		colIndex = i + 1
 	}
 
-	// Ensure colIndex does not exceed line length
-	if colIndex > len(lineText) {
-		colIndex = len(lineText)
-	}
-
-	charPos := t.getCharPosFromLineAndColWithclamp(lineInt, colIndex)
-	fmt.Printf("Mouse click at (x=%d, y=%d) mapped to byte position %d\n", x, y, charPos)
-	return charPos
+	// Ensure colIndex does not exceed the row length
+	return t.getCharPosFromRowAndCol(rowInt, colIndex)
 }
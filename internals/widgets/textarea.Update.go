@@ -4,18 +4,13 @@ import (
 
 	//"example.com/menu/internals/textwrapper02"
 
-	"strings"
-
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/inpututil"
 )
 
 func (t *TextArea) Update() error {
 
-	if t.isTextChanged {
-		t.cachedLines = strings.Split(t.text, "\n")
-		t.isTextChanged = false
-	}
+	t.refreshLayout()
 
 	// Single, double, triple, and Shift+Click detection
 	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
@@ -28,7 +23,17 @@ func (t *TextArea) Update() error {
 			return nil
 		}
 
-		if t.isShiftPressed() {
+		inside := x >= t.x && x <= t.x+t.w && y >= t.y && y <= t.y+t.h
+		if inside && t.isAltPressed() {
+			// Alt + Drag: Column selection
+			t.clearExtraCarets()
+			t.startColumnSelection(x, y)
+		} else if inside && t.hasFocus && t.isCtrlPressed() {
+			// Ctrl + Click: Add a cursor
+			t.AddCaretAt(t.getCharPosFromPosition(x, y))
+			t.doubleClickHandled = true // Keep the drag from moving the new cursor
+		} else if t.isShiftPressed() {
+			t.clearExtraCarets()
 			// Handle Shift + Click: Extend selection
 			charPos := t.getCharPosFromPosition(x, y)
 
@@ -46,6 +51,7 @@ func (t *TextArea) Update() error {
 			t.selection.SetIsSelecting(true)
 		} else {
 			// Handle single, double, and triple clicks
+			t.clearExtraCarets()
 			t.isMouseLeftPressed = true
 			t.clicked = true
 			currentFrame := t.counter
@@ -91,7 +97,10 @@ func (t *TextArea) Update() error {
 	// Handle mouse movement while left button is pressed
 	if ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft) {
 		// Check if a double-click was just handled to prevent unwanted selection changes
-		if t.doubleClickHandled {
+		if t.isColumnSelecting {
+			x, y := ebiten.CursorPosition()
+			t.updateColumnSelection(x, y)
+		} else if t.doubleClickHandled {
 			// Do not process selection adjustments while a double-click is handled
 			// Wait until the mouse button is released to reset the flag
 		} else {
@@ -121,6 +130,7 @@ func (t *TextArea) Update() error {
 	// Handle mouse button release (mouse up)
 	if inpututil.IsMouseButtonJustReleased(ebiten.MouseButtonLeft) {
 		t.isMouseLeftPressed = false
+		t.isColumnSelecting = false
 		if t.isDraggingThumb {
 			t.SetIsDraggingThumb(false)
 		}
@@ -136,15 +146,19 @@ func (t *TextArea) Update() error {
 
 	// Handle keyboard input when focused
 	if t.hasFocus {
-		t.checkKeyboardInput()
+		if t.find.open {
+			t.updateFindBar()
+		} else {
+			t.checkKeyboardInput()
+		}
 	}
 
 	// Handle mouse wheel scrolling with smooth scrolling
 	_, yScroll := ebiten.Wheel()
 	if yScroll != 0 {
 		const linesPerWheel = 3
-		totalLines := len(strings.Split(t.text, "\n"))
-		targetScrollOffset := clamp(t.scrollOffset-int(yScroll)*linesPerWheel, 0, max(t.scrollOffset, totalLines-t.maxLines))
+		totalRows := t.totalRows()
+		targetScrollOffset := clamp(t.scrollOffset-int(yScroll)*linesPerWheel, 0, max(t.scrollOffset, totalRows-t.maxLines))
 		// Implement smooth transition to targetScrollOffset
 		scrollSpeed := 1 // Adjust this value for faster or slower scrolling
 		if t.scrollOffset < targetScrollOffset {
@@ -154,6 +168,10 @@ func (t *TextArea) Update() error {
 		}
 	}
 
+	// Keep the rows in sync with the edits made during this frame
+	t.refreshLayout()
+	t.updateBracketMatch()
+
 	t.counter++
 	return nil
 }
@@ -0,0 +1,161 @@
+package widgets
+
+import "strings"
+
+// visualRow is one rendered row of the text area.
+// Without soft wrap every logical line maps to exactly one row,
+// with soft wrap a long line is split into several rows.
+// start and end are byte offsets into t.text.
+type visualRow struct {
+	line       int
+	lineStart  int
+	start, end int
+	lastOfLine bool
+}
+
+// refreshLayout re-splits the text and rebuilds the visual rows
+// when the text or the available wrap width changed.
+func (t *TextArea) refreshLayout() {
+	if t.isTextChanged {
+		t.cachedLines = strings.Split(t.text, "\n")
+		t.isTextChanged = false
+		t.isRowsDirty = true
+		t.isTokensDirty = true
+	}
+
+	if t.isTokensDirty {
+		t.updateTokens()
+	}
+
+	if t.softWrap && t.wrapWidth() != t.rowsWidth {
+		// The widget was resized
+		t.isRowsDirty = true
+	}
+
+	if t.isRowsDirty {
+		t.rebuildVisualRows()
+	}
+}
+
+// wrapWidth returns the width available to the text of a single row
+func (t *TextArea) wrapWidth() float64 {
+	return float64(t.w - t.gutterWidth() - t.paddingLeft*2 - t.scrollbarWidth)
+}
+
+func (t *TextArea) rebuildVisualRows() {
+	maxWidth := t.wrapWidth()
+	t.visualRows = t.visualRows[:0]
+
+	lineStart := 0
+	for i, line := range t.cachedLines {
+		if t.softWrap && maxWidth > 0 {
+			t.visualRows = append(t.visualRows, t.wrapLine(i, lineStart, line, maxWidth)...)
+		} else {
+			t.visualRows = append(t.visualRows, visualRow{
+				line:       i,
+				lineStart:  lineStart,
+				start:      lineStart,
+				end:        lineStart + len(line),
+				lastOfLine: true,
+			})
+		}
+		lineStart += len(line) + 1
+	}
+
+	t.rowsWidth = maxWidth
+	t.isRowsDirty = false
+
+	// The number of rows may have shrunk
+	maxScrollOffset := max(0, len(t.visualRows)-t.maxLines)
+	t.SetScrollOffset(clamp(t.scrollOffset, 0, maxScrollOffset))
+}
+
+// wrapLine splits a logical line into rows no wider than maxWidth.
+// Lines are broken after the last space that fits, or in the middle
+// of a word when the word alone is wider than the row.
+func (t *TextArea) wrapLine(lineIndex, lineStart int, line string, maxWidth float64) []visualRow {
+	var rows []visualRow
+	segStart := 0
+	lastBreak := -1
+	width := 0.0
+
+	for i, char := range line {
+		charWidth := t.textWidth(string(char))
+		if width+charWidth > maxWidth && i > segStart {
+			breakAt := i
+			if lastBreak > segStart {
+				breakAt = lastBreak
+			}
+			rows = append(rows, visualRow{
+				line:      lineIndex,
+				lineStart: lineStart,
+				start:     lineStart + segStart,
+				end:       lineStart + breakAt,
+			})
+			segStart = breakAt
+			lastBreak = -1
+			width = t.textWidth(line[segStart:i])
+		}
+		width += charWidth
+		if char == ' ' || char == '\t' {
+			lastBreak = i + 1
+		}
+	}
+
+	rows = append(rows, visualRow{
+		line:       lineIndex,
+		lineStart:  lineStart,
+		start:      lineStart + segStart,
+		end:        lineStart + len(line),
+		lastOfLine: true,
+	})
+	return rows
+}
+
+// getRowForPos returns the index of the visual row holding the byte position.
+// A position sitting exactly on a wrap boundary belongs to the following row.
+func (t *TextArea) getRowForPos(pos int) int {
+	for i, row := range t.visualRows {
+		if pos < row.end || (pos == row.end && row.lastOfLine) {
+			return i
+		}
+	}
+	return len(t.visualRows) - 1
+}
+
+// getRowEndPos returns the last position the cursor can take on a row.
+// For a wrapped row the trailing space or tab the line was broken on is skipped,
+// so the cursor stays on the row instead of jumping to the next one.
+func (t *TextArea) getRowEndPos(rowIndex int) int {
+	row := t.visualRows[rowIndex]
+	if !row.lastOfLine && row.end > row.start && row.end <= len(t.text) {
+		if c := t.text[row.end-1]; c == ' ' || c == '\t' {
+			return row.end - 1
+		}
+	}
+	return row.end
+}
+
+// getCharPosFromRowAndCol converts a row and a byte column inside that row
+// to a position in the text, clamping the column to the row.
+func (t *TextArea) getCharPosFromRowAndCol(rowIndex, col int) int {
+	rowIndex = clamp(rowIndex, 0, len(t.visualRows)-1)
+	row := t.visualRows[rowIndex]
+	return clamp(row.start+col, row.start, t.getRowEndPos(rowIndex))
+}
+
+// getCursorRowAndColForPos is the visual row equivalent of getCursorLineAndColForPos
+func (t *TextArea) getCursorRowAndColForPos(pos int) (int, int) {
+	rowIndex := t.getRowForPos(pos)
+	return rowIndex, pos - t.visualRows[rowIndex].start
+}
+
+func (t *TextArea) getRowText(row visualRow) string {
+	start := clamp(row.start, 0, len(t.text))
+	end := clamp(row.end, start, len(t.text))
+	return t.text[start:end]
+}
+
+func (t *TextArea) totalRows() int {
+	return len(t.visualRows)
+}